@@ -0,0 +1,297 @@
+package tview
+
+import (
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// chartBar is one bar of a [BarChart].
+type chartBar struct {
+	label string
+	value float64
+	color tcell.Color
+}
+
+// BarChart shows a number of labeled values as bars, drawn with block elements
+// for a resolution of one eighth of a cell. Bars are vertical by default and
+// horizontal if [BarChart.SetHorizontal] is set. Their length is scaled
+// automatically such that the largest value fills the available space, unless
+// a fixed maximum value is set with [BarChart.SetMax]. Negative values are
+// shown as empty bars.
+//
+// All functions of the bar chart may be called from any goroutine. Changes do
+// not cause an automatic redraw, so call [Application.Draw] afterwards.
+type BarChart struct {
+	sync.Mutex
+	*Box
+
+	// The bars, in the order in which they were added.
+	bars []*chartBar
+
+	// Whether the bars are horizontal.
+	horizontal bool
+
+	// The thickness of each bar. A value of 0 means 3 cells for vertical bars
+	// and 1 cell for horizontal bars.
+	barWidth int
+
+	// The space between two bars.
+	gap int
+
+	// The value corresponding to a bar filling the available space. A value of
+	// 0 means the largest value of all bars.
+	max float64
+
+	// Whether the values are shown next to the bars.
+	showValues bool
+
+	// An optional function which formats the values shown next to the bars.
+	format func(value float64) string
+
+	// The style of the labels and values.
+	labelStyle tcell.Style
+}
+
+// NewBarChart returns a new bar chart without any bars.
+func NewBarChart() *BarChart {
+	c := &BarChart{
+		Box:        NewBox(),
+		gap:        1,
+		showValues: true,
+		labelStyle: tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+	}
+	c.Box.Primitive = c
+	return c
+}
+
+// AddBar adds a bar with the given label and value, drawn in the given color.
+// If the color is [tcell.ColorDefault], a color of the current [Theme] is
+// chosen. The label may contain style tags.
+func (c *BarChart) AddBar(label string, value float64, color tcell.Color) *BarChart {
+	c.Lock()
+	defer c.Unlock()
+	c.bars = append(c.bars, &chartBar{
+		label: label,
+		value: value,
+		color: chartColor(color, len(c.bars)),
+	})
+	return c
+}
+
+// SetValue sets the value of the bar with the given index. Indices outside the
+// valid range are ignored.
+func (c *BarChart) SetValue(index int, value float64) *BarChart {
+	c.Lock()
+	defer c.Unlock()
+	if index >= 0 && index < len(c.bars) {
+		c.bars[index].value = value
+	}
+	return c
+}
+
+// GetValue returns the value of the bar with the given index, or 0 if there is
+// no such bar.
+func (c *BarChart) GetValue(index int) float64 {
+	c.Lock()
+	defer c.Unlock()
+	if index < 0 || index >= len(c.bars) {
+		return 0
+	}
+	return c.bars[index].value
+}
+
+// GetBarCount returns the number of bars in the chart.
+func (c *BarChart) GetBarCount() int {
+	c.Lock()
+	defer c.Unlock()
+	return len(c.bars)
+}
+
+// ClearBars removes all bars from the chart.
+func (c *BarChart) ClearBars() *BarChart {
+	c.Lock()
+	defer c.Unlock()
+	c.bars = nil
+	return c
+}
+
+// SetHorizontal sets whether the bars are drawn horizontally, from left to
+// right with their labels on the left, instead of vertically, from bottom to
+// top with their labels below them.
+func (c *BarChart) SetHorizontal(horizontal bool) *BarChart {
+	c.Lock()
+	defer c.Unlock()
+	c.horizontal = horizontal
+	return c
+}
+
+// SetBarWidth sets the thickness of each bar in cells. A value of 0 (the
+// default) results in 3 cells for vertical bars and 1 cell for horizontal
+// bars.
+func (c *BarChart) SetBarWidth(width int) *BarChart {
+	c.Lock()
+	defer c.Unlock()
+	c.barWidth = max(width, 0)
+	return c
+}
+
+// SetGap sets the number of cells between two bars. The default is 1.
+func (c *BarChart) SetGap(gap int) *BarChart {
+	c.Lock()
+	defer c.Unlock()
+	c.gap = max(gap, 0)
+	return c
+}
+
+// SetMax sets the value of a bar which fills the available space. Larger
+// values are clipped. A value of 0 (the default) causes the bars to be scaled
+// such that the largest value fills the available space.
+func (c *BarChart) SetMax(maxValue float64) *BarChart {
+	c.Lock()
+	defer c.Unlock()
+	c.max = max(maxValue, 0)
+	return c
+}
+
+// SetShowValues sets whether the values are shown above vertical bars or to the
+// right of horizontal bars. They are shown by default.
+func (c *BarChart) SetShowValues(show bool) *BarChart {
+	c.Lock()
+	defer c.Unlock()
+	c.showValues = show
+	return c
+}
+
+// SetFormatFunc sets a function which formats the values shown next to the
+// bars. By default, values are shown with as few decimals as necessary.
+func (c *BarChart) SetFormatFunc(handler func(value float64) string) *BarChart {
+	c.Lock()
+	defer c.Unlock()
+	c.format = handler
+	return c
+}
+
+// SetLabelStyle sets the style of the labels and values.
+func (c *BarChart) SetLabelStyle(style tcell.Style) *BarChart {
+	c.Lock()
+	defer c.Unlock()
+	c.labelStyle = style
+	return c
+}
+
+// formatValue returns the text shown next to a bar.
+func (c *BarChart) formatValue(value float64) string {
+	if c.format != nil {
+		return c.format(value)
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// Draw draws this primitive onto the screen.
+func (c *BarChart) Draw(screen tcell.Screen) {
+	c.Box.DrawForSubclass(screen, c)
+
+	c.Lock()
+	defer c.Unlock()
+
+	x, y, width, height := c.GetInnerRect()
+	if width <= 0 || height <= 0 || len(c.bars) == 0 {
+		return
+	}
+
+	// Determine the scale.
+	maxValue := c.max
+	if maxValue <= 0 {
+		for _, bar := range c.bars {
+			maxValue = max(maxValue, bar.value)
+		}
+		if maxValue <= 0 {
+			maxValue = 1
+		}
+	}
+	eighths := func(value float64, cells int) int {
+		if math.IsNaN(value) {
+			return 0
+		}
+		return int(math.Round(min(max(value, 0), maxValue) / maxValue * float64(cells*8)))
+	}
+	style := tcell.StyleDefault.Background(c.backgroundColor)
+
+	if c.horizontal {
+		thickness := c.barWidth
+		if thickness == 0 {
+			thickness = 1
+		}
+
+		// Calculate the widths of the labels and values.
+		var labelWidth, valueWidth int
+		for _, bar := range c.bars {
+			labelWidth = max(labelWidth, TaggedStringWidth(bar.label))
+			if c.showValues {
+				valueWidth = max(valueWidth, TaggedStringWidth(c.formatValue(bar.value))+1)
+			}
+		}
+		if labelWidth > 0 {
+			labelWidth = min(labelWidth, width/3)
+			labelWidth++
+		}
+		barLength := width - labelWidth - valueWidth
+		if barLength <= 0 {
+			return
+		}
+
+		// Draw the bars.
+		for index, bar := range c.bars {
+			barY := y + index*(thickness+c.gap)
+			if barY+thickness > y+height {
+				break
+			}
+			length := eighths(bar.value, barLength)
+			drawHorizontalBar(screen, x+labelWidth, barY, thickness, length, style.Foreground(bar.color))
+			printWithStyle(screen, bar.label, x, barY+thickness/2, 0, labelWidth-1, AlignRight, c.labelStyle, true)
+			if c.showValues {
+				printWithStyle(screen, c.formatValue(bar.value), x+labelWidth+(length+7)/8+1, barY+thickness/2, 0, valueWidth-1, AlignLeft, c.labelStyle, true)
+			}
+		}
+		return
+	}
+
+	// Vertical bars. Reserve one row for the labels and one for the values.
+	thickness := c.barWidth
+	if thickness == 0 {
+		thickness = 3
+	}
+	barHeight := height
+	for _, bar := range c.bars {
+		if bar.label != "" {
+			barHeight--
+			break
+		}
+	}
+	bottom := y + barHeight - 1
+	if c.showValues {
+		barHeight--
+	}
+	if barHeight <= 0 {
+		return
+	}
+
+	// Draw the bars.
+	for index, bar := range c.bars {
+		barX := x + index*(thickness+c.gap)
+		if barX+thickness > x+width {
+			break
+		}
+		length := eighths(bar.value, barHeight)
+		drawVerticalBar(screen, barX, bottom, thickness, length, style.Foreground(bar.color))
+		if bottom+1 < y+height {
+			printWithStyle(screen, bar.label, barX, bottom+1, 0, thickness, AlignCenter, c.labelStyle, true)
+		}
+		if c.showValues {
+			printWithStyle(screen, c.formatValue(bar.value), barX, bottom-(length+7)/8, 0, thickness, AlignCenter, c.labelStyle, true)
+		}
+	}
+}
@@ -0,0 +1,474 @@
+package tview
+
+import (
+	"image"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Canvas pixel modes. See [Canvas.SetMode].
+const (
+	CanvasBraille    = iota // Braille patterns with 2x4 pixels per cell (the default).
+	CanvasHalfBlocks        // Half blocks with 1x2 pixels per cell.
+)
+
+// canvasLabel is a text drawn on top of a [Canvas].
+type canvasLabel struct {
+	x, y  int // The pixel position.
+	text  string
+	color tcell.Color
+}
+
+// Canvas is a primitive which provides a grid of pixels smaller than a cell. In
+// the default [CanvasBraille] mode, each cell holds 2x4 pixels, drawn with
+// braille patterns. In [CanvasHalfBlocks] mode, each cell holds 1x2 pixels,
+// drawn with half block elements. Pixel coordinates start with (0, 0) in the
+// top-left corner of the canvas's inner rectangle. See [Canvas.GetPixelSize]
+// for the number of pixels currently visible.
+//
+// The canvas retains what was drawn onto it. Drawing functions such as
+// [Canvas.SetPixel], [Canvas.DrawLine], [Canvas.DrawCircle], or
+// [Canvas.FillPolygon] set pixels to a color until they are overwritten or
+// cleared (see [Canvas.Clear]). Pixels outside the visible area are retained
+// but not shown. Text labels may be placed on the canvas with
+// [Canvas.DrawText].
+//
+// A cell drawn with braille patterns can only have one color. If its pixels
+// have different colors, the colors are blended. In half block mode, the upper
+// and the lower pixel of a cell keep their own colors.
+//
+// Mouse events may be received in pixel coordinates with
+// [Canvas.SetMouseFunc]. All functions of the canvas may be called from any
+// goroutine. Changes do not cause an automatic redraw, so call
+// [Application.Draw] afterwards.
+type Canvas struct {
+	sync.Mutex
+	*Box
+
+	// The pixel mode, one of the Canvas constants.
+	mode int
+
+	// The colors of all pixels which are set.
+	pixels map[image.Point]tcell.Color
+
+	// The text labels, in the order in which they were added.
+	labels []canvasLabel
+
+	// An optional function which is called for mouse events, with the
+	// coordinates of the pixel under the mouse cursor.
+	mouse func(action MouseAction, x, y int) bool
+}
+
+// NewCanvas returns a new, empty canvas.
+func NewCanvas() *Canvas {
+	c := &Canvas{
+		Box:    NewBox(),
+		pixels: make(map[image.Point]tcell.Color),
+	}
+	c.Box.Primitive = c
+	return c
+}
+
+// SetMode sets the pixel mode of the canvas, either [CanvasBraille] or
+// [CanvasHalfBlocks]. Existing pixels keep their coordinates.
+func (c *Canvas) SetMode(mode int) *Canvas {
+	c.Lock()
+	defer c.Unlock()
+	c.mode = mode
+	return c
+}
+
+// GetMode returns the pixel mode of the canvas.
+func (c *Canvas) GetMode() int {
+	c.Lock()
+	defer c.Unlock()
+	return c.mode
+}
+
+// cellSize returns the number of pixels per cell, horizontally and vertically.
+func (c *Canvas) cellSize() (width, height int) {
+	if c.mode == CanvasHalfBlocks {
+		return 1, 2
+	}
+	return 2, 4
+}
+
+// GetPixelSize returns the number of pixels which fit into the canvas's inner
+// rectangle, horizontally and vertically.
+func (c *Canvas) GetPixelSize() (width, height int) {
+	c.Lock()
+	defer c.Unlock()
+	return c.pixelSize()
+}
+
+// pixelSize implements [Canvas.GetPixelSize] without locking.
+func (c *Canvas) pixelSize() (width, height int) {
+	_, _, width, height = c.GetInnerRect()
+	cellWidth, cellHeight := c.cellSize()
+	return max(width, 0) * cellWidth, max(height, 0) * cellHeight
+}
+
+// Clear removes all pixels and labels from the canvas.
+func (c *Canvas) Clear() *Canvas {
+	c.Lock()
+	defer c.Unlock()
+	clear(c.pixels)
+	c.labels = nil
+	return c
+}
+
+// SetPixel sets the pixel at the given coordinates to the given color.
+func (c *Canvas) SetPixel(x, y int, color tcell.Color) *Canvas {
+	c.Lock()
+	defer c.Unlock()
+	c.pixels[image.Point{x, y}] = color
+	return c
+}
+
+// GetPixel returns the color of the pixel at the given coordinates and whether
+// that pixel is set.
+func (c *Canvas) GetPixel(x, y int) (color tcell.Color, ok bool) {
+	c.Lock()
+	defer c.Unlock()
+	color, ok = c.pixels[image.Point{x, y}]
+	return
+}
+
+// ClearPixel unsets the pixel at the given coordinates.
+func (c *Canvas) ClearPixel(x, y int) *Canvas {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.pixels, image.Point{x, y})
+	return c
+}
+
+// line sets the pixels of a straight line between the two given points.
+func (c *Canvas) line(x0, y0, x1, y1 int, color tcell.Color) {
+	bresenham(x0, y0, x1, y1, func(x, y int) {
+		c.pixels[image.Point{x, y}] = color
+	})
+}
+
+// DrawLine draws a straight line between the two given points.
+func (c *Canvas) DrawLine(x0, y0, x1, y1 int, color tcell.Color) *Canvas {
+	c.Lock()
+	defer c.Unlock()
+	c.line(x0, y0, x1, y1, color)
+	return c
+}
+
+// DrawRect draws the outline of a rectangle with the given top-left corner,
+// width, and height, in pixels.
+func (c *Canvas) DrawRect(x, y, width, height int, color tcell.Color) *Canvas {
+	c.Lock()
+	defer c.Unlock()
+	if width <= 0 || height <= 0 {
+		return c
+	}
+	right, bottom := x+width-1, y+height-1
+	c.line(x, y, right, y, color)
+	c.line(x, bottom, right, bottom, color)
+	c.line(x, y, x, bottom, color)
+	c.line(right, y, right, bottom, color)
+	return c
+}
+
+// FillRect draws a filled rectangle with the given top-left corner, width, and
+// height, in pixels.
+func (c *Canvas) FillRect(x, y, width, height int, color tcell.Color) *Canvas {
+	c.Lock()
+	defer c.Unlock()
+	for row := y; row < y+height; row++ {
+		for column := x; column < x+width; column++ {
+			c.pixels[image.Point{column, row}] = color
+		}
+	}
+	return c
+}
+
+// DrawCircle draws the outline of a circle with the given center and radius,
+// in pixels.
+func (c *Canvas) DrawCircle(centerX, centerY, radius int, color tcell.Color) *Canvas {
+	c.Lock()
+	defer c.Unlock()
+	if radius < 0 {
+		return c
+	}
+
+	// Midpoint circle algorithm.
+	x, y, err := radius, 0, 1-radius
+	for x >= y {
+		for _, point := range [8][2]int{{x, y}, {y, x}, {-y, x}, {-x, y}, {-x, -y}, {-y, -x}, {y, -x}, {x, -y}} {
+			c.pixels[image.Point{centerX + point[0], centerY + point[1]}] = color
+		}
+		y++
+		if err < 0 {
+			err += 2*y + 1
+		} else {
+			x--
+			err += 2*(y-x) + 1
+		}
+	}
+	return c
+}
+
+// FillCircle draws a filled circle with the given center and radius, in
+// pixels.
+func (c *Canvas) FillCircle(centerX, centerY, radius int, color tcell.Color) *Canvas {
+	c.Lock()
+	defer c.Unlock()
+	for dy := -radius; dy <= radius; dy++ {
+		dx := int(math.Sqrt(float64(radius*radius - dy*dy)))
+		for x := centerX - dx; x <= centerX+dx; x++ {
+			c.pixels[image.Point{x, centerY + dy}] = color
+		}
+	}
+	return c
+}
+
+// DrawPolygon draws the outline of a polygon with the given corners. The last
+// corner is connected to the first one.
+func (c *Canvas) DrawPolygon(points []image.Point, color tcell.Color) *Canvas {
+	c.Lock()
+	defer c.Unlock()
+	for index, point := range points {
+		next := points[(index+1)%len(points)]
+		c.line(point.X, point.Y, next.X, next.Y, color)
+	}
+	return c
+}
+
+// FillPolygon draws a filled polygon with the given corners. Pixels are filled
+// if their center is inside the polygon according to the even-odd rule. The
+// polygon's outline is always filled.
+func (c *Canvas) FillPolygon(points []image.Point, color tcell.Color) *Canvas {
+	c.Lock()
+	defer c.Unlock()
+	if len(points) == 0 {
+		return c
+	}
+
+	// Fill the inside, one row at a time.
+	top, bottom := points[0].Y, points[0].Y
+	for _, point := range points {
+		top, bottom = min(top, point.Y), max(bottom, point.Y)
+	}
+	var crossings []float64
+	for y := top; y <= bottom; y++ {
+		center := float64(y) + 0.5
+		crossings = crossings[:0]
+		for index, from := range points {
+			to := points[(index+1)%len(points)]
+			fromY, toY := float64(from.Y)+0.5, float64(to.Y)+0.5
+			if (fromY <= center) == (toY <= center) {
+				continue // The edge doesn't cross this row.
+			}
+			fromX, toX := float64(from.X)+0.5, float64(to.X)+0.5
+			crossings = append(crossings, fromX+(center-fromY)/(toY-fromY)*(toX-fromX))
+		}
+		sort.Float64s(crossings)
+		for index := 0; index+1 < len(crossings); index += 2 {
+			for x := int(math.Ceil(crossings[index] - 0.5)); float64(x)+0.5 <= crossings[index+1]; x++ {
+				c.pixels[image.Point{x, y}] = color
+			}
+		}
+	}
+
+	// Draw the outline.
+	for index, point := range points {
+		next := points[(index+1)%len(points)]
+		c.line(point.X, point.Y, next.X, next.Y, color)
+	}
+	return c
+}
+
+// FloodFill sets the pixel at the given coordinates and all pixels connected
+// to it (horizontally or vertically) which have the same color, or which are
+// all unset, to the given color. The fill is limited to the visible area (see
+// [Canvas.GetPixelSize]).
+func (c *Canvas) FloodFill(x, y int, color tcell.Color) *Canvas {
+	c.Lock()
+	defer c.Unlock()
+	width, height := c.pixelSize()
+	if x < 0 || y < 0 || x >= width || y >= height {
+		return c
+	}
+	target, targetSet := c.pixels[image.Point{x, y}]
+	if targetSet && target == color {
+		return c
+	}
+	stack := []image.Point{{x, y}}
+	for len(stack) > 0 {
+		point := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if point.X < 0 || point.Y < 0 || point.X >= width || point.Y >= height {
+			continue
+		}
+		if existing, ok := c.pixels[point]; ok != targetSet || ok && existing != target {
+			continue
+		}
+		c.pixels[point] = color
+		stack = append(stack,
+			image.Point{point.X + 1, point.Y},
+			image.Point{point.X - 1, point.Y},
+			image.Point{point.X, point.Y + 1},
+			image.Point{point.X, point.Y - 1})
+	}
+	return c
+}
+
+// DrawText places a text label on the canvas. It is drawn in the cell which
+// contains the pixel at the given coordinates, in the given color, on top of
+// all pixels. The text may contain style tags. Labels are removed with
+// [Canvas.Clear].
+func (c *Canvas) DrawText(x, y int, text string, color tcell.Color) *Canvas {
+	c.Lock()
+	defer c.Unlock()
+	c.labels = append(c.labels, canvasLabel{x: x, y: y, text: text, color: color})
+	return c
+}
+
+// SetMouseFunc sets a function which is called for mouse events over the
+// canvas's inner rectangle. It receives the mouse action and the coordinates
+// of the pixel at the top-left corner of the cell under the mouse cursor. If
+// the function returns true, the event is consumed.
+//
+// The function is called while the canvas is not locked so it may draw onto
+// the canvas.
+func (c *Canvas) SetMouseFunc(handler func(action MouseAction, x, y int) bool) *Canvas {
+	c.Lock()
+	defer c.Unlock()
+	c.mouse = handler
+	return c
+}
+
+// PixelAt returns the coordinates of the pixel at the top-left corner of the
+// cell at the given screen coordinates. If the screen coordinates are outside
+// the canvas's inner rectangle, ok is false.
+func (c *Canvas) PixelAt(screenX, screenY int) (x, y int, ok bool) {
+	c.Lock()
+	defer c.Unlock()
+	rectX, rectY, width, height := c.GetInnerRect()
+	if screenX < rectX || screenY < rectY || screenX >= rectX+width || screenY >= rectY+height {
+		return 0, 0, false
+	}
+	cellWidth, cellHeight := c.cellSize()
+	return (screenX - rectX) * cellWidth, (screenY - rectY) * cellHeight, true
+}
+
+// Draw draws this primitive onto the screen.
+func (c *Canvas) Draw(screen tcell.Screen) {
+	c.Box.DrawForSubclass(screen, c)
+
+	c.Lock()
+	defer c.Unlock()
+
+	x, y, width, height := c.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	cellWidth, cellHeight := c.cellSize()
+
+	// Collect the pixels of each cell.
+	type cell struct {
+		dots               uint8         // Braille pattern bits.
+		colors             []tcell.Color // Braille pixel colors.
+		upper, lower       tcell.Color   // Half block colors.
+		upperSet, lowerSet bool          // Whether the half blocks are set.
+	}
+	cells := make([]cell, width*height)
+	for point, color := range c.pixels {
+		if point.X < 0 || point.Y < 0 || point.X >= width*cellWidth || point.Y >= height*cellHeight {
+			continue
+		}
+		cl := &cells[point.Y/cellHeight*width+point.X/cellWidth]
+		if c.mode == CanvasHalfBlocks {
+			if point.Y%2 == 0 {
+				cl.upper, cl.upperSet = color, true
+			} else {
+				cl.lower, cl.lowerSet = color, true
+			}
+			continue
+		}
+		cl.dots |= brailleDots[point.Y%4][point.X%2]
+		cl.colors = append(cl.colors, color)
+	}
+
+	// Draw the cells.
+	background := tcell.StyleDefault.Background(c.backgroundColor)
+	for index, cl := range cells {
+		cellX, cellY := x+index%width, y+index/width
+		switch {
+		case c.mode == CanvasHalfBlocks && cl.upperSet && cl.lowerSet:
+			screen.SetContent(cellX, cellY, BlockUpperHalfBlock, nil, tcell.StyleDefault.Foreground(cl.upper).Background(cl.lower))
+		case c.mode == CanvasHalfBlocks && cl.upperSet:
+			screen.SetContent(cellX, cellY, BlockUpperHalfBlock, nil, background.Foreground(cl.upper))
+		case c.mode == CanvasHalfBlocks && cl.lowerSet:
+			screen.SetContent(cellX, cellY, BlockLowerHalfBlock, nil, background.Foreground(cl.lower))
+		case cl.dots != 0:
+			screen.SetContent(cellX, cellY, rune(0x2800+int(cl.dots)), nil, background.Foreground(blendColors(cl.colors)))
+		}
+	}
+
+	// Draw the labels.
+	for _, label := range c.labels {
+		if label.x < 0 || label.y < 0 {
+			continue
+		}
+		cellX, cellY := label.x/cellWidth, label.y/cellHeight
+		if cellX >= width || cellY >= height {
+			continue
+		}
+		printWithStyle(screen, label.text, x+cellX, y+cellY, 0, width-cellX, AlignLeft, tcell.StyleDefault.Foreground(label.color), true)
+	}
+}
+
+// blendColors returns the average of the given colors. If the colors are not
+// all the same and one of them cannot be represented as RGB (e.g. the default
+// color), the first color is returned.
+func blendColors(colors []tcell.Color) tcell.Color {
+	same := true
+	for _, color := range colors {
+		if color != colors[0] {
+			same = false
+			break
+		}
+	}
+	if same {
+		return colors[0]
+	}
+	var red, green, blue int32
+	for _, color := range colors {
+		r, g, b := color.RGB()
+		if r < 0 {
+			return colors[0]
+		}
+		red, green, blue = red+r, green+g, blue+b
+	}
+	count := int32(len(colors))
+	return tcell.NewRGBColor(red/count, green/count, blue/count)
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (c *Canvas) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return c.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		x, y, ok := c.PixelAt(event.Position())
+		if !ok {
+			return false, nil
+		}
+		if action == MouseLeftDown {
+			setFocus(c)
+			consumed = true
+		}
+		c.Lock()
+		handler := c.mouse
+		c.Unlock()
+		if handler != nil && handler(action, x, y) {
+			consumed = true
+		}
+		return
+	})
+}
@@ -0,0 +1,285 @@
+package tview
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// chartColors returns the colors used for chart series and bars which were not
+// given an explicit color, derived from the current [Theme].
+func chartColors() []tcell.Color {
+	return []tcell.Color{
+		Styles.TertiaryTextColor,
+		Styles.SecondaryTextColor,
+		Styles.ContrastBackgroundColor,
+		Styles.PrimaryTextColor,
+		Styles.MoreContrastBackgroundColor,
+		Styles.ContrastSecondaryTextColor,
+	}
+}
+
+// chartColor returns the given color or, if it is the default color, the
+// palette color for the given index.
+func chartColor(color tcell.Color, index int) tcell.Color {
+	if color != tcell.ColorDefault {
+		return color
+	}
+	colors := chartColors()
+	return colors[index%len(colors)]
+}
+
+// chartScale extends the range from low to high to "nice" boundaries and
+// returns the extended range together with the distance between roughly
+// "count" ticks on that range and the number of decimals needed to print them.
+func chartScale(low, high float64, count int) (newLow, newHigh, step float64, decimals int) {
+	if math.IsNaN(low) || math.IsNaN(high) || math.IsInf(low, 0) || math.IsInf(high, 0) {
+		low, high = 0, 1
+	}
+	if high <= low {
+		if low == 0 {
+			high = 1
+		} else {
+			low, high = low-math.Abs(low)/2, high+math.Abs(high)/2
+		}
+	}
+	rawStep := (high - low) / float64(max(count-1, 1))
+	magnitude := math.Pow(10, math.Floor(math.Log10(rawStep)))
+	for _, multiple := range []float64{1, 2, 2.5, 5, 10} {
+		step = multiple * magnitude
+		if step >= rawStep {
+			break
+		}
+	}
+	decimals = max(0, -int(math.Floor(math.Log10(step)+1e-9)))
+	if scaled := step * math.Pow(10, float64(decimals)); math.Abs(scaled-math.Round(scaled)) > 1e-9 {
+		decimals++ // For steps such as 0.25.
+	}
+	newLow = math.Floor(low/step+1e-9) * step
+	newHigh = math.Ceil(high/step-1e-9) * step
+	return
+}
+
+// chartTicks returns the tick values on the range from low to high which are
+// multiples of step.
+func chartTicks(low, high, step float64) (ticks []float64) {
+	for index := math.Ceil(low/step - 1e-9); index*step <= high+step*1e-9; index++ {
+		ticks = append(ticks, index*step)
+	}
+	return
+}
+
+// chartFormat formats a value with the given number of decimals.
+func chartFormat(value float64, decimals int) string {
+	if math.Abs(value) < math.Pow(10, -float64(decimals))/2 {
+		value = 0 // Avoid "-0".
+	}
+	return strconv.FormatFloat(value, 'f', decimals, 64)
+}
+
+// chartRange returns the minimum and maximum of the given values, ignoring NaN
+// values. If there are no such values, ok is false.
+func chartRange(values []float64) (low, high float64, ok bool) {
+	for _, value := range values {
+		if math.IsNaN(value) {
+			continue
+		}
+		if !ok {
+			low, high, ok = value, value, true
+			continue
+		}
+		low, high = min(low, value), max(high, value)
+	}
+	return
+}
+
+// chartAppend appends values to a slice, discarding the oldest values such that
+// at most "window" values remain if window is positive. It returns the new
+// slice and the number of discarded values.
+func chartAppend(values []float64, window int, add ...float64) ([]float64, int) {
+	values = append(values, add...)
+	if window <= 0 || len(values) <= window {
+		return values, 0
+	}
+	dropped := len(values) - window
+	return append(values[:0], values[dropped:]...), dropped
+}
+
+// brailleDots maps the pixel positions within a braille cell (two columns,
+// four rows) to the bits of the braille pattern.
+var brailleDots = [4][2]uint8{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// brailleGrid is a grid of pixels drawn with braille patterns, two pixels wide
+// and four pixels high per cell. Each cell has a single color, the color of
+// the pixel set last in that cell.
+type brailleGrid struct {
+	width, height int           // The size in cells.
+	dots          []uint8       // The braille pattern bits of each cell.
+	colors        []tcell.Color // The color of each cell.
+}
+
+// newBrailleGrid returns a new, empty grid of the given size in cells.
+func newBrailleGrid(width, height int) *brailleGrid {
+	return &brailleGrid{
+		width:  width,
+		height: height,
+		dots:   make([]uint8, width*height),
+		colors: make([]tcell.Color, width*height),
+	}
+}
+
+// set sets the pixel at the given pixel coordinates. Pixels outside the grid
+// are ignored.
+func (g *brailleGrid) set(x, y int, color tcell.Color) {
+	if x < 0 || y < 0 || x >= g.width*2 || y >= g.height*4 {
+		return
+	}
+	cell := y/4*g.width + x/2
+	g.dots[cell] |= brailleDots[y%4][x%2]
+	g.colors[cell] = color
+}
+
+// line sets the pixels of a straight line between the two given pixel
+// coordinates.
+func (g *brailleGrid) line(x0, y0, x1, y1 int, color tcell.Color) {
+	bresenham(x0, y0, x1, y1, func(x, y int) {
+		g.set(x, y, color)
+	})
+}
+
+// bresenham calls the "set" function for each point of a straight line between
+// the two given points, using Bresenham's algorithm.
+func bresenham(x0, y0, x1, y1 int, set func(x, y int)) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		set(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		if e2 := 2 * err; e2 >= dy {
+			err += dy
+			x0 += sx
+		} else {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// draw draws the grid's non-empty cells onto the screen at the given position,
+// using the given style with each cell's color as the foreground color.
+func (g *brailleGrid) draw(screen tcell.Screen, x, y int, style tcell.Style) {
+	for row := range g.height {
+		for column := range g.width {
+			cell := row*g.width + column
+			if g.dots[cell] == 0 {
+				continue
+			}
+			screen.SetContent(x+column, y+row, rune(0x2800+int(g.dots[cell])), nil, style.Foreground(g.colors[cell]))
+		}
+	}
+}
+
+// abs returns the absolute value of an integer.
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// chartLowerBlocks contains the block elements used to draw partially filled
+// cells of vertical bars, indexed by the number of filled eighths.
+var chartLowerBlocks = [9]rune{
+	' ',
+	BlockLowerOneEighthBlock,
+	BlockLowerOneQuarterBlock,
+	BlockLowerThreeEighthsBlock,
+	BlockLowerHalfBlock,
+	BlockLowerFiveEighthsBlock,
+	BlockLowerThreeQuartersBlock,
+	BlockLowerSevenEighthsBlock,
+	BlockFullBlock,
+}
+
+// drawVerticalBar draws a bar of the given width upwards from the given bottom
+// row. Its length is given in eighths of a cell.
+func drawVerticalBar(screen tcell.Screen, x, bottom, width, eighths int, style tcell.Style) {
+	for row := 0; eighths > 0; row++ {
+		ch := chartLowerBlocks[min(eighths, 8)]
+		for column := range width {
+			screen.SetContent(x+column, bottom-row, ch, nil, style)
+		}
+		eighths -= 8
+	}
+}
+
+// drawHorizontalBar draws a bar of the given height rightwards from the given
+// column. Its length is given in eighths of a cell.
+func drawHorizontalBar(screen tcell.Screen, left, y, height, eighths int, style tcell.Style) {
+	for column := 0; eighths > 0; column++ {
+		ch := BlockFullBlock
+		if eighths < 8 {
+			ch = progressBarEighths[eighths]
+		}
+		for row := range height {
+			screen.SetContent(left+column, y+row, ch, nil, style)
+		}
+		eighths -= 8
+	}
+}
+
+// drawChartYAxis draws a vertical axis with the given tick labels at the given
+// position. The labels are right-aligned to the left of the axis which is drawn
+// in the column x+labelWidth, from row y down to the row y+height-1. The "row"
+// function returns the row offset of a tick.
+func drawChartYAxis(screen tcell.Screen, x, y, height, labelWidth int, ticks []float64, labels []string, row func(value float64) int, axisStyle, labelStyle tcell.Style) {
+	for offset := range height {
+		screen.SetContent(x+labelWidth, y+offset, Borders.Vertical, nil, axisStyle)
+	}
+	for index, tick := range ticks {
+		offset := row(tick)
+		if offset < 0 || offset >= height {
+			continue
+		}
+		screen.SetContent(x+labelWidth, y+offset, Borders.RightT, nil, axisStyle)
+		printWithStyle(screen, labels[index], x, y+offset, 0, labelWidth, AlignRight, labelStyle, true)
+	}
+}
+
+// drawChartXAxis draws a horizontal axis in row y, from column x to the column
+// x+width-1, and the given tick labels in the row below, centered on the given
+// column offsets. Labels which would overlap a previous label are skipped.
+func drawChartXAxis(screen tcell.Screen, x, y, width int, positions []int, labels []string, axisStyle, labelStyle tcell.Style) {
+	for offset := range width {
+		screen.SetContent(x+offset, y, Borders.Horizontal, nil, axisStyle)
+	}
+	next := 0 // The first column offset available for a label.
+	for index, position := range positions {
+		if position < 0 || position >= width {
+			continue
+		}
+		screen.SetContent(x+position, y, Borders.TopT, nil, axisStyle)
+		labelWidth := TaggedStringWidth(labels[index])
+		start := min(max(position-labelWidth/2, 0), width-labelWidth)
+		if start < next || start < 0 {
+			continue
+		}
+		printWithStyle(screen, labels[index], x+start, y+1, 0, labelWidth, AlignLeft, labelStyle, true)
+		next = start + labelWidth + 1
+	}
+}
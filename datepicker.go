@@ -0,0 +1,735 @@
+package tview
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// The size of the calendar shown by a DatePicker, including a one-cell
+// padding on each side.
+const (
+	calendarWidth  = 22
+	calendarHeight = 10
+)
+
+// DatePicker is a form item which lets the user select a date or, in range
+// mode (see [DatePicker.SetRangeMode]), a range of dates from a calendar
+// which pops up below the field.
+//
+// The following keys are available when the calendar is closed:
+//
+//   - Enter, Down arrow, Space: Open the calendar.
+//   - Delete, Backspace: Clear the selected date.
+//   - Tab, Backtab, Escape: Done (see [DatePicker.SetDoneFunc]).
+//
+// The following keys are available when the calendar is open:
+//
+//   - Arrow keys: Move to the previous or next day or week.
+//   - Page Up, Page Down: Move to the previous or next month.
+//   - Shift-Page Up, Shift-Page Down: Move to the previous or next year.
+//   - Home, End: Move to the first or last day of the month.
+//   - t: Move to today.
+//   - Enter, Space: Select the highlighted day. In range mode, the first
+//     selected day is the start of the range, the second is its end.
+//   - Escape: Close the calendar without changing the selection.
+//
+// The calendar can also be operated with the mouse. The arrows in its header
+// navigate to the previous or next month or year.
+type DatePicker struct {
+	*Box
+
+	// Whether or not this date picker is disabled/read-only.
+	disabled bool
+
+	// The selected date, the start of the selected range in range mode. The
+	// zero value if no date is selected.
+	date time.Time
+
+	// The end of the selected range in range mode.
+	end time.Time
+
+	// Whether or not a range of dates is selected.
+	rangeMode bool
+
+	// The layout used to format dates (see [time.Time.Format]).
+	format string
+
+	// The text displayed when no date has been selected.
+	placeholder string
+
+	// The first day of the week shown in the calendar.
+	weekStart time.Weekday
+
+	// The earliest and latest date that can be selected. Zero values mean no
+	// limit.
+	minDate, maxDate time.Time
+
+	// An optional function which returns true for dates which cannot be
+	// selected.
+	isDisabled func(date time.Time) bool
+
+	// Set to true if the calendar is visible.
+	open bool
+
+	// The highlighted day in the calendar.
+	cursor time.Time
+
+	// In range mode, the start of the range being selected. The zero value if
+	// the user has not selected the start yet.
+	anchor time.Time
+
+	// The position of the calendar as determined during the last call to Draw.
+	calendarX, calendarY int
+
+	// The text to be displayed before the input area.
+	label string
+
+	// The screen width of the label area. A value of 0 means use the width of
+	// the label text.
+	labelWidth int
+
+	// The screen width of the input area. A value of 0 means use the width of
+	// a formatted date.
+	fieldWidth int
+
+	// Styles.
+	labelStyle, fieldStyle, focusedStyle, disabledStyle, placeholderStyle tcell.Style
+
+	// Calendar styles.
+	calendarStyle, cursorStyle, selectedStyle, disabledDayStyle tcell.Style
+
+	// An optional function which is called when the selected date changes.
+	changed func(date time.Time)
+
+	// An optional function which is called when the selected range changes.
+	rangeChanged func(start, end time.Time)
+
+	// An optional function which is called when the user leaves the date
+	// picker.
+	done func(key tcell.Key)
+}
+
+// NewDatePicker returns a new date picker without a selected date.
+func NewDatePicker() *DatePicker {
+	box := NewBox()
+	d := &DatePicker{
+		Box:              box,
+		format:           time.DateOnly,
+		weekStart:        time.Monday,
+		labelStyle:       tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		fieldStyle:       tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		focusedStyle:     tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
+		disabledStyle:    tcell.StyleDefault.Background(box.backgroundColor).Foreground(Styles.SecondaryTextColor),
+		placeholderStyle: tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.ContrastSecondaryTextColor),
+		calendarStyle:    tcell.StyleDefault.Background(Styles.MoreContrastBackgroundColor).Foreground(Styles.PrimitiveBackgroundColor),
+		cursorStyle:      tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.PrimitiveBackgroundColor),
+		selectedStyle:    tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		disabledDayStyle: tcell.StyleDefault.Background(Styles.MoreContrastBackgroundColor).Foreground(Styles.ContrastSecondaryTextColor),
+	}
+	d.Box.Primitive = d
+	return d
+}
+
+// dateOnly returns the given time at midnight.
+func dateOnly(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// SetDate sets the selected date. The time of day is ignored. Pass the zero
+// value to clear the selection. In range mode, this sets a range of one day.
+// This does not trigger the "changed" callbacks.
+func (d *DatePicker) SetDate(date time.Time) *DatePicker {
+	if !date.IsZero() {
+		date = dateOnly(date)
+	}
+	d.date, d.end = date, date
+	return d
+}
+
+// GetDate returns the selected date (at midnight) or the zero value if no date
+// has been selected. In range mode, this is the start of the selected range.
+func (d *DatePicker) GetDate() time.Time {
+	return d.date
+}
+
+// SetDateRange sets the selected range of dates. The time of day is ignored.
+// If "end" is before "start", the two are swapped. This does not trigger the
+// "changed" callbacks.
+func (d *DatePicker) SetDateRange(start, end time.Time) *DatePicker {
+	if start.IsZero() || end.IsZero() {
+		d.date, d.end = time.Time{}, time.Time{}
+		return d
+	}
+	start, end = dateOnly(start), dateOnly(end)
+	if end.Before(start) {
+		start, end = end, start
+	}
+	d.date, d.end = start, end
+	return d
+}
+
+// GetDateRange returns the first and the last day of the selected range of
+// dates. Both are the zero value if no range has been selected.
+func (d *DatePicker) GetDateRange() (start, end time.Time) {
+	return d.date, d.end
+}
+
+// SetRangeMode sets whether or not the user selects a range of dates instead
+// of a single date.
+func (d *DatePicker) SetRangeMode(rangeMode bool) *DatePicker {
+	d.rangeMode = rangeMode
+	if !rangeMode {
+		d.end = d.date
+	}
+	return d
+}
+
+// SetFormat sets the layout used to display dates, see [time.Time.Format].
+// The default is [time.DateOnly].
+func (d *DatePicker) SetFormat(layout string) *DatePicker {
+	d.format = layout
+	return d
+}
+
+// SetPlaceholder sets the text displayed when no date has been selected.
+func (d *DatePicker) SetPlaceholder(text string) *DatePicker {
+	d.placeholder = text
+	return d
+}
+
+// SetWeekStart sets the first day of the week shown in the calendar. The
+// default is [time.Monday].
+func (d *DatePicker) SetWeekStart(weekday time.Weekday) *DatePicker {
+	d.weekStart = weekday
+	return d
+}
+
+// SetLimits sets the earliest and the latest date which can be selected. Zero
+// values mean that there is no limit.
+func (d *DatePicker) SetLimits(minDate, maxDate time.Time) *DatePicker {
+	if !minDate.IsZero() {
+		minDate = dateOnly(minDate)
+	}
+	if !maxDate.IsZero() {
+		maxDate = dateOnly(maxDate)
+	}
+	d.minDate, d.maxDate = minDate, maxDate
+	return d
+}
+
+// SetDisabledFunc sets a function which returns true for dates (at midnight)
+// which cannot be selected, e.g. weekends or holidays.
+func (d *DatePicker) SetDisabledFunc(isDisabled func(date time.Time) bool) *DatePicker {
+	d.isDisabled = isDisabled
+	return d
+}
+
+// SetLabel sets the text to be displayed before the input area.
+func (d *DatePicker) SetLabel(label string) *DatePicker {
+	d.label = label
+	return d
+}
+
+// GetLabel returns the text to be displayed before the input area.
+func (d *DatePicker) GetLabel() string {
+	return d.label
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause the
+// primitive to use the width of the label string.
+func (d *DatePicker) SetLabelWidth(width int) *DatePicker {
+	d.labelWidth = width
+	return d
+}
+
+// SetLabelStyle sets the style of the label.
+func (d *DatePicker) SetLabelStyle(style tcell.Style) *DatePicker {
+	d.labelStyle = style
+	return d
+}
+
+// SetFieldStyle sets the style of the input area.
+func (d *DatePicker) SetFieldStyle(style tcell.Style) *DatePicker {
+	d.fieldStyle = style
+	return d
+}
+
+// SetFocusedStyle sets the style of the input area when the date picker is
+// focused and the calendar is closed.
+func (d *DatePicker) SetFocusedStyle(style tcell.Style) *DatePicker {
+	d.focusedStyle = style
+	return d
+}
+
+// SetDisabledStyle sets the style of the input area when the date picker is
+// disabled.
+func (d *DatePicker) SetDisabledStyle(style tcell.Style) *DatePicker {
+	d.disabledStyle = style
+	return d
+}
+
+// SetPlaceholderStyle sets the style of the placeholder text.
+func (d *DatePicker) SetPlaceholderStyle(style tcell.Style) *DatePicker {
+	d.placeholderStyle = style
+	return d
+}
+
+// SetCalendarStyles sets the styles of the calendar: the style of the calendar
+// itself, of the highlighted day, of the selected days, and of days which
+// cannot be selected.
+func (d *DatePicker) SetCalendarStyles(calendar, cursor, selected, disabled tcell.Style) *DatePicker {
+	d.calendarStyle = calendar
+	d.cursorStyle = cursor
+	d.selectedStyle = selected
+	d.disabledDayStyle = disabled
+	return d
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (d *DatePicker) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
+	d.labelWidth = labelWidth
+	d.labelStyle = d.labelStyle.Foreground(labelColor)
+	d.SetBackgroundColor(bgColor)
+	d.fieldStyle = tcell.StyleDefault.Foreground(fieldTextColor).Background(fieldBgColor)
+	d.placeholderStyle = d.placeholderStyle.Background(fieldBgColor)
+	return d
+}
+
+// SetFieldWidth sets the screen width of the input area. A value of 0 means
+// the width of a formatted date (or range of dates).
+func (d *DatePicker) SetFieldWidth(width int) *DatePicker {
+	d.fieldWidth = width
+	return d
+}
+
+// GetFieldWidth returns this primitive's field screen width.
+func (d *DatePicker) GetFieldWidth() int {
+	if d.fieldWidth > 0 {
+		return d.fieldWidth
+	}
+	width := TaggedStringWidth(Escape(time.Date(2006, time.September, 30, 0, 0, 0, 0, time.UTC).Format(d.format)))
+	if d.rangeMode {
+		width = 2*width + 3
+	}
+	return max(width, TaggedStringWidth(d.placeholder))
+}
+
+// GetFieldHeight returns this primitive's field height.
+func (d *DatePicker) GetFieldHeight() int {
+	return 1
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (d *DatePicker) SetDisabled(disabled bool) FormItem {
+	d.disabled = disabled
+	if disabled {
+		d.open = false
+	}
+	return d
+}
+
+// GetDisabled returns whether or not the item is disabled / read-only.
+func (d *DatePicker) GetDisabled() bool {
+	return d.disabled
+}
+
+// SetChangedFunc sets a handler which is called when the user changes the
+// selected date. It receives the new date (at midnight) or the zero value if
+// the date was cleared. In range mode, use [DatePicker.SetRangeChangedFunc]
+// instead.
+func (d *DatePicker) SetChangedFunc(handler func(date time.Time)) *DatePicker {
+	d.changed = handler
+	return d
+}
+
+// SetRangeChangedFunc sets a handler which is called in range mode when the
+// user changes the selected range of dates. It receives the first and the last
+// day of the range, or zero values if the range was cleared.
+func (d *DatePicker) SetRangeChangedFunc(handler func(start, end time.Time)) *DatePicker {
+	d.rangeChanged = handler
+	return d
+}
+
+// SetDoneFunc sets a handler which is called when the user is done selecting
+// a date. The callback function is provided with the key that was pressed,
+// which is one of the following:
+//
+//   - KeyEscape: Abort selection.
+//   - KeyTab: Move to the next field.
+//   - KeyBacktab: Move to the previous field.
+func (d *DatePicker) SetDoneFunc(handler func(key tcell.Key)) *DatePicker {
+	d.done = handler
+	return d
+}
+
+// AllowExit returns whether or not this primitive will allow a containing form
+// to move focus away from this primitive.
+func (d *DatePicker) AllowExit(event *tcell.EventKey) bool {
+	return !d.open && event.Key() != tcell.KeyEnter
+}
+
+// IsOpen returns true if the calendar is currently visible.
+func (d *DatePicker) IsOpen() bool {
+	return d.open
+}
+
+// selectable returns true if the given date can be selected.
+func (d *DatePicker) selectable(date time.Time) bool {
+	if !d.minDate.IsZero() && date.Before(d.minDate) || !d.maxDate.IsZero() && date.After(d.maxDate) {
+		return false
+	}
+	return d.isDisabled == nil || !d.isDisabled(date)
+}
+
+// moveCursor moves the calendar's cursor to the given date, respecting the
+// limits.
+func (d *DatePicker) moveCursor(date time.Time) {
+	date = dateOnly(date)
+	if !d.minDate.IsZero() && date.Before(d.minDate) {
+		date = d.minDate
+	}
+	if !d.maxDate.IsZero() && date.After(d.maxDate) {
+		date = d.maxDate
+	}
+	d.cursor = date
+}
+
+// addMonths returns the given date moved by the given number of months. If
+// the day does not exist in the target month, the last day of the month is
+// used.
+func addMonths(date time.Time, months int) time.Time {
+	year, month, day := date.Date()
+	first := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return time.Date(first.Year(), first.Month(), min(day, last), 0, 0, 0, 0, date.Location())
+}
+
+// openCalendar shows the calendar.
+func (d *DatePicker) openCalendar() {
+	if d.open {
+		return
+	}
+	d.open = true
+	d.anchor = time.Time{}
+	if d.date.IsZero() {
+		d.moveCursor(time.Now())
+	} else {
+		d.moveCursor(d.date)
+	}
+}
+
+// choose selects the given date. In range mode, the first call sets the start
+// of the range, the second call its end.
+func (d *DatePicker) choose(date time.Time) {
+	if !d.selectable(date) {
+		return
+	}
+	if !d.rangeMode {
+		d.open = false
+		d.date, d.end = date, date
+		if d.changed != nil {
+			d.changed(date)
+		}
+		return
+	}
+	if d.anchor.IsZero() {
+		d.anchor = date
+		return
+	}
+	d.open = false
+	d.SetDateRange(d.anchor, date)
+	d.anchor = time.Time{}
+	if d.rangeChanged != nil {
+		d.rangeChanged(d.date, d.end)
+	}
+}
+
+// clearDate removes the selection.
+func (d *DatePicker) clearDate() {
+	if d.date.IsZero() {
+		return
+	}
+	d.date, d.end = time.Time{}, time.Time{}
+	if d.rangeMode {
+		if d.rangeChanged != nil {
+			d.rangeChanged(d.date, d.end)
+		}
+	} else if d.changed != nil {
+		d.changed(d.date)
+	}
+}
+
+// gridStart returns the date shown in the top left cell of the calendar for
+// the month of the cursor.
+func (d *DatePicker) gridStart() time.Time {
+	first := time.Date(d.cursor.Year(), d.cursor.Month(), 1, 0, 0, 0, 0, d.cursor.Location())
+	column := (int(first.Weekday()) - int(d.weekStart) + 7) % 7
+	return first.AddDate(0, 0, -column)
+}
+
+// text returns the text shown in the input area.
+func (d *DatePicker) text() string {
+	if d.date.IsZero() {
+		return ""
+	}
+	if d.rangeMode {
+		return d.date.Format(d.format) + " - " + d.end.Format(d.format)
+	}
+	return d.date.Format(d.format)
+}
+
+// Draw draws this primitive onto the screen.
+func (d *DatePicker) Draw(screen tcell.Screen) {
+	d.Box.DrawForSubclass(screen, d)
+
+	// Prepare.
+	x, y, width, height := d.GetInnerRect()
+	rightLimit := x + width
+	if height < 1 || rightLimit <= x {
+		return
+	}
+	if d.open && !d.HasFocus() {
+		d.open = false
+	}
+
+	// Draw label.
+	if d.labelWidth > 0 {
+		labelWidth := min(d.labelWidth, rightLimit-x)
+		printWithStyle(screen, d.label, x, y, 0, labelWidth, AlignLeft, d.labelStyle, true)
+		x += labelWidth
+	} else {
+		_, _, drawnWidth := printWithStyle(screen, d.label, x, y, 0, rightLimit-x, AlignLeft, d.labelStyle, true)
+		x += drawnWidth
+	}
+
+	// Draw the input area.
+	fieldWidth := min(d.GetFieldWidth(), rightLimit-x)
+	fieldStyle := d.fieldStyle
+	if d.disabled {
+		fieldStyle = d.disabledStyle
+	} else if d.HasFocus() && !d.open {
+		fieldStyle = d.focusedStyle
+	}
+	for index := range fieldWidth {
+		screen.SetContent(x+index, y, ' ', nil, fieldStyle)
+	}
+	if text := d.text(); text != "" {
+		printWithStyle(screen, Escape(text), x, y, 0, fieldWidth, AlignLeft, fieldStyle, false)
+	} else if d.placeholder != "" {
+		style := d.placeholderStyle
+		if d.HasFocus() && !d.open {
+			style = fieldStyle
+		}
+		printWithStyle(screen, Escape(d.placeholder), x, y, 0, fieldWidth, AlignLeft, style, false)
+	}
+
+	// Draw the calendar.
+	if !d.open {
+		return
+	}
+	screenWidth, screenHeight := screen.Size()
+	cx, cy := x, y+1
+	if cx+calendarWidth > screenWidth {
+		cx = max(screenWidth-calendarWidth, 0)
+	}
+	if cy+calendarHeight > screenHeight && y-calendarHeight >= 0 {
+		cy = y - calendarHeight // Drop up.
+	}
+	d.calendarX, d.calendarY = cx, cy
+	for row := range calendarHeight {
+		for column := range calendarWidth {
+			screen.SetContent(cx+column, cy+row, ' ', nil, d.calendarStyle)
+		}
+	}
+	cx, cy = cx+1, cy+1
+
+	// Header.
+	printWithStyle(screen, "«", cx, cy, 0, 1, AlignLeft, d.calendarStyle, false)
+	printWithStyle(screen, "‹", cx+2, cy, 0, 1, AlignLeft, d.calendarStyle, false)
+	printWithStyle(screen, fmt.Sprintf("%s %d", d.cursor.Month(), d.cursor.Year()), cx+3, cy, 0, calendarWidth-8, AlignCenter, d.calendarStyle.Bold(true), false)
+	printWithStyle(screen, "›", cx+17, cy, 0, 1, AlignLeft, d.calendarStyle, false)
+	printWithStyle(screen, "»", cx+19, cy, 0, 1, AlignLeft, d.calendarStyle, false)
+
+	// Weekdays.
+	for column := range 7 {
+		weekday := time.Weekday((int(d.weekStart) + column) % 7)
+		printWithStyle(screen, weekday.String()[:2], cx+column*3, cy+1, 0, 2, AlignLeft, d.calendarStyle, false)
+	}
+
+	// Days.
+	start, end := d.date, d.end
+	if !d.anchor.IsZero() {
+		start, end = d.anchor, d.cursor
+		if end.Before(start) {
+			start, end = end, start
+		}
+	}
+	today := dateOnly(time.Now().In(d.cursor.Location()))
+	date := d.gridStart()
+	for row := range 6 {
+		for column := range 7 {
+			if date.Month() == d.cursor.Month() {
+				style := d.calendarStyle
+				if !d.selectable(date) {
+					style = d.disabledDayStyle
+				} else if !start.IsZero() && !date.Before(start) && !date.After(end) {
+					style = d.selectedStyle
+				}
+				if date.Equal(d.cursor) {
+					style = d.cursorStyle
+				}
+				if date.Equal(today) {
+					style = style.Underline(true)
+				}
+				printWithStyle(screen, fmt.Sprintf("%2d", date.Day()), cx+column*3, cy+2+row, 0, 2, AlignLeft, style, false)
+			}
+			date = date.AddDate(0, 0, 1)
+		}
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (d *DatePicker) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return d.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if d.disabled {
+			return
+		}
+
+		// Closed calendar.
+		key := event.Key()
+		if !d.open {
+			switch key {
+			case tcell.KeyEnter, tcell.KeyDown:
+				d.openCalendar()
+			case tcell.KeyRune:
+				if event.Rune() == ' ' {
+					d.openCalendar()
+				}
+			case tcell.KeyDelete, tcell.KeyBackspace, tcell.KeyBackspace2:
+				d.clearDate()
+			case tcell.KeyEscape, tcell.KeyTab, tcell.KeyBacktab:
+				if d.done != nil {
+					d.done(key)
+				}
+			}
+			return
+		}
+
+		// Open calendar.
+		switch key {
+		case tcell.KeyLeft:
+			d.moveCursor(d.cursor.AddDate(0, 0, -1))
+		case tcell.KeyRight:
+			d.moveCursor(d.cursor.AddDate(0, 0, 1))
+		case tcell.KeyUp:
+			d.moveCursor(d.cursor.AddDate(0, 0, -7))
+		case tcell.KeyDown:
+			d.moveCursor(d.cursor.AddDate(0, 0, 7))
+		case tcell.KeyPgUp, tcell.KeyPgDn:
+			months := 1
+			if event.Modifiers()&(tcell.ModShift|tcell.ModCtrl) != 0 {
+				months = 12
+			}
+			if key == tcell.KeyPgUp {
+				months = -months
+			}
+			d.moveCursor(addMonths(d.cursor, months))
+		case tcell.KeyHome:
+			d.moveCursor(d.cursor.AddDate(0, 0, 1-d.cursor.Day()))
+		case tcell.KeyEnd:
+			d.moveCursor(addMonths(d.cursor.AddDate(0, 0, 1-d.cursor.Day()), 1).AddDate(0, 0, -1))
+		case tcell.KeyEnter:
+			d.choose(d.cursor)
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case ' ':
+				d.choose(d.cursor)
+			case 't':
+				d.moveCursor(time.Now())
+			}
+		case tcell.KeyEscape:
+			d.open = false
+		case tcell.KeyTab, tcell.KeyBacktab:
+			d.open = false
+			if d.done != nil {
+				d.done(key)
+			}
+		}
+	})
+}
+
+// calendarDateAt returns the date shown at the given screen position in the
+// calendar. The second return value is false if there is no date at that
+// position.
+func (d *DatePicker) calendarDateAt(x, y int) (time.Time, bool) {
+	column, row := x-d.calendarX-1, y-d.calendarY-3
+	if column < 0 || column >= calendarWidth-2 || column%3 == 2 || row < 0 || row >= 6 {
+		return time.Time{}, false
+	}
+	date := d.gridStart().AddDate(0, 0, row*7+column/3)
+	return date, date.Month() == d.cursor.Month()
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (d *DatePicker) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return d.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if d.disabled {
+			return false, nil
+		}
+		x, y := event.Position()
+		inField := d.InInnerRect(x, y)
+		if !d.open {
+			if !inField {
+				return d.InRect(x, y), nil
+			}
+			if action == MouseLeftDown {
+				setFocus(d)
+				d.openCalendar()
+				return true, d
+			}
+			return true, nil
+		}
+
+		// As long as the calendar is open, we capture all mouse events.
+		inCalendar := x >= d.calendarX && x < d.calendarX+calendarWidth && y >= d.calendarY && y < d.calendarY+calendarHeight
+		switch action {
+		case MouseLeftDown:
+			if !inCalendar {
+				d.open = false
+				return inField, nil
+			}
+		case MouseLeftClick:
+			if y == d.calendarY+1 {
+				// Header navigation.
+				switch x - d.calendarX - 1 {
+				case 0:
+					d.moveCursor(addMonths(d.cursor, -12))
+				case 2:
+					d.moveCursor(addMonths(d.cursor, -1))
+				case 17:
+					d.moveCursor(addMonths(d.cursor, 1))
+				case 19:
+					d.moveCursor(addMonths(d.cursor, 12))
+				}
+			} else if date, ok := d.calendarDateAt(x, y); ok {
+				d.cursor = date
+				d.choose(date)
+			}
+		case MouseScrollUp:
+			if inCalendar {
+				d.moveCursor(addMonths(d.cursor, -1))
+			}
+		case MouseScrollDown:
+			if inCalendar {
+				d.moveCursor(addMonths(d.cursor, 1))
+			}
+		}
+		if d.open {
+			capture = d
+		}
+		return true, capture
+	})
+}
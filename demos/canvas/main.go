@@ -0,0 +1,70 @@
+// Demo code for the Canvas primitive.
+package main
+
+import (
+	"image"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func main() {
+	app := tview.NewApplication()
+	canvas := tview.NewCanvas()
+	canvas.SetBorder(true).SetTitle("Drag to draw, right-click to fill, (c)lear, (m)ode, (q)uit")
+
+	// Draw some shapes.
+	shapes := func() {
+		canvas.Clear().
+			DrawRect(2, 2, 40, 24, tcell.ColorWhite).
+			DrawLine(2, 2, 41, 25, tcell.ColorGray).
+			DrawCircle(70, 20, 14, tcell.ColorYellow).
+			FillCircle(110, 20, 10, tcell.ColorBlue).
+			FillPolygon([]image.Point{{10, 60}, {40, 36}, {70, 70}}, tcell.ColorRed).
+			DrawText(90, 40, "Hello, canvas!", tcell.ColorGreen)
+	}
+	shapes()
+
+	// Let the user draw with the mouse.
+	var drawing bool
+	var lastX, lastY int
+	canvas.SetMouseFunc(func(action tview.MouseAction, x, y int) bool {
+		switch action {
+		case tview.MouseLeftDown:
+			drawing, lastX, lastY = true, x, y
+			canvas.SetPixel(x, y, tcell.ColorFuchsia)
+		case tview.MouseMove:
+			if drawing {
+				canvas.DrawLine(lastX, lastY, x, y, tcell.ColorFuchsia)
+				lastX, lastY = x, y
+			}
+		case tview.MouseLeftUp:
+			drawing = false
+		case tview.MouseRightClick:
+			canvas.FloodFill(x, y, tcell.ColorTeal)
+		default:
+			return false
+		}
+		return true
+	})
+
+	canvas.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'c':
+			shapes()
+		case 'm':
+			if canvas.GetMode() == tview.CanvasBraille {
+				canvas.SetMode(tview.CanvasHalfBlocks)
+			} else {
+				canvas.SetMode(tview.CanvasBraille)
+			}
+		case 'q':
+			app.Stop()
+		}
+		return event
+	})
+
+	if err := app.SetRoot(canvas, true).EnableMouse(true).Run(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,77 @@
+// Demo code for the chart primitives.
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func main() {
+	app := tview.NewApplication()
+
+	// A line chart showing the last 100 values of two series.
+	lines := tview.NewLineChart().SetWindow(100)
+	lines.SetBorder(true).SetTitle("CPU load")
+	user := lines.AddSeries("user", tcell.ColorDefault)
+	system := lines.AddSeries("system", tcell.ColorDefault)
+
+	// A bar chart with vertical bars and one with horizontal bars.
+	days := tview.NewBarChart()
+	days.SetBorder(true).SetTitle("Commits")
+	for _, day := range []string{"Mon", "Tue", "Wed", "Thu", "Fri"} {
+		days.AddBar(day, float64(rand.Intn(20)), tcell.ColorDefault)
+	}
+	latencies := tview.NewBarChart().
+		SetHorizontal(true).
+		SetMax(100).
+		SetFormatFunc(func(value float64) string {
+			return fmt.Sprintf("%.0fms", value)
+		})
+	latencies.SetBorder(true).SetTitle("Response times")
+	for _, name := range []string{"api", "db", "cache"} {
+		latencies.AddBar(name, rand.Float64()*100, tcell.ColorDefault)
+	}
+
+	// A sparkline showing the most recent requests per second.
+	requests := tview.NewSparkline().SetWindow(200)
+	requests.SetBorder(true).SetTitle("Requests per second")
+
+	// A histogram of the last 1000 response sizes.
+	sizes := tview.NewHistogram().SetWindow(1000).SetBinCount(12)
+	sizes.SetBorder(true).SetTitle("Response sizes")
+
+	grid := tview.NewGrid().
+		SetRows(0, 0, 5).
+		SetColumns(0, 0).
+		AddItem(lines, 0, 0, 1, 2, 0, 0, false).
+		AddItem(days, 1, 0, 1, 1, 0, 0, false).
+		AddItem(sizes, 1, 1, 1, 1, 0, 0, false).
+		AddItem(latencies, 2, 0, 1, 1, 0, 0, false).
+		AddItem(requests, 2, 1, 1, 1, 0, 0, false)
+
+	// Stream data into the charts.
+	go func() {
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for tick := 0; ; tick++ {
+			<-ticker.C
+			t := float64(tick) / 10
+			lines.AppendValues(user, 40+30*math.Sin(t)+rand.Float64()*10)
+			lines.AppendValues(system, 20+10*math.Cos(t/3)+rand.Float64()*5)
+			requests.AppendValues(50 + 40*math.Sin(t/2) + rand.Float64()*20)
+			for range 10 {
+				sizes.AddSamples(rand.NormFloat64()*200 + 1000)
+			}
+			app.Draw()
+		}
+	}()
+
+	if err := app.SetRoot(grid, true).Run(); err != nil {
+		panic(err)
+	}
+}
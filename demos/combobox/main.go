@@ -0,0 +1,73 @@
+// Demo code for a DropDown in combo box mode.
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+var countries = []string{
+	"Argentina", "Australia", "Austria", "Belgium", "Brazil", "Canada", "Chile",
+	"China", "Colombia", "Czechia", "Denmark", "Egypt", "Finland", "France",
+	"Germany", "Greece", "Hungary", "Iceland", "India", "Indonesia", "Ireland",
+	"Israel", "Italy", "Japan", "Kenya", "Mexico", "Morocco", "Netherlands",
+	"New Zealand", "Nigeria", "Norway", "Peru", "Philippines", "Poland",
+	"Portugal", "Romania", "Singapore", "South Africa", "South Korea", "Spain",
+	"Sweden", "Switzerland", "Thailand", "Turkey", "Ukraine",
+	"United Kingdom", "United States", "Vietnam",
+}
+
+func main() {
+	app := tview.NewApplication()
+	status := tview.NewTextView()
+
+	// A combo box filtering a fixed set of options.
+	country := tview.NewDropDown().
+		SetLabel("Country (fuzzy): ").
+		SetComboBox(true).
+		SetMatchFunc(tview.DropDownMatchFuzzy).
+		SetFieldWidth(20).
+		SetOptions(countries, func(text string, index int) {
+			status.SetText(fmt.Sprintf("Selected country %q (index %d)", text, index))
+		})
+
+	// A combo box whose options are looked up in the background.
+	city := tview.NewDropDown().
+		SetLabel("City (remote, free text): ").
+		SetComboBox(true).
+		SetAllowFreeText(true).
+		SetFieldWidth(20).
+		SetOptionsProvider(func(text string, setOptions func(options []string)) {
+			go func() {
+				time.Sleep(200 * time.Millisecond) // Simulate a remote lookup.
+				var options []string
+				for _, city := range []string{"Amsterdam", "Berlin", "Bern", "Boston", "Brussels", "Budapest", "Lisbon", "London", "Madrid", "Paris", "Prague", "Rome", "Vienna"} {
+					if strings.Contains(strings.ToLower(city), strings.ToLower(text)) {
+						options = append(options, city)
+					}
+				}
+				app.QueueUpdateDraw(func() {
+					setOptions(options)
+				})
+			}()
+		}).
+		SetSelectedFunc(func(text string, index int) {
+			status.SetText(fmt.Sprintf("Selected city %q (index %d)", text, index))
+		})
+
+	form := tview.NewForm().
+		AddFormItem(country).
+		AddFormItem(city).
+		AddButton("Quit", func() {
+			app.Stop()
+		})
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(status, 1, 0, false)
+	if err := app.SetRoot(flex, true).EnableMouse(true).EnablePaste(true).Run(); err != nil {
+		panic(err)
+	}
+}
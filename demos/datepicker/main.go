@@ -0,0 +1,42 @@
+// Demo code for the DatePicker and TimePicker primitives.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+func main() {
+	app := tview.NewApplication()
+	now := time.Now()
+	trip := tview.NewDatePicker().
+		SetLabel("Trip").
+		SetRangeMode(true).
+		SetLimits(now, time.Time{}).
+		SetDateRange(now, now.AddDate(0, 0, 7))
+	hours := tview.NewTimePicker().
+		SetLabel("Office hours").
+		SetMinuteStep(15).
+		SetTimeRange(time.Date(0, 1, 1, 9, 0, 0, 0, time.Local), time.Date(0, 1, 1, 17, 0, 0, 0, time.Local))
+	form := tview.NewForm().
+		AddDatePicker("Birthday", time.Date(1990, 6, 15, 0, 0, 0, 0, time.Local), nil).
+		AddFormItem(trip).
+		AddTimePicker("Alarm", time.Date(0, 1, 1, 7, 30, 0, 0, time.Local), nil).
+		AddFormItem(hours).
+		AddButton("Quit", func() {
+			app.Stop()
+		})
+	form.SetBorder(true).SetTitle("Pick dates and times").SetTitleAlign(tview.AlignLeft)
+	if err := app.SetRoot(form, true).EnableMouse(true).Run(); err != nil {
+		panic(err)
+	}
+	birthday := form.GetFormItem(0).(*tview.DatePicker).GetDate()
+	start, end := trip.GetDateRange()
+	from, to := hours.GetTimeRange()
+	fmt.Println("Birthday:", birthday.Format(time.DateOnly))
+	fmt.Println("Trip:", start.Format(time.DateOnly), "to", end.Format(time.DateOnly))
+	fmt.Println("Alarm:", form.GetFormItem(2).(*tview.TimePicker).GetTime().Format(time.Kitchen))
+	fmt.Println("Office hours:", from.Format(time.Kitchen), "to", to.Format(time.Kitchen))
+}
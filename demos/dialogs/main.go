@@ -0,0 +1,70 @@
+// Demo code for the Dialogs primitive.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+func main() {
+	app := tview.NewApplication()
+	dialogs := tview.NewDialogs(app)
+	status := tview.NewTextView().SetText("Select an action from the list.")
+	status.SetBorder(true).SetTitle("Status")
+
+	list := tview.NewList().ShowSecondaryText(false).
+		AddItem("Alert", "", 'a', func() {
+			dialogs.Alert("Alert", "Something happened.", nil)
+		}).
+		AddItem("Prompt", "", 'p', func() {
+			dialogs.Prompt("Prompt", "Your name: ", "", func(value string, ok bool) {
+				if ok {
+					status.SetText(fmt.Sprintf("Hello, %s!", value))
+				}
+			})
+		}).
+		AddItem("Choose", "", 'c', func() {
+			dialogs.Choose("Choose", []string{"Red", "Green", "Blue"}, func(index int, option string) {
+				if index >= 0 {
+					status.SetText("You chose " + option + ".")
+				}
+			})
+		}).
+		AddItem("Progress", "", 'r', func() {
+			cancelled := make(chan struct{})
+			progress := dialogs.Progress("Progress", "Working hard...", func() { close(cancelled) })
+			go func() {
+				for step := 1; step <= 20; step++ {
+					select {
+					case <-cancelled:
+						app.QueueUpdateDraw(func() { status.SetText("Cancelled.") })
+						return
+					case <-time.After(200 * time.Millisecond):
+					}
+					app.QueueUpdateDraw(func() { progress.SetProgress(float64(step) / 20) })
+				}
+				app.QueueUpdateDraw(func() {
+					progress.Close()
+					status.SetText("Finished.")
+				})
+			}()
+		}).
+		AddItem("Quit", "", 'q', func() {
+			go func() {
+				if confirmed, ok := <-dialogs.ConfirmChan("Quit", "Do you really want to quit?"); ok && confirmed {
+					app.Stop()
+				}
+			}()
+		})
+	list.SetBorder(true).SetTitle("Actions")
+
+	layout := tview.NewFlex().
+		AddItem(list, 20, 0, true).
+		AddItem(status, 0, 1, false)
+	dialogs.SetRoot(layout)
+	if err := app.SetRoot(dialogs, true).EnableMouse(true).Run(); err != nil {
+		panic(err)
+	}
+}
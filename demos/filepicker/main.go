@@ -0,0 +1,36 @@
+// Demo code for the FilePicker primitive.
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+func main() {
+	dir, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+
+	app := tview.NewApplication()
+	picker := tview.NewFilePicker(tview.DirFS(dir)).
+		SetFilters("*.go", "*.md").
+		SetMultiSelect(true)
+	picker.SetBorder(true).SetTitle("Open (F7: new folder, Alt-.: hidden files, Space: mark)")
+
+	var selected []string
+	picker.SetSelectedFunc(func(paths []string) {
+		selected = paths
+		app.Stop()
+	}).SetCancelFunc(app.Stop)
+
+	if err := app.SetRoot(picker, true).EnableMouse(true).Run(); err != nil {
+		panic(err)
+	}
+	if len(selected) > 0 {
+		fmt.Println("Selected:", strings.Join(selected, ", "))
+	}
+}
@@ -16,6 +16,13 @@ func main() {
 		AddTextArea("Address", "", 40, 0, 0, nil).
 		AddTextView("Notes", "This is just a demo.\nYou can enter whatever you wish.", 40, 2, true, false).
 		AddCheckbox("Age 18+", false, nil).
+		AddRadioGroup("Contact by", []string{"Email", "Phone", "Mail"}, 0, nil).
+		AddFormItem(tview.NewDropDown().
+			SetLabel("Interests").
+			SetMultiSelect(true).
+			SetTextOptions("", "", "", "", "(none)").
+			SetOptions([]string{"Books", "Movies", "Music", "Sports", "Travel"}, nil).
+			SetFieldWidth(20)).
 		AddPasswordField("Password", "", 10, '*', nil).
 		AddButton("Save", func() {
 			app.Stop()
@@ -33,6 +40,10 @@ func main() {
 			app.Stop()
 			fmt.Println("Form was submitted")
 		})
+	form.SetValidators(form.GetFormItemByLabel("First name"), tview.RequiredValidator("Please enter your first name")).
+		SetValidators(form.GetFormItemByLabel("Last name"), tview.RequiredValidator("Please enter your last name")).
+		SetValidators(form.GetFormItemByLabel("Password"), tview.LengthValidator(8, 0, "At least 8 characters")).
+		SetButtonValidation(form.GetButtonIndex("Quit"), false)
 	form.SetBorder(true).SetTitle("Enter some data").SetTitleAlign(tview.AlignLeft)
 	if err := app.SetRoot(form, true).EnableMouse(true).EnablePaste(true).Run(); err != nil {
 		panic(err)
@@ -0,0 +1,54 @@
+// Demo code for a Form built from a struct.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// Config is edited with the form.
+type Config struct {
+	Name       string        `tview:"label=Server name,width=20,required"`
+	Port       uint16        `tview:"min=1,width=6"`
+	LogLevel   int           `tview:"label=Log level,options=Debug|Info|Warning|Error"`
+	Timeout    time.Duration `tview:"width=10"`
+	CacheRatio float64       `tview:"label=Cache ratio,min=0,max=1,step=0.05,slider,width=20"`
+	TLS        bool          `tview:"label=Use TLS"`
+	Password   string        `tview:"password,width=20"`
+	Expires    time.Time
+	internal   string
+}
+
+func main() {
+	config := &Config{
+		Name:       "example",
+		Port:       8080,
+		LogLevel:   1,
+		Timeout:    30 * time.Second,
+		CacheRatio: 0.25,
+		Expires:    time.Now().AddDate(1, 0, 0),
+	}
+	defaults := *config
+
+	app := tview.NewApplication()
+	form := tview.NewForm().AddStruct(config)
+	form.AddButton("Save", func() {
+		app.Stop()
+		fmt.Printf("%+v\n", *config)
+	}).
+		AddButton("Reset", func() {
+			*config = defaults
+			form.ReloadStruct()
+		}).
+		AddButton("Quit", func() {
+			app.Stop()
+		}).
+		SetButtonValidation(1, false).
+		SetButtonValidation(2, false)
+	form.SetBorder(true).SetTitle("Server configuration").SetTitleAlign(tview.AlignLeft)
+	if err := app.SetRoot(form, true).EnableMouse(true).EnablePaste(true).Run(); err != nil {
+		panic(err)
+	}
+}
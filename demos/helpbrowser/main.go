@@ -0,0 +1,88 @@
+// Demo code for a simple help browser using links in a TextView.
+package main
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// pages contains the help pages, mapped by their names. Links to other pages
+// use URLs of the form "help:<name>".
+var pages = map[string]string{
+	"index": `[yellow::b]Help Index[-::-]
+
+Welcome to the help browser. Select a topic:
+
+  • [:::help:navigation]Navigating the help[:::-]
+  • [:::help:links]About links[:::-]
+  • [:::help:about]About this demo[:::-]
+
+Press Escape to quit.`,
+	"navigation": `[yellow::b]Navigating the help[-::-]
+
+Press Tab and Backtab to select the links on the screen, then press Enter to
+follow the selected link. You can also click on links with the mouse.
+
+Press Backspace to go back to the previous page.
+
+[:::help:index]Back to the index[:::-]`,
+	"links": `[yellow::b]About links[-::-]
+
+Links are created with the URL field of style tags. The text view calls the
+function provided with SetLinkClickedFunc() when a link is activated. Links to
+websites such as [:::https://github.com/rivo/tview]the tview repository[:::-]
+are also shown in the title bar in this demo.
+
+See also: [:::help:navigation]Navigating the help[:::-]
+
+[:::help:index]Back to the index[:::-]`,
+	"about": `[yellow::b]About this demo[-::-]
+
+This demo shows how a TextView can be used as a simple hypertext browser.
+
+[:::help:index]Back to the index[:::-]`,
+}
+
+func main() {
+	app := tview.NewApplication()
+	textView := tview.NewTextView().
+		SetDynamicColors(true)
+	textView.SetBorder(true)
+
+	var history []string
+	show := func(name string) {
+		history = append(history, name)
+		textView.SetText(pages[name]).ScrollToBeginning()
+		textView.SetTitle(name)
+	}
+	textView.SetLinkClickedFunc(func(url string) {
+		if name, ok := strings.CutPrefix(url, "help:"); ok {
+			show(name)
+			return
+		}
+		textView.SetTitle(url)
+	})
+	textView.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			app.Stop()
+		}
+	})
+	textView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyBackspace || event.Key() == tcell.KeyBackspace2 {
+			if len(history) > 1 {
+				previous := history[len(history)-2]
+				history = history[:len(history)-2]
+				show(previous)
+			}
+			return nil
+		}
+		return event
+	})
+	show("index")
+
+	if err := app.SetRoot(textView, true).EnableMouse(true).Run(); err != nil {
+		panic(err)
+	}
+}
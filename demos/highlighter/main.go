@@ -0,0 +1,92 @@
+// Demo code for syntax highlighting in a TextArea.
+package main
+
+import (
+	"github.com/rivo/tview"
+)
+
+// samples contains sample texts for the supported languages.
+var samples = map[string]string{
+	"go": `package main
+
+import "fmt"
+
+/* main prints a greeting
+   and exits. */
+func main() {
+	name := "world" // Who to greet.
+	for i := 0; i < 3; i++ {
+		fmt.Printf("Hello, %s! (%d)\n", name, i+1)
+	}
+}
+`,
+	"json": `{
+  "name": "tview",
+  "version": 1.5,
+  "stable": true,
+  "tags": ["terminal", "ui"],
+  "license": null
+}
+`,
+	"yaml": `# Service configuration.
+server:
+  host: example.com
+  port: 8080
+  tls: true
+defaults: &defaults
+  timeout: 30
+clients:
+  - name: 'primary'
+    <<: *defaults
+`,
+	"sql": `-- Find active users.
+SELECT u.id, u.name, COUNT(o.id) AS orders
+FROM users u
+LEFT JOIN orders o ON o.user_id = u.id
+WHERE u.active = TRUE AND u.created > :since
+GROUP BY u.id, u.name
+ORDER BY orders DESC
+LIMIT 10;
+`,
+	"shell": `#!/bin/bash
+# Back up all files.
+for file in "$@"; do
+	if [ -f "$file" ]; then
+		cp "$file" "${file}.bak"
+	fi
+done
+echo 'Done.'
+`,
+	"diff": `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+-// Old comment.
++// New comment.
+`,
+}
+
+func main() {
+	app := tview.NewApplication()
+
+	textArea := tview.NewTextArea()
+	textArea.SetBorder(true).SetTitle("Editor")
+
+	languages := []string{"go", "json", "yaml", "sql", "shell", "diff"}
+	dropDown := tview.NewDropDown().
+		SetLabel("Language: ").
+		SetOptions(languages, func(text string, index int) {
+			textArea.SetHighlighter(tview.NewSyntaxHighlighter(text)).
+				SetText(samples[text], false)
+		}).
+		SetCurrentOption(0)
+
+	flex := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(dropDown, 1, 0, false).
+		AddItem(textArea, 0, 1, true)
+	if err := app.SetRoot(flex, true).EnableMouse(true).Run(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,65 @@
+// Demo code for the LogHandler, which routes log/slog output to a TextView
+// or a LogView.
+package main
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+func main() {
+	app := tview.NewApplication()
+
+	// A text view with a limited number of lines which redraws on changes.
+	textView := tview.NewTextView().
+		SetMaxLines(500).
+		SetChangedFunc(func() {
+			app.Draw()
+		})
+	textView.SetBorder(true).SetTitle("TextView")
+	textLogger := slog.New(tview.NewTextViewHandler(textView, &slog.HandlerOptions{
+		Level:     slog.LevelDebug,
+		AddSource: true,
+	}))
+
+	// A log view which is redrawn periodically.
+	logView := tview.NewLogView()
+	logView.SetBorder(true).SetTitle("LogView")
+	viewLogger := slog.New(tview.NewLogViewHandler(logView, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+	go func() {
+		for range time.Tick(100 * time.Millisecond) {
+			app.Draw()
+		}
+	}()
+
+	// Log from several goroutines.
+	levels := []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError}
+	messages := []string{"request handled", "cache miss", "connection reset", "retrying", "user logged in"}
+	for _, component := range []string{"api", "db", "auth"} {
+		for _, logger := range []*slog.Logger{textLogger, viewLogger} {
+			logger := logger.With("component", component).WithGroup("request")
+			go func() {
+				for n := 0; ; n++ {
+					time.Sleep(time.Duration(rand.Intn(1000)) * time.Millisecond)
+					logger.Log(context.Background(), levels[rand.Intn(len(levels))], messages[rand.Intn(len(messages))],
+						"n", n,
+						"took", time.Duration(rand.Intn(1000))*time.Millisecond)
+				}
+			}()
+		}
+	}
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(textView, 0, 1, false).
+		AddItem(logView, 0, 1, true)
+	if err := app.SetRoot(layout, true).EnableMouse(true).Run(); err != nil {
+		panic(err)
+	}
+}
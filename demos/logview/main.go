@@ -0,0 +1,80 @@
+// Demo code for the LogView primitive.
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"regexp"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func main() {
+	app := tview.NewApplication()
+	logView := tview.NewLogView().
+		SetMaxEntries(5000).
+		SetScrollBarVisibility(tview.ScrollBarAuto, tview.ScrollBarNever)
+	logView.SetBorder(true).SetTitle("Log (End to follow)")
+
+	// Filters.
+	levels := []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError}
+	level := tview.NewDropDown().
+		SetLabel("Level ").
+		SetOptions([]string{"DEBUG", "INFO", "WARN", "ERROR"}, func(text string, index int) {
+			logView.SetLevel(levels[index])
+		}).
+		SetCurrentOption(0)
+	filter := tview.NewInputField().
+		SetLabel("Filter (regular expression) ").
+		SetChangedFunc(func(text string) {
+			re, err := regexp.Compile("(?i)" + text)
+			if err != nil {
+				return
+			}
+			logView.SetFilterRegexp(re)
+		})
+	filters := tview.NewFlex().
+		AddItem(level, 16, 0, false).
+		AddItem(filter, 0, 1, true)
+
+	// Produce log entries from several goroutines.
+	messages := []string{"request handled", "cache miss", "connection reset", "retrying", "user logged in"}
+	for _, source := range []string{"api", "db", "auth", "worker"} {
+		go func() {
+			for n := 0; ; n++ {
+				time.Sleep(time.Duration(rand.Intn(500)) * time.Millisecond)
+				logView.Log(levels[rand.Intn(len(levels))], source, messages[rand.Intn(len(messages))],
+					slog.Int("n", n),
+					slog.Duration("took", time.Duration(rand.Intn(1000))*time.Millisecond))
+			}
+		}()
+	}
+	go func() {
+		for range time.Tick(100 * time.Millisecond) {
+			app.Draw()
+		}
+	}()
+	fmt.Fprintln(logView, "Started. Press Tab to switch between the filter and the log.")
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(filters, 1, 0, true).
+		AddItem(logView, 0, 1, false)
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyTab {
+			if logView.HasFocus() {
+				app.SetFocus(filter)
+			} else {
+				app.SetFocus(logView)
+			}
+			return nil
+		}
+		return event
+	})
+	if err := app.SetRoot(layout, true).EnableMouse(true).Run(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,98 @@
+// Demo code for the Markdown primitive. Pass the name of a Markdown file as an
+// argument to view it, otherwise a sample document is shown.
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const sample = `# Markdown
+
+The **Markdown** primitive renders documents written in
+[Markdown](https://commonmark.org). Text is _reflowed_ to the width of the
+view. Press **n** and **p** to jump between headings or see the
+[list of features](#features) below.
+
+## Features
+
+- Headings, paragraphs, and block quotes
+- Ordered and unordered lists
+  - which may be nested
+- [x] Task lists
+- ` + "`Code spans`" + ` and fenced code blocks
+- ~~Strike-through~~ text
+
+> Block quotes may contain other elements.
+>
+> 1. Such as lists.
+
+## Code
+
+` + "```go" + `
+func main() {
+	app := tview.NewApplication()
+	markdown := tview.NewMarkdown().SetText("# Hello, world!")
+	if err := app.SetRoot(markdown, true).Run(); err != nil {
+		panic(err)
+	}
+}
+` + "```" + `
+
+## Tables
+
+| Primitive | Package | Since |
+|:----------|:-------:|------:|
+| TextView  | tview   |  2017 |
+| Markdown  | tview   |  2026 |
+
+---
+
+Back to the [top](#markdown).
+`
+
+func main() {
+	text := sample
+	if len(os.Args) > 1 {
+		content, err := os.ReadFile(os.Args[1])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		text = string(content)
+	}
+
+	app := tview.NewApplication()
+	markdown := tview.NewMarkdown().
+		SetText(text).
+		SetScrollBarVisibility(tview.ScrollBarAuto, tview.ScrollBarNever)
+	markdown.SetBorder(true).SetTitle("Document")
+
+	// A table of contents.
+	contents := tview.NewList().ShowSecondaryText(false)
+	contents.SetBorder(true).SetTitle("Contents")
+	for _, heading := range markdown.GetHeadings() {
+		contents.AddItem(strings.Repeat("  ", heading.Level-1)+heading.Text, "", 0, nil)
+	}
+	contents.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		markdown.ScrollToHeading(markdown.GetHeadings()[index].ID)
+		app.SetFocus(markdown)
+	})
+	contents.SetDoneFunc(func() {
+		app.SetFocus(markdown)
+	})
+	markdown.SetDoneFunc(func(key tcell.Key) {
+		app.SetFocus(contents)
+	})
+
+	flex := tview.NewFlex().
+		AddItem(contents, 30, 0, false).
+		AddItem(markdown, 0, 1, true)
+	if err := app.SetRoot(flex, true).EnableMouse(true).Run(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,46 @@
+// Demo code for the MenuBar primitive.
+package main
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func main() {
+	app := tview.NewApplication()
+	textView := tview.NewTextView().
+		SetText("Press F10 or Alt+F to open the menus.")
+
+	file := tview.NewMenuItem("File").SetMnemonic('f').
+		AddItem(tview.NewMenuItem("New").SetMnemonic('n').SetShortcut(tcell.KeyCtrlN, 0, tcell.ModCtrl).SetSelectedFunc(func() {
+			textView.SetText("New file")
+		})).
+		AddItem(tview.NewMenuItem("Open recent").SetMnemonic('r').
+			AddItem(tview.NewMenuItem("notes.txt")).
+			AddItem(tview.NewMenuItem("todo.txt"))).
+		AddItem(tview.NewMenuItem("Save").SetMnemonic('s').SetDisabled(true)).
+		AddItem(tview.NewMenuSeparator()).
+		AddItem(tview.NewMenuItem("Quit").SetMnemonic('q').SetShortcut(tcell.KeyCtrlQ, 0, tcell.ModCtrl).SetSelectedFunc(app.Stop))
+	view := tview.NewMenuItem("View").SetMnemonic('v').
+		AddItem(tview.NewMenuItem("Word wrap").SetMnemonic('w').SetCheckable(true).SetChecked(true)).
+		AddItem(tview.NewMenuSeparator()).
+		AddItem(tview.NewMenuItem("Small").SetRadioGroup("size").SetChecked(true)).
+		AddItem(tview.NewMenuItem("Large").SetRadioGroup("size"))
+	help := tview.NewMenuItem("Help").SetMnemonic('h').SetSelectedFunc(func() {
+		textView.SetText("This is the menu bar demo.")
+	})
+
+	menuBar := tview.NewMenuBar().
+		AddItem(file).
+		AddItem(view).
+		AddItem(help).
+		SetContent(textView).
+		SetSelectedFunc(func(item *tview.MenuItem) {
+			if item == view.GetItems()[0] {
+				textView.SetWrap(item.IsChecked())
+			}
+		})
+	if err := app.SetRoot(menuBar, true).EnableMouse(true).Run(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,32 @@
+// Demo code for a List with multiple selected items.
+package main
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+)
+
+func main() {
+	app := tview.NewApplication()
+	status := tview.NewTextView().SetText("Space: toggle, Shift+arrows: select range, Ctrl-A: select all/none")
+	list := tview.NewList().
+		ShowSecondaryText(false).
+		SetMultiSelect(true).
+		ShowCheckboxes(true)
+	for _, fruit := range []string{"Apple", "Banana", "Cherry", "Date", "Elderberry", "Fig", "Grape", "Honeydew"} {
+		list.AddItem(fruit, "", 0, nil)
+	}
+	list.SetSelectionChangedFunc(func(indices []int) {
+		status.SetText(fmt.Sprintf("%d item(s) selected: %v", len(indices), indices))
+	}).SetDoneFunc(func() {
+		app.Stop()
+	})
+	list.SetBorder(true).SetTitle("Fruits")
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(list, 0, 1, true).
+		AddItem(status, 1, 0, false)
+	if err := app.SetRoot(flex, true).EnableMouse(true).Run(); err != nil {
+		panic(err)
+	}
+}
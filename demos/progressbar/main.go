@@ -0,0 +1,61 @@
+// Demo code for the ProgressBar and Spinner primitives.
+package main
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func main() {
+	app := tview.NewApplication()
+	download := tview.NewProgressBar().
+		SetText("Downloading").
+		SetShowPercentage(true)
+	disk := tview.NewProgressBar().
+		SetMax(512).
+		SetShowPercentage(true).
+		AddThreshold(0.75, tcell.ColorYellow).
+		AddThreshold(0.9, tcell.ColorRed)
+	search := tview.NewProgressBar().
+		SetIndeterminate(true)
+	spinner := tview.NewSpinner().
+		SetFrames(tview.SpinnerDots...).
+		SetText("Waiting for server")
+	form := tview.NewForm().
+		AddFormItem(download.SetLabel("Download")).
+		AddFormItem(disk.SetLabel("Disk usage")).
+		AddFormItem(search.SetLabel("Searching")).
+		AddFormItem(spinner.SetLabel("Status")).
+		AddButton("Quit", func() {
+			app.Stop()
+		})
+	form.SetBorder(true).SetTitle("Progress").SetTitleAlign(tview.AlignLeft)
+
+	// Update the primitives from separate goroutines.
+	go func() {
+		for !download.IsComplete() {
+			time.Sleep(50 * time.Millisecond)
+			download.AddValue(rand.Float64())
+			disk.AddValue(rand.Float64() * 2)
+			app.Draw()
+		}
+		download.SetText("Done")
+		app.Draw()
+	}()
+	go func() {
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for range ticker.C {
+			search.Tick()
+			spinner.Tick()
+			app.Draw()
+		}
+	}()
+
+	if err := app.SetRoot(form, true).EnableMouse(true).Run(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,31 @@
+// Demo code for the ScrollView primitive.
+package main
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+)
+
+const fieldCount = 30
+
+func main() {
+	app := tview.NewApplication()
+	form := tview.NewForm()
+	for index := 1; index <= fieldCount; index++ {
+		form.AddInputField(fmt.Sprintf("Setting %d", index), "", 20, nil, nil)
+	}
+	form.AddButton("Save", nil).
+		AddButton("Quit", func() {
+			app.Stop()
+		})
+
+	// Each form item takes two rows, plus the buttons and the form's padding.
+	scrollView := tview.NewScrollView().
+		SetContent(form).
+		SetContentSize(0, 2*fieldCount+3)
+	scrollView.SetBorder(true).SetTitle("Settings (press Tab to move down)")
+	if err := app.SetRoot(scrollView, true).EnableMouse(true).EnablePaste(true).Run(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,48 @@
+// Demo code for the Slider and NumberField primitives.
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+func main() {
+	app := tview.NewApplication()
+	status := tview.NewTextView()
+	form := tview.NewForm().
+		AddSlider("Volume", 40, 0, 100, 5, func(value float64) {
+			status.SetText(fmt.Sprintf("Volume: %g", value))
+		}).
+		AddSlider("Balance", 0, -1, 1, 0.1, func(value float64) {
+			status.SetText(fmt.Sprintf("Balance: %g", value))
+		}).
+		AddNumberField("Quantity", 1, 1, 99, 1, func(value float64) {
+			status.SetText(fmt.Sprintf("Quantity: %g", value))
+		}).
+		AddNumberField("Price", 9.99, 0, 10000, 0.01, func(value float64) {
+			status.SetText(fmt.Sprintf("Price: %.2f", value))
+		}).
+		AddButton("Quit", func() {
+			app.Stop()
+		})
+
+	// Numbers with a currency sign.
+	price := form.GetFormItem(3).(*tview.NumberField)
+	price.SetFormatFunc(func(value float64) string {
+		return "$" + strconv.FormatFloat(value, 'f', 2, 64)
+	}).SetParseFunc(func(text string) (float64, error) {
+		return strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(text), "$"), 64)
+	})
+	form.GetFormItem(0).(*tview.Slider).SetFieldWidth(30)
+	form.GetFormItem(1).(*tview.Slider).SetFieldWidth(30)
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(status, 1, 0, false)
+	if err := app.SetRoot(flex, true).EnableMouse(true).EnablePaste(true).Run(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,44 @@
+// Demo code for the SplitPane primitive.
+package main
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+)
+
+func main() {
+	app := tview.NewApplication()
+	status := tview.NewTextView().SetText("Drag the dividers or press Ctrl-\\ to resize the panes with the arrow keys.")
+	tree := tview.NewTextView().SetText("Files")
+	tree.SetBorder(true).SetTitle("Explorer")
+	editor := tview.NewTextArea().SetPlaceholder("Type here...")
+	editor.SetBorder(true).SetTitle("Editor")
+	console := tview.NewTextView().SetText("Collapse me by dragging the divider down.")
+	console.SetBorder(true).SetTitle("Console")
+
+	vertical := tview.NewSplitPane().
+		SetDirection(tview.FlexRow).
+		AddItem(editor, 0, 3, true).
+		AddItem(console, 0, 1, false)
+	vertical.SetItemLimits(1, 3, 0).SetItemCollapsible(1, true)
+
+	horizontal := tview.NewSplitPane().
+		AddItem(tree, 25, 0, false).
+		AddItem(vertical, 0, 1, true)
+	horizontal.SetItemLimits(0, 10, 40).SetItemCollapsible(0, true)
+
+	report := func([]int) {
+		status.SetText(fmt.Sprintf("Sizes: %v / %v", horizontal.GetSizes(), vertical.GetSizes()))
+	}
+	horizontal.SetChangedFunc(report)
+	vertical.SetChangedFunc(report)
+
+	root := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(horizontal, 0, 1, true).
+		AddItem(status, 1, 0, false)
+	if err := app.SetRoot(root, true).EnableMouse(true).Run(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,29 @@
+// Demo code for the Tabs primitive.
+package main
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+)
+
+func main() {
+	app := tview.NewApplication()
+	tabs := tview.NewTabs().SetCloseButtons(true)
+	tabs.SetBorder(true).SetTitle("Ctrl-PgUp/PgDn to switch, Ctrl-Shift-PgUp/PgDn or drag to move tabs")
+	for index := 1; index <= 12; index++ {
+		name := fmt.Sprintf("file-%d", index)
+		textArea := tview.NewTextArea().SetPlaceholder("Type something to mark this tab as dirty...")
+		textArea.SetChangedFunc(func() {
+			tabs.SetPageDirty(name, textArea.GetText() != "")
+		})
+		tabs.AddPage(name, fmt.Sprintf("File %d", index), textArea, false)
+	}
+	tabs.SetPageBadge("file-2", "[yellow]3")
+	tabs.SetCloseFunc(func(name string) bool {
+		return !tabs.IsPageDirty(name)
+	})
+	if err := app.SetRoot(tabs, true).EnableMouse(true).EnablePaste(true).Run(); err != nil {
+		panic(err)
+	}
+}
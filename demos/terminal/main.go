@@ -0,0 +1,75 @@
+// Demo code for the Terminal primitive. It runs a shell and "top" side by
+// side. Press Ctrl-N to switch between them and Ctrl-Q to quit.
+package main
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func main() {
+	app := tview.NewApplication()
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+
+	// newTerminal returns a terminal running the given command. The box title
+	// follows the terminal title.
+	newTerminal := func(title string, cmd *exec.Cmd) *tview.Terminal {
+		terminal := tview.NewTerminal()
+		terminal.SetBorder(true).SetTitle(title)
+		terminal.SetChangedFunc(func() {
+			app.Draw()
+		}).SetTitleChangedFunc(func(text string) {
+			app.QueueUpdateDraw(func() {
+				terminal.SetTitle(text)
+			})
+		}).SetDoneFunc(func(err error) {
+			app.QueueUpdateDraw(func() {
+				terminal.SetTitle(title + " (exited)")
+			})
+		})
+		if err := terminal.Start(cmd); err != nil {
+			panic(err)
+		}
+		return terminal
+	}
+	terminals := []*tview.Terminal{
+		newTerminal("Shell", exec.Command(shell)),
+		newTerminal("top", exec.Command("top")),
+	}
+	defer func() {
+		for _, terminal := range terminals {
+			terminal.Close()
+		}
+	}()
+
+	layout := tview.NewFlex().
+		AddItem(terminals[0], 0, 1, true).
+		AddItem(terminals[1], 0, 1, false)
+	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyCtrlN:
+			if terminals[0].HasFocus() {
+				app.SetFocus(terminals[1])
+			} else {
+				app.SetFocus(terminals[0])
+			}
+			return nil
+		case tcell.KeyCtrlQ:
+			app.Stop()
+			return nil
+		case tcell.KeyCtrlC:
+			// Forward Ctrl-C to the terminal instead of stopping the app.
+			return tcell.NewEventKey(tcell.KeyCtrlC, 0, tcell.ModNone)
+		}
+		return event
+	})
+	if err := app.SetRoot(layout, true).EnableMouse(true).EnablePaste(true).Run(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,33 @@
+// Demo code for the WindowManager primitive.
+package main
+
+import (
+	"github.com/rivo/tview"
+)
+
+func main() {
+	app := tview.NewApplication()
+	manager := tview.NewWindowManager()
+
+	notes := tview.NewTextArea().SetPlaceholder("Take some notes...")
+	notesWindow := tview.NewWindow(notes).SetPosition(2, 1).SetSize(40, 12)
+	notesWindow.SetTitle("Notes")
+
+	help := tview.NewTextView().
+		SetWrap(true).
+		SetText("Drag a window by its title bar, resize it by dragging its edges. Double-click the title bar to maximize it. Press F6 to cycle through the windows.")
+	helpWindow := tview.NewWindow(help).SetPosition(30, 6).SetSize(36, 8)
+	helpWindow.SetTitle("Help")
+
+	form := tview.NewForm().
+		AddInputField("Name", "", 20, nil, nil).
+		AddCheckbox("Subscribe", false, nil).
+		AddButton("Quit", app.Stop)
+	formWindow := tview.NewWindow(form).SetPosition(10, 12).SetSize(36, 10)
+	formWindow.SetTitle("Form")
+
+	manager.AddWindow(notesWindow).AddWindow(helpWindow).AddWindow(formWindow)
+	if err := app.SetRoot(manager, true).EnableMouse(true).Run(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,871 @@
+package tview
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// Dialog is a window with arbitrary content (e.g. an [InputField], a [Form],
+// or a [List]) and an optional row of buttons below it. Dialogs are shown on
+// top of other primitives by a [Dialogs] container which also takes care of
+// restoring the focus when a dialog is closed.
+//
+// The user can move between the content and the buttons with the Tab and
+// Backtab keys, and between buttons with the Left and Right arrow keys. If a
+// cancel handler was set with [Dialog.SetCancelFunc], the Escape key closes
+// the dialog and invokes the handler.
+type Dialog struct {
+	*Box
+
+	// The primitive shown in the dialog.
+	content Primitive
+
+	// The form containing the buttons.
+	buttons *Form
+
+	// The size of the dialog. Zero values are determined automatically.
+	width, height int
+
+	// If set, this function returns the height of the content given its width.
+	// It is used to determine the height of the dialog automatically.
+	contentHeight func(width int) int
+
+	// An optional function which is called when the user cancels the dialog.
+	cancel func()
+
+	// The container this dialog is shown in. Nil if it is not shown.
+	dialogs *Dialogs
+
+	// The primitive which had focus before the dialog was opened.
+	previousFocus Primitive
+}
+
+// NewDialog returns a new dialog with the given content which may be nil.
+func NewDialog(content Primitive) *Dialog {
+	d := &Dialog{
+		Box:     NewBox().SetBorder(true),
+		content: content,
+	}
+	d.buttons = NewForm().SetButtonsAlign(AlignCenter)
+	d.buttons.SetBorderPadding(0, 0, 0, 0)
+	d.Box.Primitive = d
+	return d
+}
+
+// SetContent sets the primitive shown in the dialog.
+func (d *Dialog) SetContent(content Primitive) *Dialog {
+	d.content = content
+	return d
+}
+
+// GetContent returns the primitive shown in the dialog.
+func (d *Dialog) GetContent() Primitive {
+	return d.content
+}
+
+// SetSize sets the width and height of the dialog, including its border. If
+// a value is 0, it is determined automatically: The width will be a third of
+// the width of the [Dialogs] container but at least wide enough for the
+// buttons and the title. The height will be half of the container's height.
+func (d *Dialog) SetSize(width, height int) *Dialog {
+	d.width, d.height = width, height
+	return d
+}
+
+// AddButton adds a button to the dialog. The "selected" handler is called
+// when the user selects the button. Note that buttons do not close the dialog
+// automatically. Call [Dialog.Close] in the handler to do so.
+func (d *Dialog) AddButton(label string, selected func()) *Dialog {
+	d.buttons.AddButton(label, selected)
+	return d
+}
+
+// GetButton returns the button at the specified 0-based index.
+func (d *Dialog) GetButton(index int) *Button {
+	return d.buttons.GetButton(index)
+}
+
+// GetButtonCount returns the number of buttons in this dialog.
+func (d *Dialog) GetButtonCount() int {
+	return d.buttons.GetButtonCount()
+}
+
+// SetButtonStyle sets the style of the buttons when they are not focused.
+func (d *Dialog) SetButtonStyle(style tcell.Style) *Dialog {
+	d.buttons.SetButtonStyle(style)
+	return d
+}
+
+// SetButtonActivatedStyle sets the style of the buttons when they are focused.
+func (d *Dialog) SetButtonActivatedStyle(style tcell.Style) *Dialog {
+	d.buttons.SetButtonActivatedStyle(style)
+	return d
+}
+
+// SetBackgroundColor sets the background color of the dialog, including the
+// area behind the buttons.
+func (d *Dialog) SetBackgroundColor(color tcell.Color) *Dialog {
+	d.Box.SetBackgroundColor(color)
+	d.buttons.SetBackgroundColor(color)
+	return d
+}
+
+// SetCancelFunc sets a handler which is called when the user hits the Escape
+// key. The dialog is closed before the handler is called. If no handler is set
+// (the default), the Escape key is passed on to the dialog's content.
+func (d *Dialog) SetCancelFunc(handler func()) *Dialog {
+	d.cancel = handler
+	return d
+}
+
+// Close removes the dialog from the [Dialogs] container it is shown in and
+// returns the focus to the primitive which had it before the dialog was
+// opened. Nothing happens if the dialog is not shown.
+func (d *Dialog) Close() {
+	if d.dialogs != nil {
+		d.dialogs.Close(d)
+	}
+}
+
+// IsOpen returns whether or not the dialog is currently shown in a [Dialogs]
+// container.
+func (d *Dialog) IsOpen() bool {
+	return d.dialogs != nil
+}
+
+// size returns the width and height of the dialog, including its border, when
+// shown in a container of the given size.
+func (d *Dialog) size(containerWidth, containerHeight int) (width, height int) {
+	width, height = d.width, d.height
+	if width <= 0 {
+		width = max(containerWidth/3, 30, TaggedStringWidth(d.title)+4)
+		buttonsWidth := -2
+		for _, button := range d.buttons.buttons {
+			buttonsWidth += TaggedStringWidth(button.text) + 4 + 2
+		}
+		width = max(width, buttonsWidth+4)
+	}
+	width = min(width, containerWidth)
+	if height <= 0 {
+		if d.contentHeight != nil {
+			height = d.contentHeight(width-2) + 2
+			if d.buttons.GetButtonCount() > 0 {
+				height += 2
+			}
+		} else {
+			height = containerHeight / 2
+		}
+	}
+	height = min(height, containerHeight)
+	return
+}
+
+// Draw draws this primitive onto the screen.
+func (d *Dialog) Draw(screen tcell.Screen) {
+	d.Box.DrawForSubclass(screen, d)
+	x, y, width, height := d.GetInnerRect()
+
+	// Draw the buttons.
+	if d.buttons.GetButtonCount() > 0 && height > 0 {
+		d.buttons.SetRect(x, y+height-1, width, 1)
+		d.buttons.Draw(screen)
+		height -= 2
+	}
+
+	// Draw the content.
+	if d.content != nil && height > 0 {
+		d.content.SetRect(x, y, width, height)
+		d.content.Draw(screen)
+	}
+}
+
+// Focus is called when this primitive receives focus.
+func (d *Dialog) Focus(delegate func(p Primitive)) {
+	if d.content != nil {
+		delegate(d.content)
+		return
+	}
+	if d.buttons.GetButtonCount() > 0 {
+		delegate(d.buttons)
+		return
+	}
+	d.Box.Focus(delegate)
+}
+
+// focusChain implements the [Primitive]'s focusChain method.
+func (d *Dialog) focusChain(chain *[]Primitive) bool {
+	if d.content != nil {
+		if hasFocus := d.content.focusChain(chain); hasFocus {
+			if chain != nil {
+				*chain = append(*chain, d)
+			}
+			return true
+		}
+	}
+	if hasFocus := d.buttons.focusChain(chain); hasFocus {
+		if chain != nil {
+			*chain = append(*chain, d)
+		}
+		return true
+	}
+	return d.Box.focusChain(chain)
+}
+
+// allowExit returns whether the focused primitive of the dialog's content
+// allows the dialog to handle the given key event (see [FormItem.AllowExit]).
+func (d *Dialog) allowExit(event *tcell.EventKey) bool {
+	if d.content == nil {
+		return true
+	}
+	var chain []Primitive
+	if !d.content.focusChain(&chain) {
+		return true
+	}
+	for _, p := range chain {
+		if item, ok := p.(FormItem); ok {
+			return item.AllowExit(event)
+		}
+	}
+	return true
+}
+
+// moveFocus moves the focus from the content to the buttons or vice versa if
+// the focus is at the end (or start, if "forward" is false) of the content or
+// the buttons. It returns true if the focus was moved.
+func (d *Dialog) moveFocus(forward bool, setFocus func(p Primitive)) bool {
+	if d.content == nil || d.buttons.GetButtonCount() == 0 {
+		return false
+	}
+
+	// From the content to the buttons.
+	if d.content.HasFocus() {
+		if form, ok := d.content.(*Form); ok {
+			index, last := form.focusIndex(), form.GetFormItemCount()+form.GetButtonCount()-1
+			if forward && index != last || !forward && index != 0 {
+				return false // The form will handle this.
+			}
+		}
+		if forward {
+			d.buttons.SetFocus(0)
+		} else {
+			d.buttons.SetFocus(d.buttons.GetButtonCount() - 1)
+		}
+		setFocus(d.buttons)
+		return true
+	}
+
+	// From the buttons to the content.
+	index := d.buttons.focusIndex()
+	if forward && index != d.buttons.GetButtonCount()-1 || !forward && index != 0 {
+		return false
+	}
+	if form, ok := d.content.(*Form); ok {
+		if forward {
+			form.SetFocus(0)
+		} else {
+			form.SetFocus(form.GetFormItemCount() + form.GetButtonCount() - 1)
+		}
+	}
+	setFocus(d.content)
+	return true
+}
+
+// InputHandler returns the handler for this primitive.
+func (d *Dialog) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return d.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		switch key := event.Key(); key {
+		case tcell.KeyEscape:
+			if d.cancel != nil && d.allowExit(event) {
+				d.Close()
+				d.cancel()
+				return
+			}
+		case tcell.KeyTab, tcell.KeyBacktab:
+			if d.allowExit(event) && d.moveFocus(key == tcell.KeyTab, setFocus) {
+				return
+			}
+		case tcell.KeyLeft, tcell.KeyRight:
+			if d.buttons.HasFocus() {
+				// Arrow keys move between buttons.
+				index := d.buttons.focusIndex()
+				if key == tcell.KeyLeft && index > 0 {
+					setFocus(d.buttons.GetButton(index - 1))
+				} else if key == tcell.KeyRight && index < d.buttons.GetButtonCount()-1 {
+					setFocus(d.buttons.GetButton(index + 1))
+				}
+				return
+			}
+		}
+
+		// Pass the event on to the focused primitive.
+		if d.content != nil && d.content.HasFocus() {
+			if handler := d.content.InputHandler(); handler != nil {
+				handler(event, setFocus)
+			}
+		} else if d.buttons.HasFocus() {
+			if handler := d.buttons.InputHandler(); handler != nil {
+				handler(event, setFocus)
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (d *Dialog) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return d.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if d.content != nil {
+			consumed, capture = d.content.MouseHandler()(action, event, setFocus)
+			if consumed {
+				return
+			}
+		}
+		if d.buttons.GetButtonCount() > 0 {
+			consumed, capture = d.buttons.MouseHandler()(action, event, setFocus)
+			if consumed {
+				return
+			}
+		}
+		if action == MouseLeftDown && d.InRect(event.Position()) {
+			d.Focus(setFocus)
+			consumed = true
+		}
+		return
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (d *Dialog) PasteHandler() func(pastedText string, setFocus func(p Primitive)) {
+	return d.WrapPasteHandler(func(pastedText string, setFocus func(p Primitive)) {
+		if d.content != nil && d.content.HasFocus() {
+			if handler := d.content.PasteHandler(); handler != nil {
+				handler(pastedText, setFocus)
+			}
+		}
+	})
+}
+
+// ProgressDialog is a [Dialog] which shows a message and a progress bar for
+// long-running operations. It is returned by [Dialogs.Progress].
+//
+// Its methods are not thread-safe. When the operation runs in a separate
+// goroutine, call them from within [Application.QueueUpdateDraw].
+type ProgressDialog struct {
+	*Dialog
+
+	// The primitive showing the message and the progress bar.
+	indicator *progressIndicator
+}
+
+// SetMessage sets the message shown above the progress bar.
+func (p *ProgressDialog) SetMessage(message string) *ProgressDialog {
+	p.indicator.message = message
+	return p
+}
+
+// SetProgress sets the progress of the operation, a value between 0 and 1.
+func (p *ProgressDialog) SetProgress(progress float64) *ProgressDialog {
+	p.indicator.bar.SetValue(progress)
+	return p
+}
+
+// GetProgress returns the progress of the operation, a value between 0 and 1.
+func (p *ProgressDialog) GetProgress() float64 {
+	return p.indicator.bar.GetValue()
+}
+
+// progressIndicator shows a word-wrapped message followed by a progress bar.
+type progressIndicator struct {
+	*Box
+
+	// The message shown above the progress bar.
+	message string
+
+	// The progress bar, with a maximum value of 1.
+	bar *ProgressBar
+}
+
+// height returns the number of rows needed for the given width.
+func (p *progressIndicator) height(width int) int {
+	if p.message == "" {
+		return 1
+	}
+	return len(WordWrap(p.message, width)) + 1
+}
+
+// Draw draws this primitive onto the screen.
+func (p *progressIndicator) Draw(screen tcell.Screen) {
+	p.Box.DrawForSubclass(screen, p)
+	x, y, width, height := p.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Draw the message.
+	if p.message != "" {
+		for _, line := range WordWrap(p.message, width) {
+			if height <= 1 {
+				break
+			}
+			printWithStyle(screen, line, x, y, 0, width, AlignCenter, tcell.StyleDefault.Foreground(Styles.PrimaryTextColor).Background(p.backgroundColor), true)
+			y++
+			height--
+		}
+	}
+
+	// Draw the progress bar.
+	p.bar.SetRect(x, y, width, 1)
+	p.bar.Draw(screen)
+}
+
+// Dialogs is a container which shows a primitive (the root) and, on top of it,
+// a stack of [Dialog]s. Only the top-most dialog can be interacted with. When
+// a dialog is closed, the focus returns to the primitive which had it before
+// the dialog was opened.
+//
+// Helper functions such as [Dialogs.Alert], [Dialogs.Confirm],
+// [Dialogs.Prompt], and [Dialogs.Choose] open common dialogs and deliver the
+// user's response to a callback. Their counterparts ending in "Chan" (e.g.
+// [Dialogs.ConfirmChan]) deliver the response through a channel instead and
+// may be called from any goroutine.
+//
+// The Dialogs container needs access to the [Application] to manage the
+// focus. It is typically the application's root primitive:
+//
+//	app := tview.NewApplication()
+//	dialogs := tview.NewDialogs(app).SetRoot(mainLayout)
+//	app.SetRoot(dialogs, true)
+type Dialogs struct {
+	*Box
+
+	// The application used to manage the focus.
+	app *Application
+
+	// The primitive shown below the dialogs.
+	root Primitive
+
+	// The dialogs currently shown, the top-most dialog last.
+	stack []*Dialog
+}
+
+// NewDialogs returns a new, empty dialogs container for the given
+// application.
+func NewDialogs(app *Application) *Dialogs {
+	d := &Dialogs{
+		Box: NewBox(),
+		app: app,
+	}
+	d.Box.Primitive = d
+	return d
+}
+
+// SetRoot sets the primitive shown below the dialogs. It fills the entire
+// inner area of the container.
+func (d *Dialogs) SetRoot(root Primitive) *Dialogs {
+	d.root = root
+	return d
+}
+
+// GetRoot returns the primitive shown below the dialogs.
+func (d *Dialogs) GetRoot() Primitive {
+	return d.root
+}
+
+// Open shows the given dialog on top of all other dialogs and gives it focus.
+// If the dialog is already open, it is moved to the top.
+//
+// This function is not thread-safe. From other goroutines, call it from within
+// [Application.QueueUpdateDraw].
+func (d *Dialogs) Open(dialog *Dialog) *Dialogs {
+	if dialog.dialogs != nil {
+		dialog.dialogs.remove(dialog)
+	}
+	dialog.previousFocus = d.app.GetFocus()
+	dialog.dialogs = d
+	d.stack = append(d.stack, dialog)
+	d.app.SetFocus(dialog)
+	return d
+}
+
+// Close removes the given dialog. If it was the top-most dialog, the focus
+// returns to the primitive which had it before the dialog was opened.
+//
+// This function is not thread-safe. From other goroutines, call it from within
+// [Application.QueueUpdateDraw].
+func (d *Dialogs) Close(dialog *Dialog) *Dialogs {
+	if dialog.dialogs != d {
+		return d
+	}
+	top := d.stack[len(d.stack)-1] == dialog
+	d.remove(dialog)
+	if !top {
+		return d
+	}
+	if focus := dialog.previousFocus; focus != nil {
+		d.app.SetFocus(focus)
+	} else {
+		d.app.SetFocus(d)
+	}
+	return d
+}
+
+// remove removes the given dialog from the stack without changing the focus.
+func (d *Dialogs) remove(dialog *Dialog) {
+	for index, dlg := range d.stack {
+		if dlg != dialog {
+			continue
+		}
+		// The dialog above this one returns the focus to where this one would
+		// have returned it.
+		if index < len(d.stack)-1 {
+			d.stack[index+1].previousFocus = dialog.previousFocus
+		}
+		d.stack = append(d.stack[:index], d.stack[index+1:]...)
+		break
+	}
+	dialog.dialogs = nil
+}
+
+// GetDialogCount returns the number of open dialogs.
+func (d *Dialogs) GetDialogCount() int {
+	return len(d.stack)
+}
+
+// GetTopDialog returns the top-most dialog or nil if no dialog is open.
+func (d *Dialogs) GetTopDialog() *Dialog {
+	if len(d.stack) == 0 {
+		return nil
+	}
+	return d.stack[len(d.stack)-1]
+}
+
+// newMessage returns a text view showing the given message and a function
+// returning its height for a given width.
+func newMessage(message string) (*TextView, func(width int) int) {
+	text := NewTextView().
+		SetText(message).
+		SetWordWrap(true).
+		SetTextAlign(AlignCenter)
+	return text, func(width int) int {
+		return max(len(WordWrap(message, width)), 1)
+	}
+}
+
+// Alert opens a dialog with the given message and an "OK" button. The "done"
+// handler, which may be nil, is called after the dialog was closed.
+func (d *Dialogs) Alert(title, message string, done func()) *Dialog {
+	text, height := newMessage(message)
+	dialog := NewDialog(text)
+	dialog.contentHeight = height
+	closed := func() {
+		if done != nil {
+			done()
+		}
+	}
+	dialog.SetCancelFunc(closed).
+		AddButton("OK", func() {
+			dialog.Close()
+			closed()
+		}).
+		SetTitle(title)
+	d.Open(dialog)
+	d.app.SetFocus(dialog.buttons)
+	return dialog
+}
+
+// Confirm opens a dialog with the given message and "Yes" and "No" buttons.
+// The "done" handler is called after the dialog was closed. It receives true
+// if the user selected "Yes" and false if they selected "No" or cancelled the
+// dialog with the Escape key.
+func (d *Dialogs) Confirm(title, message string, done func(confirmed bool)) *Dialog {
+	text, height := newMessage(message)
+	dialog := NewDialog(text)
+	dialog.contentHeight = height
+	closed := func(confirmed bool) {
+		dialog.Close()
+		if done != nil {
+			done(confirmed)
+		}
+	}
+	dialog.SetCancelFunc(func() {
+		if done != nil {
+			done(false)
+		}
+	}).
+		AddButton("Yes", func() { closed(true) }).
+		AddButton("No", func() { closed(false) }).
+		SetTitle(title)
+	d.Open(dialog)
+	d.app.SetFocus(dialog.buttons)
+	return dialog
+}
+
+// Prompt opens a dialog which asks the user to enter a value. The dialog
+// contains an input field with the given label and initial value, and "OK"
+// and "Cancel" buttons. The "done" handler is called after the dialog was
+// closed. It receives the entered value and true if the user confirmed the
+// value (by pressing Enter or selecting "OK"), or the initial value and false
+// if the user cancelled the dialog.
+func (d *Dialogs) Prompt(title, label, value string, done func(value string, ok bool)) *Dialog {
+	input := NewInputField().
+		SetLabel(label).
+		SetText(value)
+	dialog := NewDialog(input)
+	dialog.contentHeight = func(width int) int { return 1 }
+	closed := func(ok bool) {
+		dialog.Close()
+		if done != nil {
+			if ok {
+				done(input.GetText(), true)
+			} else {
+				done(value, false)
+			}
+		}
+	}
+	input.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			closed(true)
+		}
+	})
+	dialog.SetCancelFunc(func() {
+		if done != nil {
+			done(value, false)
+		}
+	}).
+		AddButton("OK", func() { closed(true) }).
+		AddButton("Cancel", func() { closed(false) }).
+		SetTitle(title)
+	d.Open(dialog)
+	return dialog
+}
+
+// Choose opens a dialog which lets the user choose one of the given options
+// from a list. The "done" handler is called after the dialog was closed. It
+// receives the index of the selected option and the option itself, or -1 and
+// an empty string if the user cancelled the dialog.
+func (d *Dialogs) Choose(title string, options []string, done func(index int, option string)) *Dialog {
+	list := NewList().ShowSecondaryText(false)
+	dialog := NewDialog(list)
+	dialog.contentHeight = func(width int) int { return max(len(options), 1) }
+	width := 0
+	for _, option := range options {
+		list.AddItem(option, "", 0, nil)
+		width = max(width, TaggedStringWidth(option))
+	}
+	if width+4 > 30 {
+		dialog.SetSize(width+4, 0)
+	}
+	list.SetSelectedFunc(func(index int, option, _ string, _ rune) {
+		dialog.Close()
+		if done != nil {
+			done(index, option)
+		}
+	})
+	cancelled := func() {
+		if done != nil {
+			done(-1, "")
+		}
+	}
+	dialog.SetCancelFunc(cancelled).
+		AddButton("Cancel", func() {
+			dialog.Close()
+			cancelled()
+		}).
+		SetTitle(title)
+	d.Open(dialog)
+	return dialog
+}
+
+// Progress opens a dialog which shows the given message and a progress bar
+// for long-running operations. If a "cancel" handler is provided, the dialog
+// contains a "Cancel" button and can also be cancelled with the Escape key.
+// The handler is called after the dialog was closed. The dialog can be closed
+// programmatically with [Dialog.Close].
+func (d *Dialogs) Progress(title, message string, cancel func()) *ProgressDialog {
+	indicator := &progressIndicator{
+		Box:     NewBox(),
+		message: message,
+		bar:     NewProgressBar().SetMax(1).SetShowPercentage(true),
+	}
+	indicator.Box.Primitive = indicator
+	dialog := NewDialog(indicator)
+	dialog.contentHeight = indicator.height
+	if cancel != nil {
+		dialog.SetCancelFunc(cancel).
+			AddButton("Cancel", func() {
+				dialog.Close()
+				cancel()
+			})
+	}
+	dialog.SetTitle(title)
+	d.Open(dialog)
+	return &ProgressDialog{Dialog: dialog, indicator: indicator}
+}
+
+// AlertChan is like [Dialogs.Alert] but may be called from any goroutine. The
+// returned channel is closed when the dialog was closed. Do not wait for the
+// channel in the goroutine which handles the application's events (e.g. in an
+// event handler or callback) as this will cause a deadlock.
+func (d *Dialogs) AlertChan(title, message string) <-chan struct{} {
+	ch := make(chan struct{})
+	d.app.QueueUpdateDraw(func() {
+		d.Alert(title, message, func() { close(ch) })
+	})
+	return ch
+}
+
+// ConfirmChan is like [Dialogs.Confirm] but may be called from any goroutine.
+// The user's response is sent to the returned channel which is then closed.
+// If the user cancelled the dialog, the channel is closed without sending a
+// value. Do not wait for the channel in the goroutine which handles the
+// application's events as this will cause a deadlock.
+func (d *Dialogs) ConfirmChan(title, message string) <-chan bool {
+	ch := make(chan bool, 1)
+	d.app.QueueUpdateDraw(func() {
+		dialog := d.Confirm(title, message, func(confirmed bool) {
+			ch <- confirmed
+			close(ch)
+		})
+		dialog.SetCancelFunc(func() { close(ch) })
+	})
+	return ch
+}
+
+// PromptChan is like [Dialogs.Prompt] but may be called from any goroutine.
+// The entered value is sent to the returned channel which is then closed. If
+// the user cancelled the dialog, the channel is closed without sending a
+// value. Do not wait for the channel in the goroutine which handles the
+// application's events as this will cause a deadlock.
+func (d *Dialogs) PromptChan(title, label, value string) <-chan string {
+	ch := make(chan string, 1)
+	d.app.QueueUpdateDraw(func() {
+		d.Prompt(title, label, value, func(value string, ok bool) {
+			if ok {
+				ch <- value
+			}
+			close(ch)
+		})
+	})
+	return ch
+}
+
+// ChooseChan is like [Dialogs.Choose] but may be called from any goroutine.
+// The index of the selected option is sent to the returned channel which is
+// then closed. If the user cancelled the dialog, the channel is closed
+// without sending a value. Do not wait for the channel in the goroutine which
+// handles the application's events as this will cause a deadlock.
+func (d *Dialogs) ChooseChan(title string, options []string) <-chan int {
+	ch := make(chan int, 1)
+	d.app.QueueUpdateDraw(func() {
+		d.Choose(title, options, func(index int, option string) {
+			if index >= 0 {
+				ch <- index
+			}
+			close(ch)
+		})
+	})
+	return ch
+}
+
+// Draw draws this primitive onto the screen.
+func (d *Dialogs) Draw(screen tcell.Screen) {
+	d.Box.DrawForSubclass(screen, d)
+	x, y, width, height := d.GetInnerRect()
+	if d.root != nil {
+		d.root.SetRect(x, y, width, height)
+		d.root.Draw(screen)
+	}
+	for _, dialog := range d.stack {
+		w, h := dialog.size(width, height)
+		dialog.SetRect(x+(width-w)/2, y+(height-h)/2, w, h)
+		dialog.Draw(screen)
+	}
+}
+
+// Focus is called when this primitive receives focus.
+func (d *Dialogs) Focus(delegate func(p Primitive)) {
+	if top := d.GetTopDialog(); top != nil {
+		delegate(top)
+		return
+	}
+	if d.root != nil {
+		delegate(d.root)
+		return
+	}
+	d.Box.Focus(delegate)
+}
+
+// focusChain implements the [Primitive]'s focusChain method.
+func (d *Dialogs) focusChain(chain *[]Primitive) bool {
+	for _, dialog := range d.stack {
+		if hasFocus := dialog.focusChain(chain); hasFocus {
+			if chain != nil {
+				*chain = append(*chain, d)
+			}
+			return true
+		}
+	}
+	if d.root != nil {
+		if hasFocus := d.root.focusChain(chain); hasFocus {
+			if chain != nil {
+				*chain = append(*chain, d)
+			}
+			return true
+		}
+	}
+	return d.Box.focusChain(chain)
+}
+
+// InputHandler returns the handler for this primitive.
+func (d *Dialogs) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return d.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		for _, dialog := range d.stack {
+			if dialog.HasFocus() {
+				if handler := dialog.InputHandler(); handler != nil {
+					handler(event, setFocus)
+				}
+				return
+			}
+		}
+		if d.root != nil && d.root.HasFocus() {
+			if handler := d.root.InputHandler(); handler != nil {
+				handler(event, setFocus)
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive. While a dialog is
+// open, only the top-most dialog receives mouse events.
+func (d *Dialogs) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return d.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if !d.InRect(event.Position()) {
+			return false, nil
+		}
+		if top := d.GetTopDialog(); top != nil {
+			consumed, capture = top.MouseHandler()(action, event, setFocus)
+			return true, capture
+		}
+		if d.root != nil {
+			return d.root.MouseHandler()(action, event, setFocus)
+		}
+		return
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (d *Dialogs) PasteHandler() func(pastedText string, setFocus func(p Primitive)) {
+	return d.WrapPasteHandler(func(pastedText string, setFocus func(p Primitive)) {
+		for _, dialog := range d.stack {
+			if dialog.HasFocus() {
+				if handler := dialog.PasteHandler(); handler != nil {
+					handler(pastedText, setFocus)
+				}
+				return
+			}
+		}
+		if d.root != nil && d.root.HasFocus() {
+			if handler := d.root.PasteHandler(); handler != nil {
+				handler(pastedText, setFocus)
+			}
+		}
+	})
+}
@@ -10,22 +10,44 @@ The package implements the following widgets:
   - [TextView]: A scrollable window that display multi-colored text. Text may
     also be highlighted.
   - [TextArea]: An editable multi-line text area.
+  - [Markdown]: A scrollable view of a document written in Markdown.
+  - [LogView]: A scrollable view of log entries which can be filtered.
+    Log records of the log/slog package can be routed to a log view or a text
+    view with a [LogHandler].
   - [Table]: A scrollable display of tabular data. Table cells, rows, or columns
     may also be highlighted.
   - [TreeView]: A scrollable display for hierarchical data. Tree nodes can be
     highlighted, collapsed, expanded, and more.
+  - [FilePicker]: A selector for files and directories of any file system.
   - [List]: A navigable text list with optional keyboard shortcuts.
   - [InputField]: One-line input fields to enter text.
   - [DropDown]: Drop-down selection fields.
   - [Checkbox]: Selectable checkbox for boolean values.
+  - [RadioGroup]: A group of mutually exclusive options.
+  - [DatePicker]: Date and date range selection fields with a pop-up calendar.
+  - [TimePicker]: Time and time range input fields.
+  - [Slider]: A horizontal track for choosing a number from a range.
+  - [NumberField]: Input fields for numbers which can be stepped up and down.
   - [Image]: Displays images.
+  - [Canvas]: A grid of pixels smaller than a cell with drawing functions.
+  - [LineChart], [BarChart], [Sparkline], [Histogram]: Charts for numerical
+    data.
+  - [ProgressBar]: A bar which visualizes the progress of an operation.
+  - [Spinner]: A small animation which indicates that an operation is ongoing.
+  - [Terminal]: A terminal emulator which runs a command in a pseudo-terminal.
   - [Button]: Buttons which get activated when the user selects them.
   - [Form]: Forms composed of input fields, drop down selections, checkboxes,
     and buttons.
   - [Modal]: A centered window with a text message and one or more buttons.
+  - [Dialogs]: A container for stacked dialogs such as prompts and confirmations.
+  - [MenuBar]: A bar of pull-down menus with submenus and keyboard shortcuts.
   - [Grid]: A grid based layout manager.
   - [Flex]: A Flexbox based layout manager.
+  - [SplitPane]: A layout manager with panes which can be resized by the user.
+  - [ScrollView]: A container for primitives larger than the visible area.
   - [Pages]: A page based layout manager.
+  - [Tabs]: A page based layout manager with a tab strip.
+  - [WindowManager]: A container for movable and resizable windows.
 
 The package also provides Application which is used to poll the event queue and
 draw widgets on screen.
@@ -154,6 +176,14 @@ This package therefore does not support using the terminal's color theme. The
 default style is a dark theme and you must change the [Styles] variable to
 switch to a light (or other) theme.
 
+# Syntax Highlighting
+
+The text of a [TextArea] or a [TextView] can be highlighted with a
+[Highlighter] which maps ranges of text to styles. The [SyntaxHighlighter]
+provides syntax highlighting for a few common languages:
+
+	textArea.SetHighlighter(tview.NewSyntaxHighlighter("go"))
+
 # Unicode Support
 
 This package supports all unicode characters supported by your terminal.
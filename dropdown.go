@@ -1,12 +1,58 @@
 package tview
 
 import (
+	"fmt"
 	"regexp"
+	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+var (
+	// DropDownMatchSubstring matches options which contain the text entered
+	// into a combo box, ignoring case. This is the default match function (see
+	// [DropDown.SetMatchFunc]).
+	DropDownMatchSubstring = func(option, text string) (matched bool, positions []int) {
+		optionRunes, textRunes := lowerRunes(option), lowerRunes(text)
+		for start := 0; start+len(textRunes) <= len(optionRunes); start++ {
+			if slices.Equal(optionRunes[start:start+len(textRunes)], textRunes) {
+				for index := range textRunes {
+					positions = append(positions, start+index)
+				}
+				return true, positions
+			}
+		}
+		return false, nil
+	}
+
+	// DropDownMatchFuzzy matches options which contain all characters of the
+	// text entered into a combo box in the same order but not necessarily next
+	// to each other, ignoring case.
+	DropDownMatchFuzzy = func(option, text string) (matched bool, positions []int) {
+		textRunes := lowerRunes(text)
+		for index, r := range lowerRunes(option) {
+			if len(positions) < len(textRunes) && r == textRunes[len(positions)] {
+				positions = append(positions, index)
+			}
+		}
+		if len(positions) < len(textRunes) {
+			return false, nil
+		}
+		return true, positions
+	}
+)
+
+// lowerRunes returns the runes of the given string, mapped to lower case.
+func lowerRunes(text string) []rune {
+	runes := []rune(text)
+	for index, r := range runes {
+		runes[index] = unicode.ToLower(r)
+	}
+	return runes
+}
+
 // dropDownOption is one option that can be selected in a drop-down primitive.
 type dropDownOption struct {
 	Text     string // The text to be displayed in the drop-down.
@@ -16,6 +62,22 @@ type dropDownOption struct {
 // DropDown implements a selection widget whose options become visible in a
 // drop-down list when activated.
 //
+// In multi-selection mode (see [DropDown.SetMultiSelect]), any number of
+// options can be selected. The open drop-down list then shows a checkbox in
+// front of each option. Enter, Space, and mouse clicks toggle options without
+// closing the list, and Ctrl-A selects all or no options. The closed drop-down
+// shows a comma-separated list of the selected options or, if it doesn't fit,
+// their number (see [DropDown.SetSelectionSummaryFormat]).
+//
+// # Combo Boxes
+//
+// In combo box mode (see [DropDown.SetComboBox]), the user types into the
+// field and the drop-down list shows only the options which match the entered
+// text (see [DropDown.SetMatchFunc]), with the matched characters highlighted.
+// Optionally, the entered text may be accepted when no option matches (see
+// [DropDown.SetAllowFreeText]). For large or remote sets of options, the
+// options may also be provided on demand (see [DropDown.SetOptionsProvider]).
+//
 // See https://github.com/rivo/tview/wiki/DropDown for an example.
 type DropDown struct {
 	*Box
@@ -39,6 +101,43 @@ type DropDown struct {
 	// The text to be displayed when no option has yet been selected.
 	noSelection string
 
+	// Whether or not multiple options can be selected.
+	multiSelect bool
+
+	// The format string used to display the number of selected options in
+	// multi-selection mode when their texts don't fit into the field.
+	summaryFormat string
+
+	// Whether or not the drop-down is a combo box whose options are filtered
+	// by the text entered by the user.
+	comboBox bool
+
+	// Whether or not a combo box accepts entered text which matches no option.
+	allowFreeText bool
+
+	// The text accepted by a combo box which did not match any option. Only
+	// relevant if currentOption is negative.
+	freeText string
+
+	// The function which determines if an option matches the text entered into
+	// a combo box, returning the positions of the matched runes.
+	matchFunc func(option, text string) (matched bool, positions []int)
+
+	// The style of the matched characters of the options of a combo box.
+	matchStyle tcell.Style
+
+	// The indices of the options shown in the drop-down list. If nil, all
+	// options are shown.
+	filtered []int
+
+	// An optional function which provides the options of a combo box for the
+	// entered text.
+	optionsProvider func(text string, setOptions func(options []string))
+
+	// The number of times the options provider was invoked. Used to discard
+	// outdated options.
+	lookups int
+
 	// Set to true if the options are visible and selectable.
 	open bool
 
@@ -85,6 +184,10 @@ type DropDown struct {
 	// selection.
 	selected func(text string, index int)
 
+	// A callback function which is called when the selection changes in
+	// multi-selection mode.
+	selectionChanged func(indices []int)
+
 	// Set to true when mouse dragging is in progress.
 	dragging bool
 }
@@ -111,7 +214,15 @@ func NewDropDown() *DropDown {
 		focusedStyle:  tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
 		disabledStyle: tcell.StyleDefault.Background(box.backgroundColor).Foreground(Styles.SecondaryTextColor),
 		prefixStyle:   tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
+		summaryFormat: "%d selected",
+		matchFunc:     DropDownMatchSubstring,
+		matchStyle:    tcell.StyleDefault.Underline(true),
 	}
+	list.SetSelectionChangedFunc(func(indices []int) {
+		if d.selectionChanged != nil {
+			d.selectionChanged(indices)
+		}
+	})
 
 	d.Box.Primitive = d
 	return d
@@ -121,6 +232,7 @@ func NewDropDown() *DropDown {
 // be a negative value to indicate that no option is currently selected. Calling
 // this function will also trigger the "selected" callback (if there is one).
 func (d *DropDown) SetCurrentOption(index int) *DropDown {
+	d.freeText = ""
 	if index >= 0 && index < len(d.options) {
 		d.currentOption = index
 		d.list.SetCurrentItem(index)
@@ -142,14 +254,287 @@ func (d *DropDown) SetCurrentOption(index int) *DropDown {
 
 // GetCurrentOption returns the index of the currently selected option as well
 // as its text. If no option was selected, -1 and an empty string is returned.
+// If a combo box accepted text which matches no option (see
+// [DropDown.SetAllowFreeText]), -1 and that text is returned.
 func (d *DropDown) GetCurrentOption() (int, string) {
-	var text string
+	text := d.freeText
 	if d.currentOption >= 0 && d.currentOption < len(d.options) {
 		text = d.options[d.currentOption].Text
 	}
 	return d.currentOption, text
 }
 
+// SetComboBox sets whether or not the drop-down is a combo box. See the
+// [DropDown] documentation for details. Combo boxes don't support
+// multi-selection, so this switches off multi-selection mode.
+func (d *DropDown) SetComboBox(comboBox bool) *DropDown {
+	d.comboBox = comboBox
+	if comboBox && d.multiSelect {
+		d.SetMultiSelect(false)
+	}
+	return d
+}
+
+// SetAllowFreeText sets whether or not a combo box accepts entered text which
+// doesn't match any option when the user presses Enter. The "selected"
+// callback then receives the entered text and an index of -1.
+func (d *DropDown) SetAllowFreeText(allow bool) *DropDown {
+	d.allowFreeText = allow
+	return d
+}
+
+// SetMatchFunc sets the function which determines whether an option of a
+// combo box matches the entered text. It receives the option's text (stripped
+// of style tags) and the entered text, which is never empty. It returns
+// whether or not the option matches and the indices of the matched runes in
+// the option's text, in ascending order, which will be highlighted. The
+// default is [DropDownMatchSubstring]. See also [DropDownMatchFuzzy].
+func (d *DropDown) SetMatchFunc(match func(option, text string) (matched bool, positions []int)) *DropDown {
+	d.matchFunc = match
+	return d
+}
+
+// SetMatchStyle sets the style used to highlight the matched characters of the
+// options of a combo box. The background color is not applied. Matches are not
+// highlighted if style tags are switched off (see [DropDown.SetUseStyleTags]).
+func (d *DropDown) SetMatchStyle(style tcell.Style) *DropDown {
+	d.matchStyle = style.Background(tcell.ColorDefault)
+	return d
+}
+
+// SetOptionsProvider sets a function which provides the options of a combo
+// box. It is called with the entered text when the drop-down list opens and
+// whenever the text changes. It delivers the options by calling setOptions,
+// either right away or later, e.g. after a remote lookup. The options
+// delivered for outdated texts are discarded. All options delivered by the
+// provider are shown, but matched characters are still highlighted.
+//
+// The provided options replace the drop-down's current options. If the
+// currently selected option is not among them, the current option index
+// becomes -1 but the option's text is retained (see
+// [DropDown.GetCurrentOption]).
+//
+// The setOptions function must be called from the main goroutine. When called
+// from a different goroutine, use [Application.QueueUpdateDraw].
+func (d *DropDown) SetOptionsProvider(provider func(text string, setOptions func(options []string))) *DropDown {
+	d.optionsProvider = provider
+	return d
+}
+
+// updateOptions updates the options shown in the open drop-down list of a
+// combo box after the entered text changed.
+func (d *DropDown) updateOptions() {
+	if d.optionsProvider == nil {
+		d.filterOptions()
+		return
+	}
+	d.lookups++
+	lookup := d.lookups
+	d.optionsProvider(d.prefix.GetText(), func(options []string) {
+		if lookup != d.lookups {
+			return // The text has changed in the meantime.
+		}
+		_, currentText := d.GetCurrentOption()
+		d.options = nil
+		d.currentOption = -1
+		for index, text := range options {
+			d.options = append(d.options, &dropDownOption{Text: text})
+			if currentText != "" && text == currentText && d.currentOption < 0 {
+				d.currentOption = index
+			}
+		}
+		d.freeText = ""
+		if d.currentOption < 0 {
+			d.freeText = currentText
+		}
+		d.filterOptions()
+	})
+}
+
+// filterOptions fills the drop-down list of a combo box with the options which
+// match the entered text, highlighting the matched characters.
+func (d *DropDown) filterOptions() {
+	text := d.prefix.GetText()
+	useStyleTags, _ := d.list.GetUseStyleTags()
+	current := d.optionIndex(d.list.GetCurrentItem())
+	d.list.Clear()
+	d.filtered = []int{}
+	for index, option := range d.options {
+		itemText := option.Text
+		if text != "" {
+			optionText := itemText
+			if useStyleTags {
+				optionText = stripTags(optionText)
+			}
+			matched, positions := d.matchFunc(optionText, text)
+			if !matched && d.optionsProvider == nil {
+				continue
+			}
+			if matched && useStyleTags && len(positions) > 0 {
+				itemText = d.highlight(optionText, positions)
+			}
+		}
+		d.filtered = append(d.filtered, index)
+		d.list.AddItem(d.optionPrefix+itemText+d.optionSuffix, "", 0, nil)
+		if index == current {
+			d.list.SetCurrentItem(len(d.filtered) - 1)
+		}
+	}
+	if len(d.filtered) == len(d.options) {
+		d.filtered = nil
+	}
+}
+
+// highlight returns the given text, escaped, with the runes at the given
+// positions highlighted using style tags.
+func (d *DropDown) highlight(text string, positions []int) string {
+	var (
+		result, segment strings.Builder
+		inMatch         bool
+	)
+	for index, r := range []rune(text) {
+		matched := len(positions) > 0 && positions[0] == index
+		if matched {
+			positions = positions[1:]
+		}
+		if matched != inMatch {
+			result.WriteString(Escape(segment.String()))
+			segment.Reset()
+			if matched {
+				result.WriteString(styleTag(d.matchStyle))
+			} else {
+				result.WriteString("[-:-:-]")
+			}
+			inMatch = matched
+		}
+		segment.WriteRune(r)
+	}
+	result.WriteString(Escape(segment.String()))
+	if inMatch {
+		result.WriteString("[-:-:-]")
+	}
+	return result.String()
+}
+
+// optionIndex returns the index of the option shown at the given index of the
+// drop-down list.
+func (d *DropDown) optionIndex(item int) int {
+	if d.filtered == nil {
+		return item
+	}
+	if item < 0 || item >= len(d.filtered) {
+		return -1
+	}
+	return d.filtered[item]
+}
+
+// acceptFreeText accepts the text entered into a combo box which matches no
+// option and closes the drop-down list.
+func (d *DropDown) acceptFreeText(setFocus func(Primitive)) {
+	text := d.prefix.GetText()
+	if !d.allowFreeText || text == "" {
+		return
+	}
+	d.currentOption = -1
+	d.freeText = text
+	d.closeList(setFocus)
+	if d.selected != nil {
+		d.selected(text, -1)
+	}
+}
+
+// SetMultiSelect sets whether or not multiple options can be selected. See the
+// [DropDown] documentation for details. When multi-selection is switched off,
+// all options are deselected.
+func (d *DropDown) SetMultiSelect(multiSelect bool) *DropDown {
+	d.multiSelect = multiSelect
+	if multiSelect {
+		d.comboBox = false
+	}
+	d.list.SetMultiSelect(multiSelect).ShowCheckboxes(multiSelect)
+	return d
+}
+
+// SetCheckboxStrings sets the strings used to display the checkboxes of
+// selected and unselected options in multi-selection mode (defaults to "☑"
+// and "☐").
+func (d *DropDown) SetCheckboxStrings(checked, unchecked string) *DropDown {
+	d.list.SetCheckboxStrings(checked, unchecked)
+	return d
+}
+
+// SetSelectionSummaryFormat sets the format string used to display the number
+// of selected options in multi-selection mode when the texts of the selected
+// options don't fit into the field. It must contain one "%d" verb. The default
+// is "%d selected".
+func (d *DropDown) SetSelectionSummaryFormat(format string) *DropDown {
+	d.summaryFormat = format
+	return d
+}
+
+// SetOptionSelected sets whether or not the option with the given index is
+// selected in multi-selection mode. This triggers a "selection changed" event
+// if the selection changes. Panics if the index is out of range.
+func (d *DropDown) SetOptionSelected(index int, selected bool) *DropDown {
+	d.list.SetItemSelected(index, selected)
+	return d
+}
+
+// IsOptionSelected returns whether or not the option with the given index is
+// selected in multi-selection mode. Panics if the index is out of range.
+func (d *DropDown) IsOptionSelected(index int) bool {
+	return d.list.IsItemSelected(index)
+}
+
+// SelectAll selects all options in multi-selection mode. This triggers a
+// "selection changed" event if the selection changes.
+func (d *DropDown) SelectAll() *DropDown {
+	d.list.SelectAll()
+	return d
+}
+
+// SelectNone deselects all options in multi-selection mode. This triggers a
+// "selection changed" event if the selection changes.
+func (d *DropDown) SelectNone() *DropDown {
+	d.list.SelectNone()
+	return d
+}
+
+// GetSelectedOptions returns the indices of the options selected in
+// multi-selection mode, in ascending order.
+func (d *DropDown) GetSelectedOptions() []int {
+	return d.list.GetSelectedItems()
+}
+
+// SetSelectionChangedFunc sets a handler which is called when the selection
+// changes in multi-selection mode (see [DropDown.SetMultiSelect]). It receives
+// the indices of the selected options, in ascending order.
+func (d *DropDown) SetSelectionChangedFunc(handler func(indices []int)) *DropDown {
+	d.selectionChanged = handler
+	return d
+}
+
+// selectionSummary returns the text to be displayed in the closed drop-down in
+// multi-selection mode, given the available screen width.
+func (d *DropDown) selectionSummary(width int, useStyleTags bool) string {
+	indices := d.list.GetSelectedItems()
+	if len(indices) == 0 {
+		return d.noSelection
+	}
+	texts := make([]string, len(indices))
+	for i, index := range indices {
+		texts[i] = d.options[index].Text
+		if !useStyleTags {
+			texts[i] = Escape(texts[i])
+		}
+	}
+	summary := strings.Join(texts, ", ")
+	if TaggedStringWidth(d.currentOptionPrefix+summary+d.currentOptionSuffix) > width {
+		summary = fmt.Sprintf(d.summaryFormat, len(indices))
+	}
+	return summary
+}
+
 // SetTextOptions sets the text to be placed before and after each drop-down
 // option (prefix/suffix), the text placed before and after the currently
 // selected option (currentPrefix/currentSuffix) as well as the text to be
@@ -293,9 +678,18 @@ func (d *DropDown) GetFieldWidth() int {
 			fieldWidth = width
 		}
 	}
+	if d.multiSelect {
+		fieldWidth = max(fieldWidth, d.summaryWidth())
+	}
 	return fieldWidth
 }
 
+// summaryWidth returns the screen width of the multi-selection summary when
+// all options are selected and the selected option texts don't fit.
+func (d *DropDown) summaryWidth() int {
+	return TaggedStringWidth(d.currentOptionPrefix + fmt.Sprintf(d.summaryFormat, len(d.options)) + d.currentOptionSuffix)
+}
+
 // GetFieldHeight returns this primitive's field height.
 func (d *DropDown) GetFieldHeight() int {
 	return 1
@@ -327,6 +721,7 @@ func (d *DropDown) AddOption(text string, selected func()) *DropDown {
 func (d *DropDown) SetOptions(texts []string, selected func(text string, index int)) *DropDown {
 	d.list.Clear()
 	d.options = nil
+	d.filtered = nil
 	for _, text := range texts {
 		d.AddOption(text, nil)
 	}
@@ -434,7 +829,10 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 			if noSelectionWidth > fieldWidth {
 				fieldWidth = noSelectionWidth
 			}
-		} else if d.currentOption < len(d.options) {
+		}
+		if d.multiSelect {
+			fieldWidth = max(fieldWidth, d.summaryWidth())
+		} else if d.currentOption >= 0 && d.currentOption < len(d.options) {
 			currentOptionWidth := TaggedStringWidth(d.currentOptionPrefix + d.options[d.currentOption].Text + d.currentOptionSuffix)
 			if currentOptionWidth > fieldWidth {
 				fieldWidth = currentOptionWidth
@@ -456,7 +854,15 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 
 	// Draw selected text.
 	prefix := Escape(d.prefix.GetText())
-	if d.HasFocus() && d.open && len(prefix) > 0 {
+	if d.comboBox && d.HasFocus() && d.open {
+		// The combo box is open. Draw the entered text and the cursor.
+		textWidth := TaggedStringWidth(prefix)
+		skip := max(textWidth-fieldWidth+1, 0)
+		printWithStyle(screen, prefix, x, y, skip, fieldWidth, AlignLeft, d.fieldStyle, false)
+		if fieldWidth > 0 {
+			screen.ShowCursor(x+textWidth-skip, y)
+		}
+	} else if d.HasFocus() && d.open && len(prefix) > 0 {
 		// The drop-down is open and we have an input prefix.
 		//  Draw current option prefix first.
 		currentOptionPrefix := d.currentOptionPrefix
@@ -487,13 +893,22 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 			}
 		}
 	} else {
-		// The drop-down is closed. Just draw the selected option.
+		// The drop-down is closed. Just draw the selected option(s).
 		text := d.noSelection
-		if d.currentOption >= 0 && d.currentOption < len(d.options) {
+		if d.multiSelect {
+			if summary := d.selectionSummary(fieldWidth, useStyleTags); summary != d.noSelection {
+				text = d.currentOptionPrefix + summary + d.currentOptionSuffix
+				if !useStyleTags {
+					text = Escape(d.currentOptionPrefix) + summary + Escape(d.currentOptionSuffix)
+				}
+			}
+		} else if d.currentOption >= 0 && d.currentOption < len(d.options) {
 			text = d.currentOptionPrefix + d.options[d.currentOption].Text + d.currentOptionSuffix
-		}
-		if !useStyleTags {
-			text = Escape(text)
+			if !useStyleTags {
+				text = Escape(text)
+			}
+		} else if d.freeText != "" {
+			text = d.currentOptionPrefix + Escape(d.freeText) + d.currentOptionSuffix
 		}
 		printWithStyle(screen, text, x, y, 0, fieldWidth, AlignLeft, fieldStyle, false)
 	}
@@ -502,8 +917,8 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 	if d.HasFocus() && d.open {
 		lx := x
 		ly := y + 1
-		lwidth := maxWidth
-		lheight := len(d.options)
+		lwidth := maxWidth + d.list.checkboxWidth()
+		lheight := d.list.GetItemCount()
 		swidth, sheight := screen.Size()
 		// We prefer to align the left sides of the list and the main widget, but
 		// if there is no space to the right, then shift the list to the left.
@@ -543,12 +958,16 @@ func (d *DropDown) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 			if handler := d.list.InputHandler(); handler != nil {
 				handler(event, setFocus)
 			}
-			d.prefix.SetText("")
+			if !d.comboBox {
+				d.prefix.SetText("")
+			}
 		case tcell.KeyEnter:
 			// If the list is closed, open it. Otherwise, forward the event to
 			// it.
 			if !d.open {
 				d.openList(setFocus)
+			} else if d.comboBox && d.list.GetItemCount() == 0 {
+				d.acceptFreeText(setFocus)
 			} else if handler := d.list.InputHandler(); handler != nil {
 				handler(event, setFocus)
 			}
@@ -559,8 +978,13 @@ func (d *DropDown) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 			}
 			d.closeList(setFocus)
 		default:
-			// Pass other key events to the input field.
-			if handler := d.prefix.InputHandler(); handler != nil {
+			// Pass other key events to the input field (via the list's input
+			// capture if the list is open).
+			handler := d.prefix.InputHandler()
+			if d.open {
+				handler = d.list.InputHandler()
+			}
+			if handler != nil {
 				handler(event, setFocus)
 			}
 			d.evalPrefix()
@@ -570,7 +994,14 @@ func (d *DropDown) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 }
 
 // evalPrefix selects an item in the drop-down list based on the current prefix.
+// For combo boxes, it updates the options shown in the open drop-down list.
 func (d *DropDown) evalPrefix() {
+	if d.comboBox {
+		if d.open {
+			d.updateOptions()
+		}
+		return
+	}
 	prefix := strings.ToLower(d.prefix.GetText())
 	if len(prefix) == 0 {
 		return
@@ -595,14 +1026,26 @@ func (d *DropDown) openList(setFocus func(Primitive)) {
 	}
 
 	d.open = true
+	if d.comboBox {
+		d.updateOptions()
+	}
 
 	d.list.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
 		if d.dragging {
 			return // If we're dragging the mouse, we don't want to trigger any events.
 		}
+		index = d.optionIndex(index)
+
+		// In multi-selection mode, toggle the option and keep the list open.
+		if d.multiSelect {
+			d.list.SetItemSelected(index, !d.list.IsItemSelected(index))
+			d.prefix.SetText("")
+			return
+		}
 
 		// An option was selected. Close the list again.
 		d.currentOption = index
+		d.freeText = ""
 		d.closeList(setFocus)
 
 		// Clear the prefix input field.
@@ -624,6 +1067,10 @@ func (d *DropDown) openList(setFocus func(Primitive)) {
 			d.closeList(setFocus)
 			return nil
 		default: // All other keys are passed to the input field.
+			if d.multiSelect && (key == tcell.KeyCtrlA || key == tcell.KeyRune && event.Rune() == ' ' && d.prefix.GetText() == "") {
+				d.prefix.SetText("")
+				return event // Toggle the current option or select all/none.
+			}
 			if handler := d.prefix.InputHandler(); handler != nil {
 				handler(event, setFocus)
 			}
@@ -646,6 +1093,15 @@ func (d *DropDown) closeList(setFocus func(Primitive)) {
 	if d.list.HasFocus() {
 		setFocus(d)
 	}
+
+	// Show all options again.
+	if d.comboBox {
+		d.prefix.SetText("")
+		d.filterOptions()
+		if d.currentOption >= 0 {
+			d.list.SetCurrentItem(d.currentOption)
+		}
+	}
 }
 
 // IsOpen returns true if the drop-down list is currently open.
@@ -700,7 +1156,7 @@ func (d *DropDown) MouseHandler() func(action MouseAction, event *tcell.EventMou
 			capture = d
 			if !d.open {
 				d.openList(setFocus)
-				d.dragging = true
+				d.dragging = !d.multiSelect
 			} else if consumed, _ := d.list.MouseHandler()(MouseLeftClick, event, setFocus); !consumed {
 				d.closeList(setFocus) // Close drop-down if clicked outside of it.
 			}
@@ -735,5 +1191,8 @@ func (d *DropDown) PasteHandler() func(pastedText string, setFocus func(p Primit
 
 		// Forward the pasted text to the input field.
 		d.prefix.PasteHandler()(pastedText, setFocus)
+		if d.comboBox {
+			d.evalPrefix()
+		}
 	})
 }
@@ -0,0 +1,888 @@
+package tview
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// MkdirFS is a file system which supports the creation of directories. If the
+// file system of a [FilePicker] implements this interface, the user can create
+// new folders.
+type MkdirFS interface {
+	fs.FS
+
+	// Mkdir creates a new directory with the given name (a path as accepted by
+	// [fs.ValidPath]) and permission bits.
+	Mkdir(name string, perm fs.FileMode) error
+}
+
+// osFS is a [MkdirFS] for a directory tree of the operating system.
+type osFS struct {
+	fs.FS
+	root string
+}
+
+// Mkdir creates a new directory.
+func (o osFS) Mkdir(name string, perm fs.FileMode) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrInvalid}
+	}
+	return os.Mkdir(filepath.Join(o.root, filepath.FromSlash(name)), perm)
+}
+
+// DirFS returns a file system for the operating system's directory tree rooted
+// at the given directory, similar to [os.DirFS]. In addition, it allows the
+// creation of directories.
+func DirFS(root string) MkdirFS {
+	return osFS{FS: os.DirFS(root), root: root}
+}
+
+// fileEntry describes a file or directory shown in a [FilePicker].
+type fileEntry struct {
+	name    string    // The base name. ".." for the parent directory.
+	dir     bool      // Whether or not this is a directory.
+	size    int64     // The file size in bytes.
+	modTime time.Time // The time of the last modification.
+}
+
+// FilePicker lets the user select files or directories from a file system.
+// The file system is accessed through the [fs.FS] interface. Use [DirFS] to
+// access the operating system's files or any other implementation such as
+// [testing/fstest.MapFS]. Paths are always slash-separated and relative to
+// the root of the file system, as described in [fs.ValidPath].
+//
+// The file picker consists of a path input field at the top, a tree of
+// directories on the left, a list of the current directory's contents on the
+// right, and a status line at the bottom. The list shows the size and the time
+// of the last modification of each file.
+//
+// The following keys are available in addition to the keys of the contained
+// primitives:
+//
+//   - Tab, Backtab: Move between the path input field, the tree, and the list.
+//     In the path input field, Tab first completes the entered path or lists
+//     the possible completions.
+//   - Enter: In the path input field, navigate to the entered directory or
+//     select the entered file. In the list, enter the selected directory or
+//     select the selected file.
+//   - Backspace: In the list, navigate to the parent directory.
+//   - Space: In the list, mark or unmark the current entry if multiple
+//     selections are allowed (see [FilePicker.SetMultiSelect]).
+//   - Alt-.: Show or hide hidden files.
+//   - F7: Create a new folder (if supported by the file system).
+//   - Escape: Cancel.
+//
+// In directory mode (see [FilePicker.SetDirectoryMode]), the user selects a
+// directory by pressing Enter in the path input field while it contains the
+// current directory.
+type FilePicker struct {
+	*Box
+
+	// The file system.
+	fsys fs.FS
+
+	// The current directory.
+	dir string
+
+	// The entries of the current directory, as shown in the list.
+	entries []fileEntry
+
+	// The primitives making up the file picker.
+	layout *Flex
+	input  *InputField
+	tree   *TreeView
+	list   *List
+	status *TextView
+
+	// The tree nodes whose children have been read.
+	loaded map[*TreeNode]bool
+
+	// The list's inner width at the time the list items were formatted.
+	listWidth int
+
+	// Glob patterns (see [path.Match]) a file name must match to be shown.
+	// Empty if all files are shown.
+	filters []string
+
+	// Whether or not files starting with a dot are shown.
+	showHidden bool
+
+	// Whether or not the user can mark multiple entries.
+	multiSelect bool
+
+	// The paths of the marked entries.
+	marked map[string]bool
+
+	// Whether or not the user may enter the name of a file which does not
+	// exist yet.
+	saveMode bool
+
+	// Whether or not directories are selected instead of files.
+	dirMode bool
+
+	// Whether or not the path input field is used to enter the name of a new
+	// folder.
+	creating bool
+
+	// An error message shown in the status line. Empty if there is none.
+	message string
+
+	// An optional function which is called when the user selects files.
+	selected func(paths []string)
+
+	// An optional function which is called when the user cancels.
+	cancel func()
+}
+
+// NewFilePicker returns a new file picker for the given file system, showing
+// the root directory.
+func NewFilePicker(fsys fs.FS) *FilePicker {
+	f := &FilePicker{
+		Box:    NewBox(),
+		fsys:   fsys,
+		dir:    ".",
+		input:  NewInputField().SetLabel("Path: "),
+		tree:   NewTreeView(),
+		list:   NewList().ShowSecondaryText(false).SetUseStyleTags(false, false).SetHighlightFullLine(true),
+		status: NewTextView().SetTextColor(Styles.SecondaryTextColor),
+		loaded: make(map[*TreeNode]bool),
+		marked: make(map[string]bool),
+	}
+	f.tree.SetBorder(true).SetTitle("Folders")
+	f.list.SetBorder(true)
+	f.layout = NewFlex().SetDirection(FlexRow).
+		AddItem(f.input, 1, 0, false).
+		AddItem(NewFlex().
+			AddItem(f.tree, 0, 1, false).
+			AddItem(f.list, 0, 2, true), 0, 1, true).
+		AddItem(f.status, 1, 0, false)
+
+	f.tree.SetChangedFunc(func(node *TreeNode) {
+		if dir, ok := node.GetReference().(string); ok && dir != f.dir {
+			f.navigate(dir, "")
+		}
+	})
+	f.tree.SetSelectedFunc(func(node *TreeNode) {
+		f.loadChildren(node)
+		node.SetExpanded(!node.IsExpanded())
+	})
+	f.list.SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		f.showEntry(index)
+	})
+	f.list.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		f.activate(index)
+	})
+
+	f.resetTree()
+	f.navigate(".", "")
+	f.Box.Primitive = f
+	return f
+}
+
+// SetDirectory sets the directory whose contents are shown.
+func (f *FilePicker) SetDirectory(dir string) *FilePicker {
+	f.navigate(path.Clean(dir), "")
+	return f
+}
+
+// GetDirectory returns the directory whose contents are shown.
+func (f *FilePicker) GetDirectory() string {
+	return f.dir
+}
+
+// SetFilters sets glob patterns (see [path.Match]) of which a file name must
+// match at least one to be shown, e.g. "*.go". Directories are always shown.
+// If no patterns are provided, all files are shown.
+func (f *FilePicker) SetFilters(patterns ...string) *FilePicker {
+	f.filters = patterns
+	f.navigate(f.dir, "")
+	return f
+}
+
+// SetShowHidden sets whether or not hidden files and directories, i.e. those
+// whose names start with a dot, are shown. They are not shown by default.
+func (f *FilePicker) SetShowHidden(show bool) *FilePicker {
+	f.showHidden = show
+	f.resetTree()
+	f.navigate(f.dir, "")
+	return f
+}
+
+// GetShowHidden returns whether or not hidden files and directories are shown.
+func (f *FilePicker) GetShowHidden() bool {
+	return f.showHidden
+}
+
+// SetMultiSelect sets whether or not the user may mark multiple entries with
+// the space bar. When the user confirms the selection, all marked entries are
+// selected.
+func (f *FilePicker) SetMultiSelect(multiSelect bool) *FilePicker {
+	f.multiSelect = multiSelect
+	if !multiSelect {
+		clear(f.marked)
+	}
+	f.listWidth = -1
+	return f
+}
+
+// SetSaveMode sets whether or not the user may enter the name of a file which
+// does not exist yet, e.g. for "save as" dialogs. Its directory must exist,
+// however.
+func (f *FilePicker) SetSaveMode(saveMode bool) *FilePicker {
+	f.saveMode = saveMode
+	return f
+}
+
+// SetDirectoryMode sets whether or not the user selects directories instead of
+// files.
+func (f *FilePicker) SetDirectoryMode(dirMode bool) *FilePicker {
+	f.dirMode = dirMode
+	return f
+}
+
+// SetSelectedFunc sets a handler which is called when the user selects one or
+// more files (or directories in directory mode). It receives their paths.
+func (f *FilePicker) SetSelectedFunc(handler func(paths []string)) *FilePicker {
+	f.selected = handler
+	return f
+}
+
+// SetCancelFunc sets a handler which is called when the user hits the Escape
+// key.
+func (f *FilePicker) SetCancelFunc(handler func()) *FilePicker {
+	f.cancel = handler
+	return f
+}
+
+// GetMarked returns the paths of all marked entries, sorted alphabetically.
+func (f *FilePicker) GetMarked() []string {
+	paths := make([]string, 0, len(f.marked))
+	for p := range f.marked {
+		paths = append(paths, p)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// Refresh reads the current directory again.
+func (f *FilePicker) Refresh() *FilePicker {
+	if node := f.tree.GetCurrentNode(); node != nil {
+		delete(f.loaded, node)
+		f.loadChildren(node)
+	}
+	f.navigate(f.dir, "")
+	return f
+}
+
+// hidden returns true if the given file name should not be shown.
+func (f *FilePicker) hidden(name string) bool {
+	return !f.showHidden && strings.HasPrefix(name, ".")
+}
+
+// matches returns true if the given file name matches the filters.
+func (f *FilePicker) matches(name string) bool {
+	if len(f.filters) == 0 {
+		return true
+	}
+	for _, pattern := range f.filters {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resetTree replaces the tree of directories with a new tree which only
+// contains the root directory.
+func (f *FilePicker) resetTree() {
+	root := NewTreeNode("/").SetReference(".")
+	clear(f.loaded)
+	f.loadChildren(root)
+	f.tree.SetRoot(root).SetCurrentNode(root)
+}
+
+// loadChildren reads the subdirectories of the given tree node's directory if
+// they have not been read yet.
+func (f *FilePicker) loadChildren(node *TreeNode) {
+	if f.loaded[node] {
+		return
+	}
+	f.loaded[node] = true
+	dir, _ := node.GetReference().(string)
+	entries, err := fs.ReadDir(f.fsys, dir)
+	if err != nil {
+		return
+	}
+	node.ClearChildren()
+	for _, entry := range entries {
+		if !entry.IsDir() || f.hidden(entry.Name()) {
+			continue
+		}
+		node.AddChild(NewTreeNode(entry.Name()).
+			SetReference(path.Join(dir, entry.Name())).
+			SetExpanded(false))
+	}
+}
+
+// reveal expands the tree down to the node of the given directory and makes
+// it the tree's current node.
+func (f *FilePicker) reveal(dir string) {
+	node := f.tree.GetRoot()
+	f.loadChildren(node)
+	if dir != "." {
+		for _, name := range strings.Split(dir, "/") {
+			var child *TreeNode
+			for _, c := range node.GetChildren() {
+				if c.GetText() == name {
+					child = c
+					break
+				}
+			}
+			if child == nil {
+				break // Probably a hidden directory.
+			}
+			node.SetExpanded(true)
+			node = child
+			f.loadChildren(node)
+		}
+	}
+	f.tree.SetCurrentNode(node)
+}
+
+// navigate shows the contents of the given directory. If "name" is not empty,
+// the entry with that name becomes the list's current item.
+func (f *FilePicker) navigate(dir, name string) {
+	dirEntries, err := fs.ReadDir(f.fsys, dir)
+	if err != nil {
+		f.message = err.Error()
+		return
+	}
+	f.message = ""
+	f.dir = dir
+	f.entries = f.entries[:0]
+	if dir != "." {
+		f.entries = append(f.entries, fileEntry{name: "..", dir: true})
+	}
+	var files []fileEntry
+	for _, entry := range dirEntries {
+		if f.hidden(entry.Name()) {
+			continue
+		}
+		e := fileEntry{name: entry.Name(), dir: entry.IsDir()}
+		if info, err := entry.Info(); err == nil {
+			e.size = info.Size()
+			e.modTime = info.ModTime()
+		}
+		if e.dir {
+			f.entries = append(f.entries, e)
+		} else if f.matches(e.name) {
+			files = append(files, e)
+		}
+	}
+	f.entries = append(f.entries, files...)
+
+	// Update the primitives.
+	f.listWidth = -1
+	f.list.Clear()
+	current := 0
+	for index, entry := range f.entries {
+		f.list.AddItem(entry.name, "", 0, nil)
+		if entry.name == name {
+			current = index
+		}
+	}
+	f.list.SetCurrentItem(current)
+	f.list.SetTitle(" " + f.displayPath(dir) + " ")
+	f.reveal(dir)
+	if !f.creating {
+		f.input.SetText(f.inputPath(dir, true))
+	}
+}
+
+// displayPath returns the given path as shown to the user.
+func (f *FilePicker) displayPath(p string) string {
+	if p == "." {
+		return "/"
+	}
+	return "/" + p
+}
+
+// inputPath returns the given path as shown in the path input field. If the
+// path is a directory, it ends with a slash.
+func (f *FilePicker) inputPath(p string, dir bool) string {
+	if p == "." {
+		return ""
+	}
+	if dir {
+		return p + "/"
+	}
+	return p
+}
+
+// entryPath returns the path of the entry with the given index.
+func (f *FilePicker) entryPath(index int) string {
+	if f.entries[index].name == ".." {
+		return path.Dir(f.dir)
+	}
+	return path.Join(f.dir, f.entries[index].name)
+}
+
+// showEntry shows the path of the entry with the given index in the path
+// input field.
+func (f *FilePicker) showEntry(index int) {
+	if f.creating || index < 0 || index >= len(f.entries) {
+		return
+	}
+	entry := f.entries[index]
+	if entry.name == ".." {
+		f.input.SetText(f.inputPath(f.dir, true))
+		return
+	}
+	f.input.SetText(f.inputPath(f.entryPath(index), entry.dir))
+}
+
+// activate enters the directory of the entry with the given index or selects
+// it if it is a file.
+func (f *FilePicker) activate(index int) {
+	if index < 0 || index >= len(f.entries) {
+		return
+	}
+	entry := f.entries[index]
+	if entry.dir {
+		if entry.name == ".." {
+			f.navigate(path.Dir(f.dir), path.Base(f.dir))
+		} else {
+			f.navigate(f.entryPath(index), "")
+		}
+		return
+	}
+	if f.dirMode {
+		return
+	}
+	f.submit(f.entryPath(index))
+}
+
+// submit calls the "selected" handler with the marked entries or, if there
+// are none, with the given path.
+func (f *FilePicker) submit(p string) {
+	paths := []string{p}
+	if f.multiSelect && len(f.marked) > 0 {
+		paths = f.GetMarked()
+	}
+	if f.selected != nil {
+		f.selected(paths)
+	}
+}
+
+// toggleMark marks or unmarks the current list entry.
+func (f *FilePicker) toggleMark() {
+	index := f.list.GetCurrentItem()
+	if index < 0 || index >= len(f.entries) || f.entries[index].name == ".." || f.entries[index].dir != f.dirMode {
+		return
+	}
+	p := f.entryPath(index)
+	if f.marked[p] {
+		delete(f.marked, p)
+	} else {
+		f.marked[p] = true
+	}
+	f.listWidth = -1
+	f.list.SetCurrentItem(index + 1)
+}
+
+// inputToPath converts the text of the path input field into a path.
+func (f *FilePicker) inputToPath() (string, error) {
+	text := strings.TrimPrefix(strings.TrimSpace(f.input.GetText()), "/")
+	p := path.Clean(strings.TrimSuffix(text, "/"))
+	if text == "" {
+		p = "."
+	}
+	if !fs.ValidPath(p) {
+		return "", fmt.Errorf("invalid path %q", text)
+	}
+	return p, nil
+}
+
+// enter processes the text of the path input field after the user pressed
+// Enter.
+func (f *FilePicker) enter() {
+	// Create a new folder.
+	if f.creating {
+		name := strings.TrimSpace(f.input.GetText())
+		if name == "" {
+			f.stopCreating()
+			return
+		}
+		p := path.Join(f.dir, name)
+		mkdirFS, ok := f.fsys.(MkdirFS)
+		if !ok {
+			f.message = "the file system does not support creating folders"
+			return
+		}
+		if err := mkdirFS.Mkdir(p, 0o755); err != nil {
+			f.message = err.Error()
+			return
+		}
+		f.stopCreating()
+		if node := f.tree.GetCurrentNode(); node != nil {
+			delete(f.loaded, node)
+		}
+		f.navigate(f.dir, path.Base(p))
+		return
+	}
+
+	// Navigate or select.
+	p, err := f.inputToPath()
+	if err != nil {
+		f.message = err.Error()
+		return
+	}
+	info, err := fs.Stat(f.fsys, p)
+	switch {
+	case err == nil && info.IsDir():
+		if f.dirMode && p == f.dir {
+			f.submit(p)
+			return
+		}
+		f.navigate(p, "")
+	case err == nil:
+		if f.dirMode {
+			f.message = fmt.Sprintf("%s is not a directory", f.displayPath(p))
+			return
+		}
+		f.submit(p)
+	case errors.Is(err, fs.ErrNotExist) && f.saveMode && !f.dirMode:
+		if info, err := fs.Stat(f.fsys, path.Dir(p)); err != nil || !info.IsDir() {
+			f.message = fmt.Sprintf("folder %s does not exist", f.displayPath(path.Dir(p)))
+			return
+		}
+		f.submit(p)
+	default:
+		f.message = err.Error()
+	}
+}
+
+// complete completes the text of the path input field. If there are multiple
+// candidates, they are listed in the status line. It returns false if there
+// was nothing to complete.
+func (f *FilePicker) complete() bool {
+	text := f.input.GetText()
+	dir, prefix := ".", text
+	if index := strings.LastIndex(text, "/"); index >= 0 {
+		dir, prefix = strings.TrimPrefix(text[:index], "/"), text[index+1:]
+		if dir == "" {
+			dir = "."
+		}
+	}
+	if !fs.ValidPath(dir) {
+		return false
+	}
+	entries, err := fs.ReadDir(f.fsys, dir)
+	if err != nil {
+		return false
+	}
+
+	// Find the longest common prefix of all matching entries.
+	var (
+		common     string
+		candidates []string
+		isDir      bool
+	)
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) || f.hidden(name) && !strings.HasPrefix(prefix, ".") {
+			continue
+		}
+		if !entry.IsDir() && (f.dirMode || !f.matches(name)) {
+			continue
+		}
+		if len(candidates) == 0 {
+			common = name
+		} else {
+			for !strings.HasPrefix(name, common) {
+				_, size := utf8.DecodeLastRuneInString(common)
+				common = common[:len(common)-size]
+			}
+		}
+		isDir = entry.IsDir()
+		candidates = append(candidates, name)
+	}
+	if len(candidates) == 0 {
+		return false
+	}
+	completed := text[:len(text)-len(prefix)] + common
+	if len(candidates) == 1 && isDir {
+		completed += "/"
+	}
+	if len(candidates) > 1 {
+		f.message = strings.Join(candidates, "  ")
+	} else if completed == text {
+		return false
+	}
+	f.input.SetText(completed)
+	return true
+}
+
+// startCreating switches the path input field to entering the name of a new
+// folder.
+func (f *FilePicker) startCreating(setFocus func(p Primitive)) {
+	if _, ok := f.fsys.(MkdirFS); !ok {
+		f.message = "the file system does not support creating folders"
+		return
+	}
+	f.creating = true
+	f.message = ""
+	f.input.SetLabel("New folder: ").SetText("")
+	setFocus(f.input)
+}
+
+// stopCreating switches the path input field back to entering paths.
+func (f *FilePicker) stopCreating() {
+	f.creating = false
+	f.input.SetLabel("Path: ").SetText(f.inputPath(f.dir, true))
+}
+
+// formatSize returns a human-readable representation of the given size.
+func formatSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	value, units := float64(size)/1024, "KMGTPE"
+	for value >= 1024 && len(units) > 1 {
+		value /= 1024
+		units = units[1:]
+	}
+	return fmt.Sprintf("%.1f %cB", value, units[0])
+}
+
+// formatEntries sets the texts of the list items according to the given list
+// width.
+func (f *FilePicker) formatEntries(width int) {
+	const sizeWidth, timeWidth = 9, 16
+	nameWidth := width
+	if f.multiSelect {
+		nameWidth -= 2
+	}
+	columns := nameWidth >= 20+sizeWidth+timeWidth+2
+	if columns {
+		nameWidth -= sizeWidth + timeWidth + 2
+	}
+	for index, entry := range f.entries {
+		var text strings.Builder
+		if f.multiSelect {
+			if f.marked[f.entryPath(index)] && entry.name != ".." {
+				text.WriteString("✓ ")
+			} else {
+				text.WriteString("  ")
+			}
+		}
+		name := entry.name
+		if entry.dir {
+			name += "/"
+		}
+		if !columns {
+			text.WriteString(name)
+			f.list.SetItemText(index, text.String(), "")
+			continue
+		}
+		if w := TaggedStringWidth(Escape(name)); w > nameWidth {
+			// Truncate long names.
+			for TaggedStringWidth(Escape(name)) > nameWidth-1 {
+				_, size := utf8.DecodeLastRuneInString(name)
+				name = name[:len(name)-size]
+			}
+			name += "…"
+		}
+		text.WriteString(name)
+		text.WriteString(strings.Repeat(" ", max(nameWidth-TaggedStringWidth(Escape(name)), 0)))
+		size := "<DIR>"
+		if !entry.dir {
+			size = formatSize(entry.size)
+		}
+		fmt.Fprintf(&text, " %*s ", sizeWidth, size)
+		if !entry.modTime.IsZero() {
+			text.WriteString(entry.modTime.Format("2006-01-02 15:04"))
+		}
+		f.list.SetItemText(index, text.String(), "")
+	}
+}
+
+// updateStatus sets the text of the status line.
+func (f *FilePicker) updateStatus() {
+	if f.message != "" {
+		f.status.SetTextColor(Styles.TertiaryTextColor).SetText(f.message)
+		return
+	}
+	var dirs, files int
+	for _, entry := range f.entries {
+		if entry.name == ".." {
+			continue
+		}
+		if entry.dir {
+			dirs++
+		} else {
+			files++
+		}
+	}
+	text := fmt.Sprintf("Folders: %d, files: %d", dirs, files)
+	if len(f.marked) > 0 {
+		text += fmt.Sprintf(", marked: %d", len(f.marked))
+	}
+	f.status.SetTextColor(Styles.SecondaryTextColor).SetText(text)
+}
+
+// Draw draws this primitive onto the screen.
+func (f *FilePicker) Draw(screen tcell.Screen) {
+	f.Box.DrawForSubclass(screen, f)
+	f.updateStatus()
+	f.layout.SetRect(f.GetInnerRect())
+	f.layout.Draw(screen)
+
+	// The list's width is only known after the layout was drawn. If it changed,
+	// format the list items again and redraw the list.
+	if _, _, width, _ := f.list.GetInnerRect(); width != f.listWidth {
+		f.listWidth = width
+		f.formatEntries(width)
+		f.list.Draw(screen)
+	}
+}
+
+// Focus is called when this primitive receives focus.
+func (f *FilePicker) Focus(delegate func(p Primitive)) {
+	delegate(f.list)
+}
+
+// focusChain implements the [Primitive]'s focusChain method.
+func (f *FilePicker) focusChain(chain *[]Primitive) bool {
+	if hasFocus := f.layout.focusChain(chain); hasFocus {
+		if chain != nil {
+			*chain = append(*chain, f)
+		}
+		return true
+	}
+	return f.Box.focusChain(chain)
+}
+
+// InputHandler returns the handler for this primitive.
+func (f *FilePicker) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return f.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		switch key := event.Key(); key {
+		case tcell.KeyEscape:
+			if f.creating {
+				f.stopCreating()
+				setFocus(f.list)
+			} else if f.cancel != nil {
+				f.cancel()
+			}
+			return
+		case tcell.KeyTab, tcell.KeyBacktab:
+			if key == tcell.KeyTab && f.input.HasFocus() && f.complete() {
+				return
+			}
+			if f.creating {
+				f.stopCreating()
+			}
+			order := []Primitive{f.input, f.tree, f.list}
+			for index, p := range order {
+				if p.HasFocus() {
+					if key == tcell.KeyTab {
+						index++
+					} else {
+						index += len(order) - 1
+					}
+					setFocus(order[index%len(order)])
+					return
+				}
+			}
+			return
+		case tcell.KeyF7:
+			f.startCreating(setFocus)
+			return
+		case tcell.KeyRune:
+			if event.Rune() == '.' && event.Modifiers()&tcell.ModAlt != 0 {
+				f.SetShowHidden(!f.showHidden)
+				return
+			}
+		}
+
+		// Keys specific to the path input field.
+		if f.input.HasFocus() {
+			if event.Key() == tcell.KeyEnter {
+				f.enter()
+				if !f.creating && f.message == "" {
+					setFocus(f.list)
+				}
+				return
+			}
+			f.message = ""
+		}
+
+		// Keys specific to the list.
+		if f.list.HasFocus() {
+			switch event.Key() {
+			case tcell.KeyBackspace, tcell.KeyBackspace2:
+				if f.dir != "." {
+					f.navigate(path.Dir(f.dir), path.Base(f.dir))
+				}
+				return
+			case tcell.KeyRune:
+				if event.Rune() == ' ' {
+					if f.multiSelect {
+						f.toggleMark()
+					}
+					return
+				}
+			}
+		}
+
+		if handler := f.layout.InputHandler(); handler != nil {
+			handler(event, setFocus)
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (f *FilePicker) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return f.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if !f.InRect(event.Position()) {
+			return false, nil
+		}
+
+		// In the list, a single click only selects an entry. A double click
+		// activates it.
+		if index := f.list.indexAtPoint(event.Position()); index >= 0 {
+			switch action {
+			case MouseLeftClick:
+				setFocus(f.list)
+				f.list.SetCurrentItem(index)
+				return true, nil
+			case MouseLeftDoubleClick:
+				setFocus(f.list)
+				f.list.SetCurrentItem(index)
+				f.activate(index)
+				return true, nil
+			}
+		}
+
+		return f.layout.MouseHandler()(action, event, setFocus)
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (f *FilePicker) PasteHandler() func(pastedText string, setFocus func(p Primitive)) {
+	return f.WrapPasteHandler(func(pastedText string, setFocus func(p Primitive)) {
+		if handler := f.layout.PasteHandler(); handler != nil {
+			handler(pastedText, setFocus)
+		}
+	})
+}
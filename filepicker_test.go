@@ -0,0 +1,64 @@
+package tview
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// TestFilePickerDeepDirectory tests that a deeply nested directory, whose
+// folder tree is wider than the folder pane, can be drawn.
+func TestFilePickerDeepDirectory(t *testing.T) {
+	const dir = "a/b/c/d/e/f/g/h/i/j/k/l"
+	fsys := fstest.MapFS{dir + "/file.txt": &fstest.MapFile{Data: []byte("text")}}
+	picker := NewFilePicker(fsys).SetDirectory(dir)
+	if picker.GetDirectory() != dir {
+		t.Fatalf("expected directory %q, got %q", dir, picker.GetDirectory())
+	}
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+	screen.SetSize(80, 24)
+	picker.SetRect(0, 0, 80, 24)
+
+	done := make(chan struct{})
+	go func() {
+		picker.Draw(screen)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("drawing the file picker did not finish")
+	}
+}
+
+// TestFilePickerCompleteUTF8 tests that path completion doesn't split runes
+// when shortening the common prefix of the candidates.
+func TestFilePickerCompleteUTF8(t *testing.T) {
+	fsys := fstest.MapFS{
+		"été.txt":  &fstest.MapFile{},
+		"ètre.txt": &fstest.MapFile{},
+	}
+	picker := NewFilePicker(fsys)
+	picker.input.SetText("")
+	if !picker.complete() {
+		t.Fatal("expected candidates to be listed")
+	}
+	if text := picker.input.GetText(); !utf8.ValidString(text) || text != "" {
+		t.Errorf("expected an empty valid path, got %q", text)
+	}
+
+	fsys["éclair.txt"] = &fstest.MapFile{}
+	picker.input.SetText("é")
+	picker.complete()
+	if text := picker.input.GetText(); text != "é" {
+		t.Errorf("expected %q, got %q", "é", text)
+	}
+}
@@ -2,6 +2,8 @@ package tview
 
 import (
 	"image"
+	"slices"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
@@ -70,6 +72,17 @@ type FormItem interface {
 // Checkbox. These elements can be optionally followed by one or more buttons
 // for which you can define form-wide actions (e.g. Save, Clear, Cancel).
 //
+// # Validation
+//
+// Form items may be checked by validators (see [Form.SetValidators] and
+// [FormValidator]). An item is validated when the user leaves it. If it is
+// invalid, the error message is shown below it (see [Form.SetErrorStyle]) and
+// the item is validated again with every change until it is valid. Before the
+// form is submitted with the Enter key (see [Form.SetSubmitFunc]) and before a
+// button is activated (unless switched off with [Form.SetButtonValidation]),
+// all items are validated. If any of them are invalid, the first invalid item
+// receives focus instead. Call [Form.Validate] to validate all items yourself.
+//
 // See https://github.com/rivo/tview/wiki/Form for an example.
 type Form struct {
 	*Box
@@ -105,6 +118,22 @@ type Form struct {
 	// The style of the buttons when they are disabled.
 	buttonDisabledStyle tcell.Style
 
+	// The style of validation error messages.
+	errorStyle tcell.Style
+
+	// The validators of the form items.
+	validators map[FormItem][]FormValidator
+
+	// The errors of the form items which were invalid when they were last
+	// validated.
+	validationErrors map[FormItem]error
+
+	// The buttons which are activated without validating the form first.
+	unvalidatedButtons map[*Button]bool
+
+	// The form items bound to struct fields (see [Form.AddStruct]).
+	structFields []formStructField
+
 	// The index of the item or button for which the user requested focus.
 	// Applied the next time the form itself receives focus. Negative if no
 	// specific item was requested.
@@ -129,6 +158,7 @@ func NewForm() *Form {
 		buttonStyle:          tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
 		buttonActivatedStyle: tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
 		buttonDisabledStyle:  tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.ContrastSecondaryTextColor),
+		errorStyle:           tcell.StyleDefault.Foreground(tcell.ColorRed),
 		requestedFocus:       -1,
 	}
 
@@ -220,6 +250,99 @@ func (f *Form) SetButtonDisabledStyle(style tcell.Style) *Form {
 	return f
 }
 
+// SetErrorStyle sets the style of the validation error messages shown below
+// invalid form items.
+func (f *Form) SetErrorStyle(style tcell.Style) *Form {
+	f.errorStyle = style
+	return f
+}
+
+// SetValidators sets the validators of the given form item, replacing any
+// previous ones. The validators are called in the given order until one of
+// them reports an error. Call this function without validators to remove all
+// validators of the item. Disabled items are not validated.
+func (f *Form) SetValidators(item FormItem, validators ...FormValidator) *Form {
+	if len(validators) == 0 {
+		delete(f.validators, item)
+		delete(f.validationErrors, item)
+		return f
+	}
+	if f.validators == nil {
+		f.validators = make(map[FormItem][]FormValidator)
+	}
+	f.validators[item] = validators
+	return f
+}
+
+// SetButtonValidation sets whether or not the form is validated before the
+// button with the given index is activated. If any form items are invalid, the
+// button is not activated. This is the default for all buttons. Switch it off
+// for buttons which don't submit the form, e.g. "Cancel".
+func (f *Form) SetButtonValidation(index int, validate bool) *Form {
+	button := f.buttons[index]
+	if validate {
+		delete(f.unvalidatedButtons, button)
+		return f
+	}
+	if f.unvalidatedButtons == nil {
+		f.unvalidatedButtons = make(map[*Button]bool)
+	}
+	f.unvalidatedButtons[button] = true
+	return f
+}
+
+// Validate validates all form items, showing error messages below the invalid
+// ones, and returns true if all of them are valid. Otherwise, the first invalid
+// item will receive focus the next time the form itself receives focus (see
+// [Form.SetFocus]).
+func (f *Form) Validate() bool {
+	if index := f.validate(); index >= 0 {
+		f.SetFocus(index)
+		return false
+	}
+	return true
+}
+
+// GetValidationError returns the error reported for the given form item when
+// it was last validated or nil if it was valid (or has not been validated).
+func (f *Form) GetValidationError(item FormItem) error {
+	return f.validationErrors[item]
+}
+
+// validateItem validates the given form item, records the result, and returns
+// the error reported by its validators, if any.
+func (f *Form) validateItem(item FormItem) error {
+	var err error
+	if !item.GetDisabled() {
+		for _, validator := range f.validators[item] {
+			if err = validator(item); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil {
+		delete(f.validationErrors, item)
+		return nil
+	}
+	if f.validationErrors == nil {
+		f.validationErrors = make(map[FormItem]error)
+	}
+	f.validationErrors[item] = err
+	return err
+}
+
+// validate validates all form items and returns the index of the first
+// invalid item or -1 if all items are valid.
+func (f *Form) validate() int {
+	invalid := -1
+	for index, item := range f.items {
+		if f.validateItem(item) != nil && invalid < 0 {
+			invalid = index
+		}
+	}
+	return invalid
+}
+
 // SetFocus shifts the focus to the form element with the given index, counting
 // non-button items first and buttons last. This does not change the
 // application's focus immediately, but the next time the form itself receives
@@ -341,6 +464,74 @@ func (f *Form) AddCheckbox(label string, checked bool, changed func(checked bool
 	return f
 }
 
+// AddRadioGroup adds a group of radio buttons to the form. It has a label,
+// options, and an (optional) callback function which is invoked when the user
+// selected an option. The initial option may be a negative value to indicate
+// that no option is currently selected.
+func (f *Form) AddRadioGroup(label string, options []string, initialOption int, changed func(option string, optionIndex int)) *Form {
+	radioGroup := NewRadioGroup().
+		SetLabel(label).
+		SetOptions(options, nil).
+		SetCurrentOption(initialOption).
+		SetChangedFunc(changed)
+	f.items = append(f.items, radioGroup)
+	return f
+}
+
+// AddSlider adds a slider to the form. It has a label, an initial value, the
+// range of values, the step by which the value changes, and an (optional)
+// callback function which is invoked when the user changed the value.
+func (f *Form) AddSlider(label string, value, minimum, maximum, step float64, changed func(value float64)) *Form {
+	slider := NewSlider().
+		SetLabel(label).
+		SetRange(minimum, maximum).
+		SetStep(step).
+		SetValue(value).
+		SetChangedFunc(changed)
+	f.items = append(f.items, slider)
+	return f
+}
+
+// AddNumberField adds a number field to the form. It has a label, an initial
+// value, the range of values, the step by which the arrow keys change the
+// value, and an (optional) callback function which is invoked when the user
+// changed the value. The value is rounded to the decimal places of the step.
+func (f *Form) AddNumberField(label string, value, minimum, maximum, step float64, changed func(value float64)) *Form {
+	numberField := NewNumberField().
+		SetLabel(label).
+		SetRange(minimum, maximum).
+		SetStep(step).
+		SetValue(value).
+		SetChangedFunc(changed)
+	f.items = append(f.items, numberField)
+	return f
+}
+
+// AddDatePicker adds a date picker to the form. It has a label, an initial
+// date (which may be the zero value to indicate that no date is selected),
+// and an (optional) callback function which is invoked when the user selected
+// a date.
+func (f *Form) AddDatePicker(label string, date time.Time, changed func(date time.Time)) *Form {
+	datePicker := NewDatePicker().
+		SetLabel(label).
+		SetDate(date).
+		SetChangedFunc(changed)
+	f.items = append(f.items, datePicker)
+	return f
+}
+
+// AddTimePicker adds a time picker to the form. It has a label, an initial
+// time, and an (optional) callback function which is invoked when the user
+// changed the time.
+func (f *Form) AddTimePicker(label string, clock time.Time, changed func(clock time.Time)) *Form {
+	timePicker := NewTimePicker().
+		SetLabel(label).
+		SetTime(clock).
+		SetChangedFunc(changed)
+	f.items = append(f.items, timePicker)
+	return f
+}
+
 // AddImage adds an image to the form. It has a label and the image will fit in
 // the specified width and height (its aspect ratio is preserved). See
 // [Image.SetColors] for a description of the "colors" parameter. Images are not
@@ -376,6 +567,7 @@ func (f *Form) GetButton(index int) *Button {
 // RemoveButton removes the button at the specified position, starting with 0
 // for the button that was added first.
 func (f *Form) RemoveButton(index int) *Form {
+	delete(f.unvalidatedButtons, f.buttons[index])
 	f.buttons = append(f.buttons[:index], f.buttons[index+1:]...)
 	return f
 }
@@ -401,6 +593,9 @@ func (f *Form) GetButtonIndex(label string) int {
 // specified.
 func (f *Form) Clear(includeButtons bool) *Form {
 	f.items = nil
+	f.validators = nil
+	f.validationErrors = nil
+	f.structFields = nil
 	if includeButtons {
 		f.ClearButtons()
 	}
@@ -410,6 +605,7 @@ func (f *Form) Clear(includeButtons bool) *Form {
 // ClearButtons removes all buttons from the form.
 func (f *Form) ClearButtons() *Form {
 	f.buttons = nil
+	f.unvalidatedButtons = nil
 	return f
 }
 
@@ -445,6 +641,12 @@ func (f *Form) GetFormItem(index int) FormItem {
 // index 0. Elements are referenced in the order they were added. Buttons are
 // not included.
 func (f *Form) RemoveFormItem(index int) *Form {
+	item := f.items[index]
+	delete(f.validators, item)
+	delete(f.validationErrors, item)
+	f.structFields = slices.DeleteFunc(f.structFields, func(field formStructField) bool {
+		return field.item == item
+	})
 	f.items = append(f.items[:index], f.items[index+1:]...)
 	return f
 }
@@ -487,7 +689,8 @@ func (f *Form) GetFocusedItemIndex() (formItem, button int) {
 }
 
 // SetSubmitFunc sets a handler which is called when the user hits the Enter
-// key. It typically signals that the user wants to submit the form.
+// key. It typically signals that the user wants to submit the form. The
+// handler is not called if any form items are invalid (see [Form.Validate]).
 func (f *Form) SetSubmitFunc(callback func()) *Form {
 	f.submit = callback
 	return f
@@ -522,7 +725,7 @@ func (f *Form) Draw(screen tcell.Screen) {
 	maxLabelWidth++ // Add one space.
 
 	// Calculate positions of form items.
-	type position struct{ x, y, width, height int }
+	type position struct{ x, y, width, height, labelWidth int }
 	positions := make([]position, len(f.items)+len(f.buttons))
 	var (
 		focusedPosition position
@@ -548,6 +751,10 @@ func (f *Form) Draw(screen tcell.Screen) {
 		if itemHeight <= 0 {
 			itemHeight = DefaultFormFieldHeight
 		}
+		var errorHeight int
+		if f.validationErrors[item] != nil {
+			errorHeight = 1 // The error message is shown below the item.
+		}
 
 		// Advance to next line if there is no space.
 		if f.horizontal && x+labelWidth+1 >= rightLimit {
@@ -557,8 +764,8 @@ func (f *Form) Draw(screen tcell.Screen) {
 		}
 
 		// Update line height.
-		if itemHeight > lineHeight {
-			lineHeight = itemHeight
+		if itemHeight+errorHeight > lineHeight {
+			lineHeight = itemHeight + errorHeight
 		}
 
 		// Adjust the item's attributes.
@@ -579,15 +786,17 @@ func (f *Form) Draw(screen tcell.Screen) {
 		positions[index].y = y
 		positions[index].width = itemWidth
 		positions[index].height = itemHeight
+		positions[index].labelWidth = labelWidth
 		if item.HasFocus() {
 			focusedPosition = positions[index]
+			focusedPosition.height += errorHeight
 		}
 
 		// Advance to next item.
 		if f.horizontal {
 			x += itemWidth + f.itemPadding
 		} else {
-			y += itemHeight + f.itemPadding
+			y += itemHeight + errorHeight + f.itemPadding
 		}
 	}
 
@@ -668,6 +877,12 @@ func (f *Form) Draw(screen tcell.Screen) {
 		height := positions[index].height
 		item.SetRect(positions[index].x, y, positions[index].width, height)
 
+		// Draw the error message below the item.
+		if err := f.validationErrors[item]; err != nil && y+height >= topLimit && y+height < bottomLimit {
+			labelWidth := min(positions[index].labelWidth, positions[index].width)
+			printWithStyle(screen, Escape(err.Error()), positions[index].x+labelWidth, y+height, 0, positions[index].width-labelWidth, AlignLeft, f.errorStyle, true)
+		}
+
 		// Is this item visible?
 		if y+height <= topLimit || y >= bottomLimit {
 			continue
@@ -767,6 +982,14 @@ func (f *Form) focusChain(chain *[]Primitive) bool {
 // MouseHandler returns the mouse handler for this primitive.
 func (f *Form) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return f.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		// Validate the form item the user leaves.
+		previous := f.focusIndex()
+		defer func() {
+			if previous >= 0 && previous < len(f.items) && f.focusIndex() != previous {
+				f.validateItem(f.items[previous])
+			}
+		}()
+
 		// Determine items to pass mouse events to.
 		for _, item := range f.items {
 			if item.GetDisabled() {
@@ -781,6 +1004,12 @@ func (f *Form) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 			if button.GetDisabled() {
 				continue
 			}
+			if action == MouseLeftClick && button.InRect(event.Position()) && !f.unvalidatedButtons[button] {
+				if invalid := f.validate(); invalid >= 0 {
+					setFocus(f.items[invalid])
+					return true, nil
+				}
+			}
 			consumed, capture = button.MouseHandler()(action, event, setFocus)
 			if consumed {
 				return
@@ -817,15 +1046,33 @@ func (f *Form) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 			allowExit = formItem.AllowExit(event)
 		}
 
+		// Validate the form before a button is activated.
+		if button, ok := item.(*Button); ok && event.Key() == tcell.KeyEnter && !button.GetDisabled() && !f.unvalidatedButtons[button] {
+			if invalid := f.validate(); invalid >= 0 {
+				setFocus(f.items[invalid])
+				return
+			}
+		}
+
 		// Handle input.
 		if handler := item.InputHandler(); handler != nil {
 			handler(event, setFocus)
 		}
 
+		// Validate invalid items again after each change.
+		if current < len(f.items) && f.validationErrors[f.items[current]] != nil {
+			f.validateItem(f.items[current])
+		}
+
 		if !allowExit {
 			return
 		}
 
+		// Validate the item the user leaves.
+		if key := event.Key(); current < len(f.items) && (key == tcell.KeyTab || key == tcell.KeyBacktab) {
+			f.validateItem(f.items[current])
+		}
+
 		switch event.Key() {
 		case tcell.KeyTab: // Move to next item.
 			for range len(f.items) + len(f.buttons) {
@@ -861,6 +1108,10 @@ func (f *Form) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 			}
 		case tcell.KeyEnter: // Submit form.
 			if current < len(f.items) && f.submit != nil {
+				if invalid := f.validate(); invalid >= 0 {
+					setFocus(f.items[invalid])
+					return
+				}
 				f.submit()
 			}
 		case tcell.KeyEscape: // Cancel form.
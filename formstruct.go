@@ -0,0 +1,375 @@
+package tview
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// formStructField binds a form item to a field of a struct added with
+// [Form.AddStruct].
+type formStructField struct {
+	item   FormItem
+	reload func() // Sets the form item's value to the value of the field.
+}
+
+// formStructTag contains the options of a "tview" struct tag.
+type formStructTag struct {
+	label              string
+	width              int
+	required           bool
+	password           bool
+	slider             bool
+	options            []string
+	minValue, maxValue float64
+	step               float64
+	precision          int
+}
+
+// AddStruct adds a form item for each exported field of the struct the given
+// pointer points to and binds the items to the fields: When the user changes
+// an item, its field is updated. Use [Form.ReloadStruct] to update the items
+// after the struct was changed elsewhere. Fields of embedded structs are added
+// as if they were fields of the struct itself.
+//
+// The form item depends on the field's type:
+//
+//   - string: An [InputField] or, with the "options" option, a [DropDown].
+//   - bool: A [Checkbox].
+//   - Integers: A [NumberField] or, with the "options" option, a [DropDown]
+//     whose selected index is stored in the field (for enumerations).
+//   - Floating-point numbers: A [NumberField].
+//   - [time.Time]: A [DatePicker].
+//   - [time.Duration]: An [InputField] for durations such as "1h30m" (see
+//     [time.ParseDuration]). The field is only updated if the text is a
+//     valid duration. Invalid text is reported as a validation error.
+//
+// Fields of other types are skipped. Fields may be tagged with "tview" struct
+// tags, which contain comma-separated options:
+//
+//   - label=text: The item's label (defaults to the field's name).
+//   - width=n: The item's field width.
+//   - required: The item must have a value (see [RequiredValidator]). For
+//     bool fields, the checkbox must be checked. Not supported for numbers
+//     without options, which always have a value.
+//   - password: The text is masked (strings only).
+//   - options=a|b|c: The options of a drop-down.
+//   - min=x, max=y: The range of numbers (defaults to the type's range).
+//   - step=x: The step by which numbers are changed (defaults to 1).
+//   - precision=n: The number of decimal places of numbers (defaults to 0
+//     for integers and to the decimal places of the step or 2 for
+//     floating-point numbers).
+//   - slider: Use a [Slider] instead of a [NumberField] (numbers only).
+//
+// A tag of "-" skips the field. For example:
+//
+//	type Config struct {
+//	  Name     string  `tview:"label=Name,width=20,required"`
+//	  Level    int     `tview:"label=Log level,options=Debug|Info|Warning|Error"`
+//	  Ratio    float64 `tview:"min=0,max=1,step=0.05,slider"`
+//	  Verbose  bool
+//	  internal string
+//	}
+//
+// This function panics if the argument is not a pointer to a struct or if a
+// struct tag is invalid.
+func (f *Form) AddStruct(structPtr any) *Form {
+	value := reflect.ValueOf(structPtr)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("tview: AddStruct requires a pointer to a struct, got %T", structPtr))
+	}
+	f.addStructFields(value.Elem())
+	return f
+}
+
+// ReloadStruct sets the values of all form items added with [Form.AddStruct]
+// to the current values of their struct fields.
+func (f *Form) ReloadStruct() *Form {
+	for _, field := range f.structFields {
+		field.reload()
+	}
+	return f
+}
+
+// addStructFields adds form items for the fields of the given struct.
+func (f *Form) addStructFields(value reflect.Value) {
+	for index := range value.NumField() {
+		field := value.Type().Field(index)
+		if field.Tag.Get("tview") == "-" {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			f.addStructFields(value.Field(index))
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		tag := parseFormStructTag(field)
+		item, reload := newFormStructItem(value.Field(index), tag)
+		if item == nil {
+			continue // Unsupported type.
+		}
+		reload()
+		f.items = append(f.items, item)
+		f.structFields = append(f.structFields, formStructField{item: item, reload: reload})
+		var validators []FormValidator
+		if tag.required {
+			validators = append(validators, RequiredValidator(tag.label+" is required"))
+		}
+		if field.Type == reflect.TypeFor[time.Duration]() {
+			validators = append(validators, durationValidator)
+		}
+		if len(validators) > 0 {
+			f.SetValidators(item, validators...)
+		}
+	}
+}
+
+// durationValidator reports an error if the text of an [InputField] is not a
+// valid duration.
+func durationValidator(item FormItem) error {
+	text, _ := formItemText(item)
+	_, err := time.ParseDuration(strings.TrimSpace(text))
+	return err
+}
+
+// parseFormStructTag parses the "tview" struct tag of the given field. It
+// panics if the tag is invalid.
+func parseFormStructTag(field reflect.StructField) formStructTag {
+	tag := formStructTag{
+		label:     field.Name,
+		minValue:  math.Inf(-1),
+		maxValue:  math.Inf(1),
+		precision: -1,
+	}
+	for option := range strings.SplitSeq(field.Tag.Get("tview"), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		var err error
+		switch key {
+		case "":
+		case "label":
+			tag.label = value
+		case "width":
+			tag.width, err = strconv.Atoi(value)
+		case "required":
+			tag.required = true
+		case "password":
+			tag.password = true
+		case "slider":
+			tag.slider = true
+		case "options":
+			tag.options = strings.Split(value, "|")
+		case "min":
+			tag.minValue, err = strconv.ParseFloat(value, 64)
+		case "max":
+			tag.maxValue, err = strconv.ParseFloat(value, 64)
+		case "step":
+			tag.step, err = strconv.ParseFloat(value, 64)
+		case "precision":
+			tag.precision, err = strconv.Atoi(value)
+		default:
+			err = fmt.Errorf("unknown option %q", key)
+		}
+		if err != nil {
+			panic(fmt.Sprintf("tview: invalid struct tag of field %s: %s", field.Name, err))
+		}
+	}
+	switch field.Type.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		if tag.required && tag.options == nil && field.Type != reflect.TypeFor[time.Duration]() {
+			panic(fmt.Sprintf("tview: invalid struct tag of field %s: option \"required\" is not supported for numbers", field.Name))
+		}
+	}
+	return tag
+}
+
+// newFormStructItem returns a form item for the given struct field and a
+// function which sets the item's value to the field's value. If the field's
+// type is not supported, nil is returned.
+func newFormStructItem(field reflect.Value, tag formStructTag) (FormItem, func()) {
+	// Values are not written back to the field while reloading.
+	var loading bool
+	load := func(set func()) func() {
+		return func() {
+			loading = true
+			defer func() {
+				loading = false
+			}()
+			set()
+		}
+	}
+
+	kind := field.Kind()
+	switch {
+	case field.Type() == reflect.TypeFor[time.Time]():
+		datePicker := NewDatePicker().
+			SetLabel(tag.label).
+			SetFieldWidth(tag.width).
+			SetChangedFunc(func(date time.Time) {
+				if !loading {
+					field.Set(reflect.ValueOf(date))
+				}
+			})
+		return datePicker, load(func() {
+			datePicker.SetDate(field.Interface().(time.Time))
+		})
+
+	case field.Type() == reflect.TypeFor[time.Duration]():
+		inputField := NewInputField().
+			SetLabel(tag.label).
+			SetFieldWidth(tag.width).
+			SetChangedFunc(func(text string) {
+				if duration, err := time.ParseDuration(strings.TrimSpace(text)); err == nil && !loading {
+					field.SetInt(int64(duration))
+				}
+			})
+		return inputField, load(func() {
+			inputField.SetText(time.Duration(field.Int()).String())
+		})
+
+	case tag.options != nil && (kind == reflect.String || field.CanInt() || field.CanUint()):
+		dropDown := NewDropDown().
+			SetLabel(tag.label).
+			SetFieldWidth(tag.width).
+			SetOptions(tag.options, func(text string, index int) {
+				if loading || index < 0 {
+					return
+				}
+				switch {
+				case kind == reflect.String:
+					field.SetString(text)
+				case field.CanInt():
+					field.SetInt(int64(index))
+				default:
+					field.SetUint(uint64(index))
+				}
+			})
+		return dropDown, load(func() {
+			index := -1
+			switch {
+			case kind == reflect.String:
+				index = slices.Index(tag.options, field.String())
+			case field.CanInt():
+				index = int(field.Int())
+			default:
+				index = int(field.Uint())
+			}
+			dropDown.SetCurrentOption(index)
+		})
+
+	case kind == reflect.String:
+		inputField := NewInputField().
+			SetLabel(tag.label).
+			SetFieldWidth(tag.width).
+			SetChangedFunc(func(text string) {
+				if !loading {
+					field.SetString(text)
+				}
+			})
+		if tag.password {
+			inputField.SetMaskCharacter('*')
+		}
+		return inputField, load(func() {
+			inputField.SetText(field.String())
+		})
+
+	case kind == reflect.Bool:
+		checkbox := NewCheckbox().
+			SetLabel(tag.label).
+			SetChangedFunc(func(checked bool) {
+				if !loading {
+					field.SetBool(checked)
+				}
+			})
+		return checkbox, load(func() {
+			checkbox.SetChecked(field.Bool())
+		})
+
+	case field.CanInt() || field.CanUint() || field.CanFloat():
+		// Determine the range and the precision.
+		minimum, maximum := tag.minValue, tag.maxValue
+		bits := field.Type().Bits()
+		precision := tag.precision
+		switch {
+		case field.CanInt():
+			minimum = max(minimum, -math.Pow(2, float64(bits-1)))
+			maximum = min(maximum, math.Pow(2, float64(bits-1))-1)
+			precision = 0
+		case field.CanUint():
+			minimum = max(minimum, 0)
+			maximum = min(maximum, math.Pow(2, float64(bits))-1)
+			precision = 0
+		case precision < 0 && tag.step == 0:
+			precision = 2
+		}
+		step := tag.step
+		if step == 0 {
+			step = 1
+		}
+		setNumber := func(value float64) {
+			if loading || math.IsNaN(value) || math.IsInf(value, 0) {
+				return
+			}
+			// Values outside the type's range are not written.
+			rounded := math.Round(value)
+			switch {
+			case field.CanInt():
+				if rounded >= -math.Pow(2, float64(bits-1)) && rounded < math.Pow(2, float64(bits-1)) {
+					field.SetInt(int64(rounded))
+				}
+			case field.CanUint():
+				if rounded >= 0 && rounded < math.Pow(2, float64(bits)) {
+					field.SetUint(uint64(rounded))
+				}
+			case !field.OverflowFloat(value):
+				field.SetFloat(value)
+			}
+		}
+		getNumber := func() float64 {
+			switch {
+			case field.CanInt():
+				return float64(field.Int())
+			case field.CanUint():
+				return float64(field.Uint())
+			}
+			return field.Float()
+		}
+
+		// Sliders need a finite range.
+		if tag.slider {
+			if math.IsInf(tag.minValue, 0) || math.IsInf(tag.maxValue, 0) {
+				minimum, maximum = max(minimum, 0), min(maximum, 100)
+			}
+			slider := NewSlider().
+				SetLabel(tag.label).
+				SetFieldWidth(tag.width).
+				SetRange(minimum, maximum).
+				SetStep(step).
+				SetPrecision(precision).
+				SetChangedFunc(setNumber)
+			return slider, load(func() {
+				slider.SetValue(getNumber())
+			})
+		}
+
+		numberField := NewNumberField().
+			SetLabel(tag.label).
+			SetFieldWidth(tag.width).
+			SetRange(minimum, maximum).
+			SetStep(step).
+			SetPrecision(precision).
+			SetChangedFunc(setNumber)
+		return numberField, load(func() {
+			numberField.SetValue(getNumber())
+		})
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,441 @@
+package tview
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// HighlightRange is a range of a line of text which is shown in a specific
+// style, as determined by a [Highlighter].
+type HighlightRange struct {
+	// The start and end byte positions of the range within the line. The end
+	// position is exclusive.
+	Start, End int
+
+	// The style of the range. Its foreground and background colors replace
+	// those of the text unless they are [tcell.ColorDefault]. Its attributes
+	// are added to the text's attributes.
+	Style tcell.Style
+}
+
+// Highlighter determines the styles of text for syntax highlighting. It can be
+// attached to a [TextArea] (see [TextArea.SetHighlighter]) or a [TextView]
+// (see [TextView.SetHighlighter]).
+//
+// Text is highlighted line by line. To support constructs spanning multiple
+// lines, e.g. block comments, a highlighter may keep an integer state between
+// lines. Lines are only highlighted again if their text or the state at their
+// beginning changed. This way, a highlighter usually only needs to process a
+// few lines when the text is edited.
+//
+// [SyntaxHighlighter] implements this interface for a number of common
+// languages.
+type Highlighter interface {
+	// Highlight returns the styled ranges of the given line of text (without
+	// the trailing newline character). The ranges must be sorted by their
+	// positions and they must not overlap. Text not covered by a range keeps
+	// its original style.
+	//
+	// The provided state is the state returned for the previous line, or 0 for
+	// the first line. The returned state is passed to the next line.
+	Highlight(line string, state int) (ranges []HighlightRange, newState int)
+}
+
+// SyntaxClass is a class of syntax elements styled by a [SyntaxHighlighter].
+type SyntaxClass int
+
+// Classes of syntax elements.
+const (
+	SyntaxKeyword  SyntaxClass = iota // Keywords, e.g. "func" or "SELECT".
+	SyntaxType                        // Names of types, e.g. "int".
+	SyntaxConstant                    // Predefined constants, e.g. "true" or "null".
+	SyntaxNumber                      // Numeric literals.
+	SyntaxString                      // String and character literals.
+	SyntaxComment                     // Comments.
+	SyntaxKey                         // Keys of objects and mappings.
+	SyntaxVariable                    // Variables, parameters, and anchors.
+	SyntaxInserted                    // Lines added in a diff.
+	SyntaxDeleted                     // Lines removed in a diff.
+	SyntaxHeader                      // File headers and hunk headers in a diff.
+)
+
+// syntaxRule describes how a syntax element is recognized.
+type syntaxRule struct {
+	// The class of the syntax element.
+	class SyntaxClass
+
+	// The pattern matching the element. It only matches at the current
+	// position. If it contains a capturing group, only the text of the group
+	// is styled.
+	pattern *regexp.Regexp
+
+	// If not nil, the pattern matches the beginning of an element which may
+	// span multiple lines and which ends with the first match of this
+	// expression.
+	end *regexp.Regexp
+
+	// If true, the rule is only applied at the beginning of a line.
+	lineStart bool
+}
+
+// syntaxWord matches a word which is skipped if no rule matches at its
+// beginning. This way, rules are never applied inside words.
+var syntaxWord = regexp.MustCompile(`^[\p{L}\p{N}_]+`)
+
+// syntaxLanguages maps language names to their rules. Use
+// [NewSyntaxHighlighter] to access them.
+var syntaxLanguages = map[string][]syntaxRule{
+	"go": {
+		{class: SyntaxComment, pattern: syntaxPattern(`//.*`)},
+		{class: SyntaxComment, pattern: syntaxPattern(`/\*`), end: regexp.MustCompile(`\*/`)},
+		{class: SyntaxString, pattern: syntaxPattern("`"), end: regexp.MustCompile("`")},
+		{class: SyntaxString, pattern: syntaxPattern(`"(?:[^"\\]|\\.)*"?|'(?:[^'\\]|\\.)*'?`)},
+		{class: SyntaxKeyword, pattern: syntaxWords("break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for", "func", "go", "goto", "if", "import", "interface", "map", "package", "range", "return", "select", "struct", "switch", "type", "var")},
+		{class: SyntaxType, pattern: syntaxWords("any", "bool", "byte", "comparable", "complex64", "complex128", "error", "float32", "float64", "int", "int8", "int16", "int32", "int64", "rune", "string", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr")},
+		{class: SyntaxConstant, pattern: syntaxWords("true", "false", "nil", "iota")},
+		{class: SyntaxNumber, pattern: syntaxPattern(`(?:0[xX][\da-fA-F_]+|0[bB][01_]+|0[oO][0-7_]+|\d[\d_]*(?:\.[\d_]*)?(?:[eE][+-]?\d+)?|\.\d[\d_]*(?:[eE][+-]?\d+)?)i?`)},
+	},
+	"json": {
+		{class: SyntaxKey, pattern: syntaxPattern(`("(?:[^"\\]|\\.)*")\s*:`)},
+		{class: SyntaxString, pattern: syntaxPattern(`"(?:[^"\\]|\\.)*"?`)},
+		{class: SyntaxConstant, pattern: syntaxWords("true", "false", "null")},
+		{class: SyntaxNumber, pattern: syntaxPattern(`-?\d+(?:\.\d+)?(?:[eE][+-]?\d+)?`)},
+	},
+	"yaml": {
+		{class: SyntaxComment, pattern: syntaxPattern(`#.*`)},
+		{class: SyntaxHeader, pattern: syntaxPattern(`(?:---|\.\.\.)(?:\s.*)?$`), lineStart: true},
+		{class: SyntaxKey, pattern: syntaxPattern(`\s*(?:-\s+)*([^\s#'"{}\[\],&*!|>%@-][^#]*?|"(?:[^"\\]|\\.)*"|'(?:[^']|'')*')\s*:(?:\s|$)`), lineStart: true},
+		{class: SyntaxString, pattern: syntaxPattern(`"(?:[^"\\]|\\.)*"?|'(?:[^']|'')*'?`)},
+		{class: SyntaxVariable, pattern: syntaxPattern(`[&*][^\s,\[\]{}]+`)},
+		{class: SyntaxType, pattern: syntaxPattern(`!!?[\w/.-]*`)},
+		{class: SyntaxConstant, pattern: syntaxPattern(`(?:true|false|True|False|TRUE|FALSE|yes|no|null|Null|NULL)\b|~`)},
+		{class: SyntaxNumber, pattern: syntaxPattern(`[-+]?(?:\d[\d_]*(?:\.\d*)?(?:[eE][+-]?\d+)?|\.inf|\.nan)\b`)},
+	},
+	"sql": {
+		{class: SyntaxComment, pattern: syntaxPattern(`--.*`)},
+		{class: SyntaxComment, pattern: syntaxPattern(`/\*`), end: regexp.MustCompile(`\*/`)},
+		{class: SyntaxString, pattern: syntaxPattern(`'`), end: regexp.MustCompile(`^(?:[^']|'')*'`)},
+		{class: SyntaxKeyword, pattern: syntaxWords("(?i)add", "all", "alter", "and", "as", "asc", "begin", "between", "by", "cascade", "case", "check", "column", "commit", "constraint", "create", "cross", "declare", "default", "delete", "desc", "distinct", "drop", "else", "end", "exists", "foreign", "from", "full", "function", "grant", "group", "having", "if", "ilike", "in", "index", "inner", "insert", "intersect", "into", "is", "join", "key", "left", "like", "limit", "not", "offset", "on", "or", "order", "outer", "primary", "procedure", "references", "replace", "returning", "revoke", "right", "rollback", "select", "set", "table", "then", "transaction", "trigger", "truncate", "union", "unique", "update", "using", "values", "view", "when", "where", "with")},
+		{class: SyntaxType, pattern: syntaxWords("(?i)bigint", "bigserial", "blob", "bool", "boolean", "bytea", "char", "date", "decimal", "double", "float", "int", "integer", "interval", "json", "jsonb", "numeric", "precision", "real", "serial", "smallint", "text", "time", "timestamp", "timestamptz", "tinyint", "uuid", "varchar")},
+		{class: SyntaxConstant, pattern: syntaxWords("(?i)null", "true", "false")},
+		{class: SyntaxNumber, pattern: syntaxPattern(`\d+(?:\.\d+)?(?:[eE][+-]?\d+)?`)},
+		{class: SyntaxVariable, pattern: syntaxPattern(`[:@$]\w+|\?`)},
+	},
+	"shell": {
+		{class: SyntaxVariable, pattern: syntaxPattern(`\$(?:\{[^}]*\}?|\w+|[@*#?$!-])`)},
+		{class: SyntaxComment, pattern: syntaxPattern(`#.*`)},
+		{class: SyntaxString, pattern: syntaxPattern(`"`), end: regexp.MustCompile(`^(?:[^"\\]|\\.)*"`)},
+		{class: SyntaxString, pattern: syntaxPattern(`'`), end: regexp.MustCompile(`'`)},
+		{class: SyntaxKeyword, pattern: syntaxWords("break", "case", "continue", "declare", "do", "done", "elif", "else", "esac", "exit", "export", "fi", "for", "function", "if", "in", "local", "readonly", "return", "select", "shift", "source", "then", "unset", "until", "while")},
+	},
+	"diff": {
+		{class: SyntaxHeader, pattern: syntaxPattern(`(?:diff|index|---|\+\+\+) .*|@@.*`), lineStart: true},
+		{class: SyntaxInserted, pattern: syntaxPattern(`[+>].*`), lineStart: true},
+		{class: SyntaxDeleted, pattern: syntaxPattern(`[-<].*`), lineStart: true},
+	},
+}
+
+// syntaxAliases maps alternative language names to those used in
+// syntaxLanguages.
+var syntaxAliases = map[string]string{
+	"golang": "go",
+	"yml":    "yaml",
+	"sh":     "shell",
+	"bash":   "shell",
+	"zsh":    "shell",
+	"patch":  "diff",
+}
+
+// syntaxPattern compiles the given regular expression such that it only
+// matches at the beginning of the text.
+func syntaxPattern(expression string) *regexp.Regexp {
+	return regexp.MustCompile(`^(?:` + expression + `)`)
+}
+
+// syntaxWords returns a pattern matching any of the given words. If the first
+// word starts with flags (e.g. "(?i)"), they are applied to all words.
+func syntaxWords(words ...string) *regexp.Regexp {
+	var flags string
+	if strings.HasPrefix(words[0], "(?") {
+		flags, words[0], _ = strings.Cut(words[0], ")")
+		flags += ")"
+	}
+	return regexp.MustCompile(flags + `^(?:` + strings.Join(words, "|") + `)\b`)
+}
+
+// SyntaxHighlighter is a [Highlighter] for a number of common languages. It
+// recognizes keywords, literals, comments, and similar elements of a language
+// with regular expressions. It is not a full parser so its results may not
+// always be accurate but they are usually good enough for source code written
+// in the language.
+//
+// The following languages are supported (alternative names in parentheses):
+//
+//   - "go" ("golang")
+//   - "json"
+//   - "yaml" ("yml")
+//   - "sql"
+//   - "shell" ("sh", "bash", "zsh")
+//   - "diff" ("patch")
+//
+// Use [SyntaxHighlighter.SetStyle] to change the style of a class of syntax
+// elements.
+type SyntaxHighlighter struct {
+	// The rules of the language.
+	rules []syntaxRule
+
+	// The styles of the syntax element classes.
+	styles map[SyntaxClass]tcell.Style
+}
+
+// NewSyntaxHighlighter returns a new syntax highlighter for the given
+// language. Language names are case-insensitive. If the language is not
+// supported, the returned highlighter does not highlight anything.
+func NewSyntaxHighlighter(language string) *SyntaxHighlighter {
+	language = strings.ToLower(language)
+	if name, ok := syntaxAliases[language]; ok {
+		language = name
+	}
+	return &SyntaxHighlighter{
+		rules: syntaxLanguages[language],
+		styles: map[SyntaxClass]tcell.Style{
+			SyntaxKeyword:  tcell.StyleDefault.Foreground(tcell.ColorFuchsia),
+			SyntaxType:     tcell.StyleDefault.Foreground(tcell.ColorAqua),
+			SyntaxConstant: tcell.StyleDefault.Foreground(tcell.ColorOrange),
+			SyntaxNumber:   tcell.StyleDefault.Foreground(tcell.ColorOrange),
+			SyntaxString:   tcell.StyleDefault.Foreground(tcell.ColorGreen),
+			SyntaxComment:  tcell.StyleDefault.Foreground(tcell.ColorGray),
+			SyntaxKey:      tcell.StyleDefault.Foreground(tcell.ColorAqua),
+			SyntaxVariable: tcell.StyleDefault.Foreground(tcell.ColorYellow),
+			SyntaxInserted: tcell.StyleDefault.Foreground(tcell.ColorGreen),
+			SyntaxDeleted:  tcell.StyleDefault.Foreground(tcell.ColorRed),
+			SyntaxHeader:   tcell.StyleDefault.Foreground(tcell.ColorAqua).Bold(true),
+		},
+	}
+}
+
+// SetStyle sets the style of the given class of syntax elements. See
+// [HighlightRange] for how styles are applied to the text.
+func (s *SyntaxHighlighter) SetStyle(class SyntaxClass, style tcell.Style) *SyntaxHighlighter {
+	s.styles[class] = style
+	return s
+}
+
+// Highlight implements [Highlighter]. A state greater than 0 indicates that
+// the line begins inside an element which spans multiple lines.
+func (s *SyntaxHighlighter) Highlight(line string, state int) (ranges []HighlightRange, newState int) {
+	add := func(class SyntaxClass, start, end int) {
+		if start >= end {
+			return
+		}
+		style := s.styles[class]
+		if last := len(ranges) - 1; last >= 0 && ranges[last].End == start && ranges[last].Style == style {
+			ranges[last].End = end
+			return
+		}
+		ranges = append(ranges, HighlightRange{Start: start, End: end, Style: style})
+	}
+
+	// Continue an element from the previous line.
+	var pos int
+	if state > 0 && state <= len(s.rules) {
+		rule := s.rules[state-1]
+		location := rule.end.FindStringIndex(line)
+		if location == nil {
+			add(rule.class, 0, len(line))
+			return ranges, state
+		}
+		add(rule.class, 0, location[1])
+		pos = location[1]
+	}
+
+	// Find elements.
+	for pos < len(line) {
+		rest := line[pos:]
+		matched := false
+		for index, rule := range s.rules {
+			if rule.lineStart && pos > 0 {
+				continue
+			}
+			match := rule.pattern.FindStringSubmatchIndex(rest)
+			if match == nil || match[1] == 0 {
+				continue
+			}
+			matched = true
+			start, end := pos, pos+match[1]
+			if len(match) > 2 && match[2] >= 0 {
+				add(rule.class, pos+match[2], pos+match[3])
+				pos = end
+				break
+			}
+			if rule.end != nil {
+				location := rule.end.FindStringIndex(line[end:])
+				if location == nil {
+					add(rule.class, start, len(line))
+					return ranges, index + 1
+				}
+				end += location[1]
+			}
+			add(rule.class, start, end)
+			pos = end
+			break
+		}
+		if !matched {
+			// Skip a word or a single character.
+			if location := syntaxWord.FindStringIndex(rest); location != nil {
+				pos += location[1]
+			} else {
+				_, size := utf8.DecodeRuneInString(rest)
+				pos += size
+			}
+		}
+	}
+
+	return ranges, 0
+}
+
+// highlightedLine is a line of text with the ranges determined by a
+// [Highlighter].
+type highlightedLine struct {
+	// The byte position of the line within the text.
+	start int
+
+	// The text of the line, without the trailing newline character.
+	text string
+
+	// The highlighter's state at the beginning and at the end of the line.
+	state, endState int
+
+	// The styled ranges of the line.
+	ranges []HighlightRange
+}
+
+// highlightLines splits the given text into lines and highlights them with the
+// given highlighter. Lines from the previously highlighted lines which were
+// not affected by changes to the text are reused. Only lines whose text or
+// whose starting state changed are highlighted again.
+func highlightLines(highlighter Highlighter, text string, previous []highlightedLine) []highlightedLine {
+	var texts []string
+	for line := range strings.SplitSeq(text, "\n") {
+		texts = append(texts, line)
+	}
+
+	// Find the lines at the beginning and at the end which are unchanged.
+	var prefix, suffix int
+	for prefix < len(texts) && prefix < len(previous) && texts[prefix] == previous[prefix].text {
+		prefix++
+	}
+	for suffix < len(texts)-prefix && suffix < len(previous)-prefix && texts[len(texts)-1-suffix] == previous[len(previous)-1-suffix].text {
+		suffix++
+	}
+
+	// Highlight the rest.
+	lines := make([]highlightedLine, len(texts))
+	var start, state int
+	for index, lineText := range texts {
+		var line *highlightedLine
+		if index < prefix {
+			line = &previous[index]
+		} else if index >= len(texts)-suffix {
+			line = &previous[len(previous)-len(texts)+index]
+		}
+		if line != nil && line.state == state {
+			lines[index] = *line
+		} else {
+			lines[index].text, lines[index].state = lineText, state
+			lines[index].ranges, lines[index].endState = highlighter.Highlight(lineText, state)
+		}
+		lines[index].start = start
+		start += len(lineText) + 1
+		state = lines[index].endState
+	}
+
+	return lines
+}
+
+// updateHighlightedLines updates the given highlighted lines after the text
+// between the byte positions "start" and "end" (exclusive) was replaced with
+// the given text. Only the changed lines and the following lines whose
+// starting state changed are highlighted again. The slice may be modified. If
+// the positions are not within the lines, nil is returned.
+func updateHighlightedLines(highlighter Highlighter, lines []highlightedLine, start, end int, insert string) []highlightedLine {
+	// Find the lines containing the start and the end of the change.
+	first := sort.Search(len(lines), func(i int) bool {
+		return lines[i].start > start
+	}) - 1
+	last := sort.Search(len(lines), func(i int) bool {
+		return lines[i].start > end
+	}) - 1
+	if first < 0 || last < first || end-lines[last].start > len(lines[last].text) {
+		return nil
+	}
+
+	// Replace them with the changed lines.
+	text := lines[first].text[:start-lines[first].start] + insert + lines[last].text[end-lines[last].start:]
+	var changed []highlightedLine
+	for line := range strings.SplitSeq(text, "\n") {
+		changed = append(changed, highlightedLine{text: line})
+	}
+	lines = slices.Replace(lines, first, last+1, changed...)
+
+	// Highlight the changed lines and any following lines whose starting state
+	// is different now. Shift the positions of all following lines.
+	var state, position int
+	if first > 0 {
+		state = lines[first-1].endState
+		position = lines[first-1].start + len(lines[first-1].text) + 1
+	}
+	delta := len(insert) - (end - start)
+	for index := first; index < len(lines); index++ {
+		line := &lines[index]
+		if index < first+len(changed) || line.state != state {
+			line.state = state
+			line.ranges, line.endState = highlighter.Highlight(line.text, state)
+		} else if delta == 0 {
+			break // Nothing else changed.
+		}
+		if index < first+len(changed) {
+			line.start = position
+			position += len(line.text) + 1
+		} else {
+			line.start += delta
+		}
+		state = line.endState
+	}
+
+	return lines
+}
+
+// highlightStyle returns the given style of the character at the given byte
+// position of the highlighted text, with the highlighted style applied to it.
+func highlightStyle(lines []highlightedLine, position int, style tcell.Style) tcell.Style {
+	index := sort.Search(len(lines), func(i int) bool {
+		return lines[i].start > position
+	}) - 1
+	if index < 0 {
+		return style
+	}
+	position -= lines[index].start
+	for _, r := range lines[index].ranges {
+		if position < r.Start {
+			break
+		}
+		if position < r.End {
+			fg, bg, attributes := r.Style.Decompose()
+			if fg != tcell.ColorDefault {
+				style = style.Foreground(fg)
+			}
+			if bg != tcell.ColorDefault {
+				style = style.Background(bg)
+			}
+			_, _, textAttributes := style.Decompose()
+			return style.Attributes(textAttributes | attributes)
+		}
+	}
+	return style
+}
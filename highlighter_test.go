@@ -0,0 +1,30 @@
+package tview
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// TestUpdateHighlightedLines tests that updating highlighted lines after edits
+// gives the same result as highlighting the whole text again.
+func TestUpdateHighlightedLines(t *testing.T) {
+	highlighter := NewSyntaxHighlighter("go")
+	pieces := []string{"a", " ", "\n", "/*", "*/", "\"", "func", "// x", "42", "é"}
+	random := rand.New(rand.NewSource(1))
+	text := "package main\n\n/* comment\n*/\nfunc main() {\n\tx := \"text\"\n}\n"
+	lines := highlightLines(highlighter, text, nil)
+	for range 2000 {
+		start := random.Intn(len(text) + 1)
+		end := min(start+random.Intn(5), len(text))
+		var insert string
+		for range random.Intn(3) {
+			insert += pieces[random.Intn(len(pieces))]
+		}
+		text = text[:start] + insert + text[end:]
+		lines = updateHighlightedLines(highlighter, lines, start, end, insert)
+		if expected := highlightLines(highlighter, text, nil); !reflect.DeepEqual(lines, expected) {
+			t.Fatalf("highlighted lines differ after replacing %d-%d with %q in %q", start, end, insert, text)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package tview
+
+import (
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Histogram shows the distribution of a number of samples. The range of the
+// samples is divided into bins of equal size and the number of samples falling
+// into each bin is shown as a vertical bar, drawn with block elements for a
+// resolution of one eighth of a cell. Axes show the counts and the boundaries
+// of the bins.
+//
+// The number of bins is chosen automatically unless it is set with
+// [Histogram.SetBinCount]. The range of the bins spans all samples unless it is
+// set with [Histogram.SetRange]. Samples which are NaN or outside the range are
+// ignored.
+//
+// For streaming data, set a window (see [Histogram.SetWindow]) and add samples
+// with [Histogram.AddSamples]. The histogram then shows the distribution of the
+// most recent samples only. All functions of the histogram may be called from
+// any goroutine. Changes do not cause an automatic redraw, so call
+// [Application.Draw] afterwards.
+type Histogram struct {
+	sync.Mutex
+	*Box
+
+	// The samples.
+	samples []float64
+
+	// The maximum number of samples kept, or 0 for no limit.
+	window int
+
+	// The number of bins, or 0 to choose it automatically.
+	binCount int
+
+	// The fixed range of the bins. If low >= high, the range is determined
+	// automatically.
+	low, high float64
+
+	// The color of the bars.
+	color tcell.Color
+
+	// The style of the axes.
+	axisStyle tcell.Style
+
+	// The style of the axis labels.
+	labelStyle tcell.Style
+}
+
+// NewHistogram returns a new histogram without any samples.
+func NewHistogram() *Histogram {
+	h := &Histogram{
+		Box:        NewBox(),
+		color:      Styles.TertiaryTextColor,
+		axisStyle:  tcell.StyleDefault.Foreground(Styles.GraphicsColor),
+		labelStyle: tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+	}
+	h.Box.Primitive = h
+	return h
+}
+
+// SetSamples replaces the samples of the histogram.
+func (h *Histogram) SetSamples(samples ...float64) *Histogram {
+	h.Lock()
+	defer h.Unlock()
+	h.samples, _ = chartAppend(nil, h.window, samples...)
+	return h
+}
+
+// AddSamples adds samples to the histogram. If a window is set (see
+// [Histogram.SetWindow]), the oldest samples are discarded.
+func (h *Histogram) AddSamples(samples ...float64) *Histogram {
+	h.Lock()
+	defer h.Unlock()
+	h.samples, _ = chartAppend(h.samples, h.window, samples...)
+	return h
+}
+
+// GetSampleCount returns the number of samples in the histogram.
+func (h *Histogram) GetSampleCount() int {
+	h.Lock()
+	defer h.Unlock()
+	return len(h.samples)
+}
+
+// SetWindow sets the maximum number of samples kept by the histogram. When more
+// samples are added, the oldest samples are discarded. A value of 0 (the
+// default) means there is no limit.
+func (h *Histogram) SetWindow(window int) *Histogram {
+	h.Lock()
+	defer h.Unlock()
+	h.window = max(window, 0)
+	h.samples, _ = chartAppend(h.samples, h.window)
+	return h
+}
+
+// SetBinCount sets the number of bins. A value of 0 (the default) causes the
+// number of bins to be chosen based on the number of samples (using Sturges'
+// rule).
+func (h *Histogram) SetBinCount(count int) *Histogram {
+	h.Lock()
+	defer h.Unlock()
+	h.binCount = max(count, 0)
+	return h
+}
+
+// SetRange sets a fixed range for the bins. Samples outside the range are
+// ignored. If low is not smaller than high (e.g. if both are 0, the default),
+// the range spans all samples.
+func (h *Histogram) SetRange(low, high float64) *Histogram {
+	h.Lock()
+	defer h.Unlock()
+	h.low, h.high = low, high
+	return h
+}
+
+// SetColor sets the color of the bars.
+func (h *Histogram) SetColor(color tcell.Color) *Histogram {
+	h.Lock()
+	defer h.Unlock()
+	h.color = color
+	return h
+}
+
+// SetAxisStyle sets the style of the axes.
+func (h *Histogram) SetAxisStyle(style tcell.Style) *Histogram {
+	h.Lock()
+	defer h.Unlock()
+	h.axisStyle = style
+	return h
+}
+
+// SetLabelStyle sets the style of the axis labels.
+func (h *Histogram) SetLabelStyle(style tcell.Style) *Histogram {
+	h.Lock()
+	defer h.Unlock()
+	h.labelStyle = style
+	return h
+}
+
+// GetBins returns the boundaries of the bins and the number of samples in each
+// bin. The i-th bin spans the values from bounds[i] (inclusive) to bounds[i+1]
+// (exclusive, except for the last bin).
+func (h *Histogram) GetBins() (bounds []float64, counts []int) {
+	h.Lock()
+	defer h.Unlock()
+	return h.bins()
+}
+
+// bins implements [Histogram.GetBins] without locking.
+func (h *Histogram) bins() (bounds []float64, counts []int) {
+	low, high := h.low, h.high
+	if low >= high {
+		var ok bool
+		low, high, ok = chartRange(h.samples)
+		if !ok {
+			return nil, nil
+		}
+		if high <= low {
+			low, high = low-0.5, high+0.5
+		}
+	}
+	count := h.binCount
+	if count == 0 {
+		count = int(math.Ceil(math.Log2(float64(max(len(h.samples), 1))))) + 1
+	}
+	bounds = make([]float64, count+1)
+	for index := range bounds {
+		bounds[index] = low + (high-low)*float64(index)/float64(count)
+	}
+	counts = make([]int, count)
+	for _, sample := range h.samples {
+		if math.IsNaN(sample) || sample < low || sample > high {
+			continue
+		}
+		counts[min(int((sample-low)/(high-low)*float64(count)), count-1)]++
+	}
+	return
+}
+
+// Draw draws this primitive onto the screen.
+func (h *Histogram) Draw(screen tcell.Screen) {
+	h.Box.DrawForSubclass(screen, h)
+
+	h.Lock()
+	defer h.Unlock()
+
+	x, y, width, height := h.GetInnerRect()
+	bounds, counts := h.bins()
+	if width <= 4 || height <= 3 || len(counts) == 0 {
+		return
+	}
+
+	// Draw the vertical axis.
+	plotHeight := height - 2
+	var maxCount int
+	for _, count := range counts {
+		maxCount = max(maxCount, count)
+	}
+	_, top, step, _ := chartScale(0, float64(max(maxCount, 1)), max(plotHeight/3+1, 2))
+	step = max(math.Round(step), 1)
+	top = math.Ceil(top/step) * step
+	ticks := chartTicks(0, top, step)
+	labels := make([]string, len(ticks))
+	labelWidth := len(strconv.Itoa(int(top)))
+	for index, tick := range ticks {
+		labels[index] = strconv.Itoa(int(tick))
+	}
+	plotX, plotWidth := x+labelWidth+1, width-labelWidth-1
+	eighths := func(value float64) int {
+		return int(math.Round(value / top * float64(plotHeight*8)))
+	}
+	drawChartYAxis(screen, x, y, plotHeight, labelWidth, ticks, labels, func(value float64) int {
+		return plotHeight - (eighths(value)+7)/8
+	}, h.axisStyle, h.labelStyle)
+	screen.SetContent(plotX-1, y+plotHeight, Borders.BottomLeft, nil, h.axisStyle)
+
+	// Draw the horizontal axis with the boundaries of the bins.
+	decimals := max(0, 1-int(math.Floor(math.Log10(bounds[1]-bounds[0])))) // Two significant digits.
+	positions := make([]int, len(bounds))
+	labels = make([]string, len(bounds))
+	for index, bound := range bounds {
+		positions[index] = min(index*plotWidth/len(counts), plotWidth-1)
+		labels[index] = chartFormat(bound, decimals)
+	}
+	drawChartXAxis(screen, plotX, y+plotHeight, plotWidth, positions, labels, h.axisStyle, h.labelStyle)
+
+	// Draw the bars.
+	style := tcell.StyleDefault.Foreground(h.color).Background(h.backgroundColor)
+	for index, count := range counts {
+		left, right := positions[index], positions[index+1]
+		if index == len(counts)-1 {
+			right = plotWidth
+		}
+		if right-left >= 3 {
+			left++ // Leave a gap between bars.
+		}
+		if right > left {
+			drawVerticalBar(screen, plotX+left, y+plotHeight-1, right-left, eighths(float64(count)), style)
+		}
+	}
+}
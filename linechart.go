@@ -0,0 +1,366 @@
+package tview
+
+import (
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// chartSeries is one series of values in a [LineChart].
+type chartSeries struct {
+	name   string
+	color  tcell.Color
+	values []float64
+
+	// The number of values which were discarded because they moved out of the
+	// window.
+	offset int
+}
+
+// LineChart plots one or more series of values as lines, drawn with braille
+// patterns for a resolution of two by four pixels per cell. Each value's
+// position on the horizontal axis is its index in the series, and its position
+// on the vertical axis is the value itself. Values which are NaN are not
+// plotted and cause a gap in the line.
+//
+// The chart shows axes with ticks and labels (see [LineChart.SetShowAxes]) and
+// a legend with the names of the series (see [LineChart.SetShowLegend]). The
+// vertical axis is scaled automatically to the range of the values unless a
+// fixed range is set with [LineChart.SetRange].
+//
+// For streaming data, set a window (see [LineChart.SetWindow]) and add values
+// with [LineChart.AppendValues]. The chart then shows the most recent values
+// only. All functions of the line chart may be called from any goroutine.
+// Changes do not cause an automatic redraw, so call [Application.Draw]
+// afterwards.
+//
+// Each cell can only have one color. Where the lines of several series share a
+// cell, the color of the series added last is used.
+type LineChart struct {
+	sync.Mutex
+	*Box
+
+	// The series, in the order in which they were added.
+	series []*chartSeries
+
+	// The maximum number of values per series, or 0 for no limit.
+	window int
+
+	// The fixed range of the vertical axis. If low >= high, the range is
+	// determined automatically.
+	low, high float64
+
+	// Whether the axes are shown.
+	showAxes bool
+
+	// Whether the legend is shown.
+	showLegend bool
+
+	// An optional function which returns the label of the horizontal axis tick
+	// for the value with the given index.
+	xLabel func(index int) string
+
+	// The style of the axes.
+	axisStyle tcell.Style
+
+	// The style of the axis labels and the legend.
+	labelStyle tcell.Style
+}
+
+// NewLineChart returns a new line chart without any series.
+func NewLineChart() *LineChart {
+	c := &LineChart{
+		Box:        NewBox(),
+		showAxes:   true,
+		showLegend: true,
+		axisStyle:  tcell.StyleDefault.Foreground(Styles.GraphicsColor),
+		labelStyle: tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+	}
+	c.Box.Primitive = c
+	return c
+}
+
+// AddSeries adds a series of values with the given name, drawn in the given
+// color. If the color is [tcell.ColorDefault], a color of the current [Theme]
+// is chosen. The function returns the index of the new series, to be used with
+// [LineChart.AppendValues] and [LineChart.SetValues].
+func (c *LineChart) AddSeries(name string, color tcell.Color, values ...float64) int {
+	c.Lock()
+	defer c.Unlock()
+	series := &chartSeries{
+		name:  name,
+		color: chartColor(color, len(c.series)),
+	}
+	series.values, series.offset = chartAppend(nil, c.window, values...)
+	c.series = append(c.series, series)
+	return len(c.series) - 1
+}
+
+// SetValues replaces the values of the series with the given index. Indices
+// outside the valid range are ignored.
+func (c *LineChart) SetValues(index int, values ...float64) *LineChart {
+	c.Lock()
+	defer c.Unlock()
+	if index < 0 || index >= len(c.series) {
+		return c
+	}
+	series := c.series[index]
+	series.values, series.offset = chartAppend(nil, c.window, values...)
+	return c
+}
+
+// AppendValues adds values to the end of the series with the given index. If
+// a window is set (see [LineChart.SetWindow]), the oldest values are
+// discarded. Indices outside the valid range are ignored.
+func (c *LineChart) AppendValues(index int, values ...float64) *LineChart {
+	c.Lock()
+	defer c.Unlock()
+	if index < 0 || index >= len(c.series) {
+		return c
+	}
+	series := c.series[index]
+	var dropped int
+	series.values, dropped = chartAppend(series.values, c.window, values...)
+	series.offset += dropped
+	return c
+}
+
+// GetValues returns a copy of the values of the series with the given index,
+// or nil if there is no such series.
+func (c *LineChart) GetValues(index int) []float64 {
+	c.Lock()
+	defer c.Unlock()
+	if index < 0 || index >= len(c.series) {
+		return nil
+	}
+	return append([]float64(nil), c.series[index].values...)
+}
+
+// GetSeriesCount returns the number of series in the chart.
+func (c *LineChart) GetSeriesCount() int {
+	c.Lock()
+	defer c.Unlock()
+	return len(c.series)
+}
+
+// ClearSeries removes all series from the chart.
+func (c *LineChart) ClearSeries() *LineChart {
+	c.Lock()
+	defer c.Unlock()
+	c.series = nil
+	return c
+}
+
+// SetWindow sets the maximum number of values kept per series. When more
+// values are added, the oldest values are discarded. The horizontal axis then
+// always spans the given number of values so that the lines move to the left
+// as new values are added. A value of 0 (the default) means there is no limit
+// and the horizontal axis spans the values of the longest series.
+func (c *LineChart) SetWindow(window int) *LineChart {
+	c.Lock()
+	defer c.Unlock()
+	c.window = max(window, 0)
+	for _, series := range c.series {
+		var dropped int
+		series.values, dropped = chartAppend(series.values, c.window)
+		series.offset += dropped
+	}
+	return c
+}
+
+// SetRange sets a fixed range for the vertical axis. Values outside the range
+// are clipped. If low is not smaller than high (e.g. if both are 0, the
+// default), the range is determined from the values.
+func (c *LineChart) SetRange(low, high float64) *LineChart {
+	c.Lock()
+	defer c.Unlock()
+	c.low, c.high = low, high
+	return c
+}
+
+// SetShowAxes sets whether the axes, their ticks, and their labels are shown.
+// They are shown by default.
+func (c *LineChart) SetShowAxes(show bool) *LineChart {
+	c.Lock()
+	defer c.Unlock()
+	c.showAxes = show
+	return c
+}
+
+// SetShowLegend sets whether the names of the series are shown in the top
+// right corner of the chart. The legend is shown by default but only if at
+// least one series has a name.
+func (c *LineChart) SetShowLegend(show bool) *LineChart {
+	c.Lock()
+	defer c.Unlock()
+	c.showLegend = show
+	return c
+}
+
+// SetXLabelFunc sets a function which returns the label of a tick on the
+// horizontal axis. It receives the index of the value at that tick. The index
+// counts all values ever added to the series, including those discarded
+// because they moved out of the window. By default, the index itself is shown.
+func (c *LineChart) SetXLabelFunc(handler func(index int) string) *LineChart {
+	c.Lock()
+	defer c.Unlock()
+	c.xLabel = handler
+	return c
+}
+
+// SetAxisStyle sets the style of the axes.
+func (c *LineChart) SetAxisStyle(style tcell.Style) *LineChart {
+	c.Lock()
+	defer c.Unlock()
+	c.axisStyle = style
+	return c
+}
+
+// SetLabelStyle sets the style of the axis labels and the legend.
+func (c *LineChart) SetLabelStyle(style tcell.Style) *LineChart {
+	c.Lock()
+	defer c.Unlock()
+	c.labelStyle = style
+	return c
+}
+
+// Draw draws this primitive onto the screen.
+func (c *LineChart) Draw(screen tcell.Screen) {
+	c.Box.DrawForSubclass(screen, c)
+
+	c.Lock()
+	defer c.Unlock()
+
+	x, y, width, height := c.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Determine the ranges of both axes.
+	low, high := c.low, c.high
+	count, offset := c.window, 0
+	if low >= high {
+		var found bool
+		for _, series := range c.series {
+			seriesLow, seriesHigh, ok := chartRange(series.values)
+			if !ok {
+				continue
+			}
+			if !found {
+				low, high, found = seriesLow, seriesHigh, true
+			} else {
+				low, high = min(low, seriesLow), max(high, seriesHigh)
+			}
+		}
+		if !found {
+			low, high = 0, 1
+		}
+	}
+	for index, series := range c.series {
+		count = max(count, len(series.values))
+		if index == 0 {
+			offset = series.offset
+		}
+	}
+
+	// Draw the axes.
+	plotX, plotY, plotWidth, plotHeight := x, y, width, height
+	if c.showAxes && width > 4 && height > 3 {
+		plotHeight -= 2
+		autoLow, autoHigh, step, decimals := chartScale(low, high, max(plotHeight/3+1, 2))
+		if c.low >= c.high {
+			low, high = autoLow, autoHigh
+		}
+		ticks := chartTicks(low, high, step)
+		labels := make([]string, len(ticks))
+		var labelWidth int
+		for index, tick := range ticks {
+			labels[index] = chartFormat(tick, decimals)
+			labelWidth = max(labelWidth, len(labels[index]))
+		}
+		labelWidth = min(labelWidth, width/3)
+		plotX += labelWidth + 1
+		plotWidth -= labelWidth + 1
+		drawChartYAxis(screen, x, y, plotHeight, labelWidth, ticks, labels, func(value float64) int {
+			return int(math.Round((high-value)/(high-low)*float64(plotHeight*4-1))) / 4
+		}, c.axisStyle, c.labelStyle)
+		screen.SetContent(plotX-1, y+plotHeight, Borders.BottomLeft, nil, c.axisStyle)
+
+		// Ticks of the horizontal axis, roughly every ten cells.
+		var positions []int
+		labels = labels[:0]
+		if count > 0 {
+			_, _, step, _ = chartScale(0, float64(max(count-1, 1)), max(plotWidth/10, 2))
+			step = max(math.Round(step), 1)
+			for index := 0; index < count; index += int(step) {
+				positions = append(positions, c.pixelX(index, count, plotWidth)/2)
+				if c.xLabel != nil {
+					labels = append(labels, c.xLabel(offset+index))
+				} else {
+					labels = append(labels, strconv.Itoa(offset+index))
+				}
+			}
+		}
+		drawChartXAxis(screen, plotX, y+plotHeight, plotWidth, positions, labels, c.axisStyle, c.labelStyle)
+	}
+	if plotWidth <= 0 || plotHeight <= 0 {
+		return
+	}
+
+	// Plot the series.
+	grid := newBrailleGrid(plotWidth, plotHeight)
+	for _, series := range c.series {
+		previousX, previousY, previous := 0, 0, false
+		for index, value := range series.values {
+			if math.IsNaN(value) {
+				previous = false
+				continue
+			}
+			px := c.pixelX(index, count, plotWidth)
+			py := int(math.Round((high - min(max(value, low), high)) / (high - low) * float64(plotHeight*4-1)))
+			if previous {
+				grid.line(previousX, previousY, px, py, series.color)
+			} else {
+				grid.set(px, py, series.color)
+			}
+			previousX, previousY, previous = px, py, true
+		}
+	}
+	grid.draw(screen, plotX, plotY, tcell.StyleDefault.Background(c.backgroundColor))
+
+	// Draw the legend.
+	if !c.showLegend {
+		return
+	}
+	var legendWidth int
+	for _, series := range c.series {
+		legendWidth = max(legendWidth, TaggedStringWidth(series.name))
+	}
+	if legendWidth == 0 {
+		return
+	}
+	legendWidth = min(legendWidth+2, plotWidth)
+	for index, series := range c.series {
+		if index >= plotHeight {
+			break
+		}
+		legendX := plotX + plotWidth - legendWidth
+		for column := range legendWidth {
+			screen.SetContent(legendX+column, plotY+index, ' ', nil, tcell.StyleDefault.Background(c.backgroundColor))
+		}
+		screen.SetContent(legendX, plotY+index, '■', nil, tcell.StyleDefault.Foreground(series.color).Background(c.backgroundColor))
+		printWithStyle(screen, series.name, legendX+2, plotY+index, 0, legendWidth-2, AlignLeft, c.labelStyle, true)
+	}
+}
+
+// pixelX returns the horizontal pixel coordinate of the value with the given
+// index if the horizontal axis spans "count" values on a plot area of the
+// given width in cells.
+func (c *LineChart) pixelX(index, count, width int) int {
+	if count <= 1 {
+		return 0
+	}
+	return int(math.Round(float64(index) / float64(count-1) * float64(width*2-1)))
+}
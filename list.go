@@ -13,6 +13,7 @@ type listItem struct {
 	SecondaryText string // A secondary text to be shown underneath the main text.
 	Shortcut      rune   // The key to select the list item directly, 0 if there is no shortcut.
 	Selected      func() // The optional function which is called when the item is selected.
+	inSelection   bool   // Whether or not the item is part of the multi-selection.
 }
 
 // List displays rows of items, each of which can be selected. List items can be
@@ -33,6 +34,23 @@ type listItem struct {
 // By default, list item texts can contain style tags. Use
 // [List.SetUseStyleTags] to disable this feature.
 //
+// # Multi-Selection
+//
+// In multi-selection mode (see [List.SetMultiSelect]), any number of items can
+// be selected in addition to the current item. Selected items can optionally
+// be shown with checkboxes (see [List.ShowCheckboxes]). The following
+// additional key binds are available:
+//
+//   - Space: Toggle the selection of the current item.
+//   - Shift + up/down arrow, home, end, page up/down: Move the current item
+//     and select all items between it and the item where the range started.
+//   - Ctrl-A: Select all items or, if all items are selected, none.
+//
+// Clicking on an item's checkbox or Ctrl-clicking an item toggles its
+// selection. Shift-clicking an item selects a range. Use
+// [List.GetSelectedItems] to retrieve the selected items and
+// [List.SetSelectionChangedFunc] to be notified of changes to the selection.
+//
 // See [List.SetChangedFunc] for a way to be notified when the user navigates
 // to a list item. See [List.SetSelectedFunc] for a way to be notified when a
 // list item was selected. Note that the first item is selected by default but
@@ -49,6 +67,22 @@ type List struct {
 	// The index of the currently selected item.
 	currentItem int
 
+	// Whether or not multiple items can be selected.
+	multiSelect bool
+
+	// The index of the item where the current range selection started.
+	rangeAnchor int
+
+	// The selection state of all items when the current range selection
+	// started, or nil if it has not started yet.
+	rangeBase []bool
+
+	// Whether or not to show checkboxes in multi-selection mode.
+	showCheckboxes bool
+
+	// The strings used to display checkboxes of selected and unselected items.
+	checkedString, uncheckedString string
+
 	// Whether or not to show the secondary item texts.
 	showSecondaryText bool
 
@@ -64,6 +98,9 @@ type List struct {
 	// The style for selected items.
 	selectedStyle tcell.Style
 
+	// The style for the main text of items in the multi-selection.
+	multiSelectedStyle tcell.Style
+
 	// If true, the selection is only shown when the list has focus.
 	selectedFocusOnly bool
 
@@ -87,6 +124,9 @@ type List struct {
 	// are not affected.
 	horizontalOffset int
 
+	// The vertical and horizontal scroll bars.
+	scrollBars scrollBars
+
 	// An optional function which is called when the user has navigated to a
 	// list item.
 	changed func(index int, mainText, secondaryText string, shortcut rune)
@@ -97,6 +137,9 @@ type List struct {
 
 	// An optional function which is called when the user presses the Escape key.
 	done func()
+
+	// An optional function which is called when the multi-selection changes.
+	selectionChanged func(indices []int)
 }
 
 // NewList returns a new [List].
@@ -109,8 +152,12 @@ func NewList() *List {
 		secondaryTextStyle: tcell.StyleDefault.Foreground(Styles.TertiaryTextColor).Background(Styles.PrimitiveBackgroundColor),
 		shortcutStyle:      tcell.StyleDefault.Foreground(Styles.SecondaryTextColor).Background(Styles.PrimitiveBackgroundColor),
 		selectedStyle:      tcell.StyleDefault.Foreground(Styles.PrimitiveBackgroundColor).Background(Styles.PrimaryTextColor),
+		multiSelectedStyle: tcell.StyleDefault.Foreground(Styles.SecondaryTextColor).Background(Styles.PrimitiveBackgroundColor),
+		checkedString:      "☑",
+		uncheckedString:    "☐",
 		mainStyleTags:      true,
 		secondaryStyleTags: true,
+		scrollBars:         newScrollBars(),
 	}
 	l.Box.Primitive = l
 	return l
@@ -195,7 +242,12 @@ func (l *List) RemoveItem(index int) *List {
 	}
 
 	// Remove item.
+	inSelection := l.items[index].inSelection
 	l.items = append(l.items[:index], l.items[index+1:]...)
+	l.rangeBase = nil
+	if inSelection {
+		l.fireSelectionChanged()
+	}
 
 	// If there is nothing left, we're done.
 	if len(l.items) == 0 {
@@ -318,6 +370,30 @@ func (l *List) ShowSecondaryText(show bool) *List {
 	return l
 }
 
+// SetScrollBarVisibility sets when the vertical and the horizontal scroll bars
+// are shown (see [ScrollBarNever], [ScrollBarAuto], and [ScrollBarAlways]).
+// Note that in order to show the horizontal scroll bar, the list needs to
+// determine the width of all item texts.
+//
+// The thumb of a scroll bar can be dragged with the mouse.
+func (l *List) SetScrollBarVisibility(vertical, horizontal ScrollBarVisibility) *List {
+	l.scrollBars.setVisibility(vertical, horizontal)
+	return l
+}
+
+// SetScrollBarRunes sets the runes used to draw the scroll bars' tracks and
+// thumbs.
+func (l *List) SetScrollBarRunes(track, thumb rune) *List {
+	l.scrollBars.setRunes(track, thumb)
+	return l
+}
+
+// SetScrollBarStyles sets the styles of the scroll bars' tracks and thumbs.
+func (l *List) SetScrollBarStyles(track, thumb tcell.Style) *List {
+	l.scrollBars.setStyles(track, thumb)
+	return l
+}
+
 // SetWrapAround sets the flag that determines whether navigating the list will
 // wrap around. That is, navigating downwards on the last item will move the
 // selection to the first item (similarly in the other direction). If set to
@@ -413,6 +489,7 @@ func (l *List) InsertItem(index int, mainText, secondaryText string, shortcut ru
 	}
 
 	// Insert item (make space for the new item, then shift and insert).
+	l.rangeBase = nil
 	l.items = append(l.items, nil)
 	if index < len(l.items)-1 { // -1 because l.items has already grown by one item.
 		copy(l.items[index+1:], l.items[index:])
@@ -497,16 +574,175 @@ func (l *List) FindItems(mainSearch, secondarySearch string, mustContainBoth, ig
 
 // Clear removes all items from the list.
 func (l *List) Clear() *List {
+	inSelection := len(l.GetSelectedItems()) > 0
 	l.items = nil
 	l.currentItem = 0
+	l.rangeAnchor = 0
+	l.rangeBase = nil
+	if inSelection {
+		l.fireSelectionChanged()
+	}
+	return l
+}
+
+// SetMultiSelect sets whether or not multiple items can be selected. See the
+// [List] documentation for details. When multi-selection is switched off, all
+// items are deselected.
+func (l *List) SetMultiSelect(multiSelect bool) *List {
+	l.multiSelect = multiSelect
+	l.rangeAnchor = l.currentItem
+	l.rangeBase = nil
+	if !multiSelect {
+		l.SelectNone()
+	}
+	return l
+}
+
+// ShowCheckboxes sets whether or not checkboxes are shown in front of the
+// items in multi-selection mode (see [List.SetMultiSelect]).
+func (l *List) ShowCheckboxes(show bool) *List {
+	l.showCheckboxes = show
+	return l
+}
+
+// SetCheckboxStrings sets the strings used to display the checkboxes of
+// selected and unselected items (defaults to "☑" and "☐"). The strings may
+// contain style tags and they should have the same width. See [Escape] in case
+// you want to display square brackets.
+func (l *List) SetCheckboxStrings(checked, unchecked string) *List {
+	l.checkedString = checked
+	l.uncheckedString = unchecked
+	return l
+}
+
+// SetMultiSelectedStyle sets the style of the main text of the items which are
+// selected in multi-selection mode. The style of the current item (see
+// [List.SetSelectedStyle]) takes precedence over this style.
+func (l *List) SetMultiSelectedStyle(style tcell.Style) *List {
+	l.multiSelectedStyle = style
 	return l
 }
 
+// SetItemSelected sets whether or not the item with the given index is part of
+// the multi-selection. This triggers a "selection changed" event if the
+// selection changes. Panics if the index is out of range.
+func (l *List) SetItemSelected(index int, selected bool) *List {
+	if l.items[index].inSelection != selected {
+		l.items[index].inSelection = selected
+		l.fireSelectionChanged()
+	}
+	return l
+}
+
+// IsItemSelected returns whether or not the item with the given index is part
+// of the multi-selection. Panics if the index is out of range.
+func (l *List) IsItemSelected(index int) bool {
+	return l.items[index].inSelection
+}
+
+// SelectAll adds all items to the multi-selection. This triggers a "selection
+// changed" event if the selection changes.
+func (l *List) SelectAll() *List {
+	l.setSelection(func(index int, inSelection bool) bool {
+		return true
+	})
+	return l
+}
+
+// SelectNone removes all items from the multi-selection. This triggers a
+// "selection changed" event if the selection changes.
+func (l *List) SelectNone() *List {
+	l.setSelection(func(index int, inSelection bool) bool {
+		return false
+	})
+	return l
+}
+
+// GetSelectedItems returns the indices of the items which are part of the
+// multi-selection, in ascending order.
+func (l *List) GetSelectedItems() (indices []int) {
+	for index, item := range l.items {
+		if item.inSelection {
+			indices = append(indices, index)
+		}
+	}
+	return
+}
+
+// SetSelectionChangedFunc sets a handler which is called when the
+// multi-selection changes (see [List.SetMultiSelect]). It receives the indices
+// of the selected items, in ascending order.
+func (l *List) SetSelectionChangedFunc(handler func(indices []int)) *List {
+	l.selectionChanged = handler
+	return l
+}
+
+// setSelection sets the selection state of all items to the value returned
+// by the given function (which receives the item's index and current state)
+// and triggers a "selection changed" event if the selection changed.
+func (l *List) setSelection(selected func(index int, inSelection bool) bool) {
+	var changed bool
+	for index, item := range l.items {
+		if inSelection := selected(index, item.inSelection); inSelection != item.inSelection {
+			item.inSelection = inSelection
+			changed = true
+		}
+	}
+	if changed {
+		l.fireSelectionChanged()
+	}
+}
+
+// selectRange selects all items between the range anchor and the current
+// item, in addition to the items which were selected when the range selection
+// started.
+func (l *List) selectRange() {
+	if l.rangeBase == nil {
+		l.rangeBase = make([]bool, len(l.items))
+		for index, item := range l.items {
+			l.rangeBase[index] = item.inSelection
+		}
+	}
+	from, to := min(l.rangeAnchor, l.currentItem), max(l.rangeAnchor, l.currentItem)
+	l.setSelection(func(index int, inSelection bool) bool {
+		return index >= from && index <= to || index < len(l.rangeBase) && l.rangeBase[index]
+	})
+}
+
+// fireSelectionChanged calls the "selection changed" handler, if one is set.
+func (l *List) fireSelectionChanged() {
+	if l.selectionChanged != nil {
+		l.selectionChanged(l.GetSelectedItems())
+	}
+}
+
+// checkboxWidth returns the screen width of the checkboxes including the
+// space after them, or 0 if no checkboxes are shown.
+func (l *List) checkboxWidth() int {
+	if !l.multiSelect || !l.showCheckboxes {
+		return 0
+	}
+	return max(TaggedStringWidth(l.checkedString), TaggedStringWidth(l.uncheckedString)) + 1
+}
+
+// hasShortcuts returns whether or not any item has a shortcut.
+func (l *List) hasShortcuts() bool {
+	for _, item := range l.items {
+		if item.Shortcut != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // adjustOffset adjusts the vertical offset to keep the current selection in
 // view.
 func (l *List) adjustOffset() {
 	_, _, _, height := l.GetInnerRect()
-	if height == 0 {
+	if l.scrollBars.horizontal.shown {
+		height--
+	}
+	if height <= 0 {
 		return
 	}
 	if l.currentItem < l.itemOffset {
@@ -531,6 +767,36 @@ func (l *List) Draw(screen tcell.Screen) {
 	if height == 0 {
 		return
 	}
+
+	// Do we show any shortcuts or checkboxes?
+	showShortcuts := l.hasShortcuts()
+	checkboxWidth := l.checkboxWidth()
+
+	// Make room for the scroll bars.
+	l.scrollBars.hide()
+	rowsPerItem := 1
+	if l.showSecondaryText {
+		rowsPerItem = 2
+	}
+	verticalBar := width > 1 && l.scrollBars.vertical.needed(rowsPerItem*len(l.items), height)
+	if verticalBar {
+		width--
+	}
+	var horizontalBar bool
+	var contentWidth int
+	if l.scrollBars.horizontal.visibility != ScrollBarNever && height > 1 {
+		contentWidth = l.maxItemWidth()
+		textWidth := width - checkboxWidth
+		if showShortcuts {
+			textWidth -= 4
+		}
+		if l.scrollBars.horizontal.needed(contentWidth, textWidth) {
+			horizontalBar = true
+			height--
+		}
+	}
+	barX, barY := x+width, y+height
+
 	bottomLimit := y + height
 	_, totalHeight := screen.Size()
 	if bottomLimit > totalHeight {
@@ -557,16 +823,13 @@ func (l *List) Draw(screen tcell.Screen) {
 		l.itemOffset = 0
 	}
 
-	// Do we show any shortcuts?
-	var showShortcuts bool
-	for _, item := range l.items {
-		if item.Shortcut != 0 {
-			showShortcuts = true
-			x += 4
-			width -= 4
-			break
-		}
+	// Make room for the shortcuts and the checkboxes.
+	if showShortcuts {
+		x += 4
+		width -= 4
 	}
+	x += checkboxWidth
+	width -= checkboxWidth
 
 	// Draw the list items.
 	var maxWidth int // The maximum printed item width.
@@ -584,9 +847,21 @@ func (l *List) Draw(screen tcell.Screen) {
 			printWithStyle(screen, fmt.Sprintf("(%s)", string(item.Shortcut)), x-5, y, 0, 4, AlignRight, l.shortcutStyle, false)
 		}
 
+		// Checkboxes.
+		if checkboxWidth > 0 {
+			checkbox := l.uncheckedString
+			if item.inSelection {
+				checkbox = l.checkedString
+			}
+			printWithStyle(screen, checkbox, x-checkboxWidth, y, 0, checkboxWidth-1, AlignLeft, l.mainTextStyle, false)
+		}
+
 		// Main text.
 		selected := index == l.currentItem && (!l.selectedFocusOnly || l.HasFocus())
 		style := l.mainTextStyle
+		if l.multiSelect && item.inSelection {
+			style = l.multiSelectedStyle
+		}
 		if selected {
 			style = l.selectedStyle
 		}
@@ -625,6 +900,14 @@ func (l *List) Draw(screen tcell.Screen) {
 		}
 	}
 
+	// Draw the scroll bars.
+	if verticalBar {
+		l.scrollBars.vertical.draw(screen, barX, barY-height, height, rowsPerItem*len(l.items), height, rowsPerItem*l.itemOffset)
+	}
+	if horizontalBar {
+		l.scrollBars.horizontal.draw(screen, x, barY, width, contentWidth, width, l.horizontalOffset)
+	}
+
 	// We don't want the item text to get out of view. If the horizontal offset
 	// is too high, we reset it and redraw. (That should be about as efficient
 	// as calculating everything up front.)
@@ -648,6 +931,23 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 
 		previousItem := l.currentItem
 
+		// Keys specific to multi-selection.
+		if l.multiSelect {
+			switch key := event.Key(); {
+			case key == tcell.KeyRune && event.Rune() == ' ':
+				l.rangeAnchor, l.rangeBase = l.currentItem, nil
+				l.SetItemSelected(l.currentItem, !l.items[l.currentItem].inSelection)
+				return
+			case key == tcell.KeyCtrlA:
+				if len(l.GetSelectedItems()) == len(l.items) {
+					l.SelectNone()
+				} else {
+					l.SelectAll()
+				}
+				return
+			}
+		}
+
 		switch key := event.Key(); key {
 		case tcell.KeyTab, tcell.KeyDown:
 			l.currentItem++
@@ -729,10 +1029,43 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 				l.changed(l.currentItem, item.MainText, item.SecondaryText, item.Shortcut)
 			}
 			l.adjustOffset()
+
+			// Select ranges with the Shift key.
+			if l.multiSelect {
+				switch event.Key() {
+				case tcell.KeyUp, tcell.KeyDown, tcell.KeyHome, tcell.KeyEnd, tcell.KeyPgUp, tcell.KeyPgDn:
+					if event.Modifiers()&tcell.ModShift != 0 {
+						l.selectRange()
+						break
+					}
+					fallthrough
+				default:
+					l.rangeAnchor, l.rangeBase = l.currentItem, nil
+				}
+			}
 		}
 	})
 }
 
+// maxItemWidth returns the width of the widest main or secondary item text.
+func (l *List) maxItemWidth() (width int) {
+	for _, item := range l.items {
+		mainText := item.MainText
+		if !l.mainStyleTags {
+			mainText = Escape(mainText)
+		}
+		width = max(width, TaggedStringWidth(mainText))
+		if l.showSecondaryText {
+			secondaryText := item.SecondaryText
+			if !l.secondaryStyleTags {
+				secondaryText = Escape(secondaryText)
+			}
+			width = max(width, TaggedStringWidth(secondaryText))
+		}
+	}
+	return
+}
+
 // indexAtPoint returns the index of the list item found at the given position
 // or a negative value if there is no such list item.
 func (l *List) indexAtPoint(x, y int) int {
@@ -753,9 +1086,58 @@ func (l *List) indexAtPoint(x, y int) int {
 	return index
 }
 
+// multiSelectClick handles a click on the item with the given index in
+// multi-selection mode: Clicks on checkboxes and Ctrl-clicks toggle the item's
+// selection, Shift-clicks select a range. It returns false if the click is
+// not one of these and should be handled like a regular click.
+func (l *List) multiSelectClick(index int, event *tcell.EventMouse) bool {
+	x, _ := event.Position()
+	rectX, _, _, _ := l.GetInnerRect()
+	if l.hasShortcuts() {
+		rectX += 4
+	}
+	onCheckbox := x >= rectX && x < rectX+l.checkboxWidth()-1
+	modifiers := event.Modifiers()
+	if !onCheckbox && modifiers&(tcell.ModCtrl|tcell.ModShift) == 0 {
+		return false
+	}
+
+	// Move to the item.
+	if index != l.currentItem {
+		l.currentItem = index
+		if l.changed != nil {
+			item := l.items[index]
+			l.changed(index, item.MainText, item.SecondaryText, item.Shortcut)
+		}
+	}
+
+	// Change the selection.
+	if modifiers&tcell.ModShift != 0 && !onCheckbox {
+		l.selectRange()
+	} else {
+		l.rangeAnchor, l.rangeBase = index, nil
+		l.SetItemSelected(index, !l.items[index].inSelection)
+	}
+	return true
+}
+
 // MouseHandler returns the mouse handler for this primitive.
 func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return l.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		// Scroll bars.
+		if consumed, capture := l.scrollBars.mouseHandler(l, action, event, setFocus, func(vertical bool, offset int) {
+			if vertical {
+				l.itemOffset = offset / 2
+				if !l.showSecondaryText {
+					l.itemOffset = offset
+				}
+			} else {
+				l.horizontalOffset = offset
+			}
+		}); consumed {
+			return consumed, capture
+		}
+
 		if !l.InRect(event.Position()) {
 			return false, nil
 		}
@@ -765,6 +1147,10 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 		case MouseLeftClick:
 			setFocus(l)
 			index := l.indexAtPoint(event.Position())
+			if index != -1 && l.multiSelect && l.multiSelectClick(index, event) {
+				consumed = true
+				break
+			}
 			if index != -1 {
 				item := l.items[index]
 				if item.Selected != nil {
@@ -779,6 +1165,7 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 					}
 				}
 				l.currentItem = index
+				l.rangeAnchor, l.rangeBase = index, nil
 			}
 			consumed = true
 		case MouseScrollUp:
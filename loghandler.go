@@ -0,0 +1,238 @@
+package tview
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// LogHandler is a [slog.Handler] which shows log records in a [TextView] or a
+// [LogView]. This allows an application to route its log output to the screen
+// instead of writing it to the terminal, which would corrupt the display.
+// Example:
+//
+//	textView := tview.NewTextView().
+//		SetMaxLines(1000).
+//		SetChangedFunc(func() { app.Draw() })
+//	logger := slog.New(tview.NewTextViewHandler(textView, nil))
+//	logger.Info("Connected", "host", "example.com", "port", 443)
+//
+// In a text view, each record is written as one line consisting of the time,
+// the level, the source (if [slog.HandlerOptions.AddSource] is set), the
+// message, and the attributes as key/value pairs, styled with style tags. Lines
+// are added with [TextView.Write] which may be called from any goroutine. Any
+// limit set with [TextView.SetMaxLines] applies as usual.
+//
+// In a log view, each record is added as a [LogEntry], with the record's
+// attributes as the entry's fields. The log view determines how entries are
+// formatted and filtered.
+//
+// Neither text views nor log views redraw automatically when a record is
+// added. Call [Application.Draw] from the text view's "changed" function (see
+// [TextView.SetChangedFunc]) or redraw periodically.
+//
+// The handler honors the level and the AddSource flag of the
+// [slog.HandlerOptions]. The ReplaceAttr function is called for the attributes
+// of records but not for the built-in time, level, message, and source fields.
+type LogHandler struct {
+	// The text view which receives the records, if any.
+	textView *TextView
+
+	// The log view which receives the records, if any.
+	logView *LogView
+
+	// The handler options.
+	options slog.HandlerOptions
+
+	// How records are formatted for the text view. This is shared with all
+	// handlers derived from this handler.
+	format *logFormat
+
+	// The attributes added with WithAttrs, already wrapped in their groups.
+	attrs []slog.Attr
+
+	// The groups opened with WithGroup.
+	groups []string
+}
+
+// NewTextViewHandler returns a new log handler which writes records to the
+// given text view. The options may be nil, in which case records of level
+// [slog.LevelInfo] and above are shown. Style tags are switched on for the text
+// view (see [TextView.SetDynamicColors]).
+func NewTextViewHandler(textView *TextView, options *slog.HandlerOptions) *LogHandler {
+	textView.Lock()
+	textView.SetDynamicColors(true)
+	textView.Unlock()
+	format := newLogFormat()
+	h := &LogHandler{
+		textView: textView,
+		format:   &format,
+	}
+	if options != nil {
+		h.options = *options
+	}
+	return h
+}
+
+// NewLogViewHandler returns a new log handler which adds records to the given
+// log view. The options may be nil, in which case records of level
+// [slog.LevelInfo] and above are added.
+func NewLogViewHandler(logView *LogView, options *slog.HandlerOptions) *LogHandler {
+	format := newLogFormat()
+	h := &LogHandler{
+		logView: logView,
+		format:  &format,
+	}
+	if options != nil {
+		h.options = *options
+	}
+	return h
+}
+
+// SetTimeFormat sets the layout used to format the time of records written to
+// a text view. See [time.Time.Format] for details. If the layout is empty,
+// times are not shown. The default is "15:04:05.000".
+//
+// This function only affects handlers writing to a text view and it must be
+// called before the handler is used.
+func (h *LogHandler) SetTimeFormat(layout string) *LogHandler {
+	h.format.timeFormat = layout
+	return h
+}
+
+// SetLevelStyle sets the style of the level name of records written to a text
+// view for the given level and all levels above it up to the next level which
+// has its own style.
+//
+// This function only affects handlers writing to a text view and it must be
+// called before the handler is used.
+func (h *LogHandler) SetLevelStyle(level slog.Level, style tcell.Style) *LogHandler {
+	h.format.levelStyles[level] = style
+	return h
+}
+
+// SetMessageStyle sets the style of the message of records written to a text
+// view.
+//
+// This function only affects handlers writing to a text view and it must be
+// called before the handler is used.
+func (h *LogHandler) SetMessageStyle(style tcell.Style) *LogHandler {
+	h.format.messageStyle = style
+	return h
+}
+
+// SetFieldStyles sets the styles of the keys and the values of the attributes
+// of records written to a text view.
+//
+// This function only affects handlers writing to a text view and it must be
+// called before the handler is used.
+func (h *LogHandler) SetFieldStyles(key, value tcell.Style) *LogHandler {
+	h.format.keyStyle = key
+	h.format.valueStyle = value
+	return h
+}
+
+// Enabled implements [slog.Handler]. It reports whether records of the given
+// level are handled.
+func (h *LogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.options.Level != nil {
+		minLevel = h.options.Level.Level()
+	}
+	return level >= minLevel
+}
+
+// Handle implements [slog.Handler]. It adds the given record to the text view
+// or the log view.
+func (h *LogHandler) Handle(ctx context.Context, record slog.Record) error {
+	entry := LogEntry{
+		Time:    record.Time,
+		Level:   record.Level,
+		Message: record.Message,
+		Fields:  slices.Clip(h.attrs),
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	if h.options.AddSource && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		if frame.File != "" {
+			entry.Source = filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+		}
+	}
+	if record.NumAttrs() > 0 {
+		attrs := make([]slog.Attr, 0, record.NumAttrs())
+		record.Attrs(func(attr slog.Attr) bool {
+			attrs = append(attrs, attr)
+			return true
+		})
+		entry.Fields = append(entry.Fields, h.wrap(h.replace(h.groups, attrs))...)
+	}
+
+	if h.logView != nil {
+		h.logView.AddEntry(entry)
+		return nil
+	}
+	_, err := h.textView.Write([]byte(h.format.format(&entry) + "\n"))
+	return err
+}
+
+// WithAttrs implements [slog.Handler]. It returns a new handler whose records
+// include the given attributes.
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	handler := *h
+	handler.attrs = append(slices.Clip(h.attrs), h.wrap(h.replace(h.groups, attrs))...)
+	return &handler
+}
+
+// WithGroup implements [slog.Handler]. It returns a new handler which places
+// all subsequent attributes in a group with the given name.
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	handler := *h
+	handler.groups = append(slices.Clip(h.groups), name)
+	return &handler
+}
+
+// replace resolves the given attributes and applies the ReplaceAttr function
+// of the handler options to them, if one was provided. Attributes replaced with
+// an empty attribute are removed.
+func (h *LogHandler) replace(groups []string, attrs []slog.Attr) []slog.Attr {
+	result := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		attr.Value = attr.Value.Resolve()
+		if attr.Value.Kind() == slog.KindGroup {
+			groupNames := groups
+			if attr.Key != "" {
+				groupNames = append(slices.Clip(groups), attr.Key)
+			}
+			attr.Value = slog.GroupValue(h.replace(groupNames, attr.Value.Group())...)
+		} else if h.options.ReplaceAttr != nil {
+			attr = h.options.ReplaceAttr(groups, attr)
+			attr.Value = attr.Value.Resolve()
+		}
+		if !attr.Equal(slog.Attr{}) {
+			result = append(result, attr)
+		}
+	}
+	return result
+}
+
+// wrap places the given attributes in the groups opened with WithGroup.
+func (h *LogHandler) wrap(attrs []slog.Attr) []slog.Attr {
+	for index := len(h.groups) - 1; index >= 0 && len(attrs) > 0; index-- {
+		attrs = []slog.Attr{{Key: h.groups[index], Value: slog.GroupValue(attrs...)}}
+	}
+	return attrs
+}
@@ -0,0 +1,778 @@
+package tview
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// LogEntry is one entry of a [LogView].
+type LogEntry struct {
+	// The time of the entry. If it is the zero time when the entry is added,
+	// the current time is used.
+	Time time.Time
+
+	// The severity of the entry.
+	Level slog.Level
+
+	// The component which created the entry. May be empty.
+	Source string
+
+	// The log message.
+	Message string
+
+	// Additional key/value pairs. Groups are shown with their keys prefixed by
+	// the group name.
+	Fields []slog.Attr
+}
+
+// logRecord is a [LogEntry] stored in a [LogView].
+type logRecord struct {
+	LogEntry
+
+	// The sequence number of this entry. Entries are numbered from 1 in the
+	// order in which they were added.
+	seq uint64
+
+	// The text searched by the text filters: source, message, and fields. The
+	// lower case version is used for the substring filter.
+	text, lowerText string
+}
+
+// LogView is a scrollable view of log entries. Each [LogEntry] consists of a
+// time, a level, a source, a message, and any number of fields. Entries are
+// kept in a buffer of limited size (see [LogView.SetMaxEntries]), dropping the
+// oldest entries when it is full.
+//
+// Entries may be filtered by level (see [LogView.SetLevel]), by text (see
+// [LogView.SetFilter] and [LogView.SetFilterRegexp]), or by any other criteria
+// (see [LogView.SetFilterFunc]). Filters only affect what is shown. Entries
+// which are hidden by a filter are shown again when the filter is removed.
+//
+// By default, the log view follows new entries, i.e. it always shows the most
+// recent entries. When the user scrolls up, the view stays at its position
+// until the user scrolls back to the end (or presses the End key). See also
+// [LogView.SetFollow].
+//
+// Entries may be added from any goroutine, using [LogView.Log] or
+// [LogView.AddEntry]. A log view also implements the [io.Writer] interface,
+// adding each line written to it as an entry of level [slog.LevelInfo]. As with
+// [TextView], changes do not cause an automatic redraw, so call
+// [Application.Draw] afterwards or redraw periodically.
+//
+// The following keys are supported to navigate the log:
+//
+//   - j, down arrow: Move down one line.
+//   - k, up arrow: Move up one line.
+//   - h, left arrow: Move left.
+//   - l, right arrow: Move right.
+//   - g, home: Move to the first entry.
+//   - G, end: Move to the last entry and follow new entries.
+//   - Ctrl-F, page down: Move down by one page.
+//   - Ctrl-B, page up: Move up by one page.
+type LogView struct {
+	sync.Mutex
+	*Box
+
+	// The ring buffer of entries. Once it is full, start is the index of the
+	// oldest entry.
+	entries []logRecord
+
+	// The index of the oldest entry in the ring buffer.
+	start int
+
+	// The maximum number of entries kept.
+	maxEntries int
+
+	// The sequence number of the last entry added.
+	seq uint64
+
+	// The unterminated last line written with [LogView.Write], added as an
+	// entry when its newline arrives.
+	partial []byte
+
+	// The minimum level of entries shown.
+	level slog.Level
+
+	// An optional substring which shown entries must contain (in lower case).
+	filter string
+
+	// An optional regular expression which shown entries must match.
+	filterRegexp *regexp.Regexp
+
+	// An optional function which returns whether an entry is shown.
+	filterFunc func(entry *LogEntry) bool
+
+	// How entries are formatted.
+	logFormat
+
+	// Whether the view follows new entries.
+	follow bool
+
+	// If not following, the sequence number of the first visible entry.
+	topSeq uint64
+
+	// The number of lines to scroll by during the next draw call.
+	scrollDelta int
+
+	// If not negative, the line to scroll to during the next draw call.
+	scrollTo int
+
+	// The number of cells the text is scrolled to the left.
+	columnOffset int
+
+	// The height of the view during the last draw call.
+	pageSize int
+
+	// Optional scroll bars.
+	scrollBars scrollBars
+
+	// An optional function which is called when the user is done, i.e. when
+	// they press Escape, Enter, Tab, or Backtab.
+	done func(key tcell.Key)
+}
+
+// NewLogView returns a new, empty log view which keeps up to 10,000 entries.
+func NewLogView() *LogView {
+	l := &LogView{
+		Box:        NewBox(),
+		maxEntries: 10000,
+		level:      slog.LevelDebug,
+		logFormat:  newLogFormat(),
+		follow:     true,
+		scrollTo:   -1,
+		scrollBars: newScrollBars(),
+	}
+	l.Box.Primitive = l
+	return l
+}
+
+// AddEntry adds an entry to the log. If the log is full, the oldest entry is
+// dropped. This function may be called from any goroutine.
+func (l *LogView) AddEntry(entry LogEntry) *LogView {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	text := logEntryText(&entry)
+	record := logRecord{LogEntry: entry, text: text, lowerText: strings.ToLower(text)}
+
+	l.Lock()
+	defer l.Unlock()
+	l.seq++
+	record.seq = l.seq
+	if len(l.entries) < l.maxEntries {
+		l.entries = append(l.entries, record)
+	} else {
+		l.entries[l.start] = record
+		l.start = (l.start + 1) % len(l.entries)
+	}
+	return l
+}
+
+// Log adds an entry with the current time and the given level, source,
+// message, and fields to the log. This function may be called from any
+// goroutine.
+func (l *LogView) Log(level slog.Level, source, message string, fields ...slog.Attr) *LogView {
+	return l.AddEntry(LogEntry{
+		Level:   level,
+		Source:  source,
+		Message: message,
+		Fields:  fields,
+	})
+}
+
+// Write lets us implement the io.Writer interface. Each line written is added
+// as an entry of level [slog.LevelInfo]. A line which is not terminated by a
+// newline is kept until the newline is written. Empty lines are ignored.
+func (l *LogView) Write(p []byte) (n int, err error) {
+	l.Lock()
+	text := append(l.partial, p...)
+	index := bytes.LastIndexByte(text, '\n')
+	if index < 0 {
+		l.partial = text
+		l.Unlock()
+		return len(p), nil
+	}
+	l.partial = bytes.Clone(text[index+1:])
+	l.Unlock()
+
+	for line := range bytes.SplitSeq(text[:index], []byte("\n")) {
+		line = bytes.TrimRight(line, "\r")
+		if len(line) > 0 {
+			l.Log(slog.LevelInfo, "", string(line))
+		}
+	}
+	return len(p), nil
+}
+
+// Clear removes all entries from the log.
+func (l *LogView) Clear() *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.entries = nil
+	l.start = 0
+	l.follow = true
+	return l
+}
+
+// GetEntryCount returns the number of entries in the log, including those
+// hidden by filters.
+func (l *LogView) GetEntryCount() int {
+	l.Lock()
+	defer l.Unlock()
+	return len(l.entries)
+}
+
+// GetEntries returns a copy of the entries in the log, from oldest to newest.
+// If "filtered" is true, only the entries which pass the current filters are
+// returned.
+func (l *LogView) GetEntries(filtered bool) []LogEntry {
+	l.Lock()
+	defer l.Unlock()
+	var entries []LogEntry
+	for index := range l.entries {
+		record := &l.entries[(l.start+index)%len(l.entries)]
+		if !filtered || l.shown(record) {
+			entries = append(entries, record.LogEntry)
+		}
+	}
+	return entries
+}
+
+// SetMaxEntries sets the maximum number of entries kept in the log. When more
+// entries are added, the oldest entries are dropped. The default is 10,000.
+func (l *LogView) SetMaxEntries(maxEntries int) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	maxEntries = max(maxEntries, 1)
+	var entries []logRecord
+	for index := max(len(l.entries)-maxEntries, 0); index < len(l.entries); index++ {
+		entries = append(entries, l.entries[(l.start+index)%len(l.entries)])
+	}
+	l.entries, l.start, l.maxEntries = entries, 0, maxEntries
+	return l
+}
+
+// SetLevel sets the minimum level of the entries shown. The default is
+// [slog.LevelDebug].
+func (l *LogView) SetLevel(level slog.Level) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.level = level
+	return l
+}
+
+// GetLevel returns the minimum level of the entries shown.
+func (l *LogView) GetLevel() slog.Level {
+	l.Lock()
+	defer l.Unlock()
+	return l.level
+}
+
+// SetFilter causes only entries to be shown whose source, message, or fields
+// contain the given text, ignoring case. An empty string removes the filter.
+func (l *LogView) SetFilter(text string) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.filter = strings.ToLower(text)
+	return l
+}
+
+// SetFilterRegexp causes only entries to be shown whose source, message, or
+// fields match the given regular expression. The text matched against
+// consists of the source, the message, and the fields in "key=value" format,
+// separated by spaces. A nil value removes the filter.
+func (l *LogView) SetFilterRegexp(re *regexp.Regexp) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.filterRegexp = re
+	return l
+}
+
+// SetFilterFunc sets a function which is called for each entry and which
+// returns whether the entry is shown. It is applied in addition to the other
+// filters. A nil value removes the filter. The function is called while the
+// log view is locked so it must not call any of the log view's functions.
+func (l *LogView) SetFilterFunc(handler func(entry *LogEntry) bool) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.filterFunc = handler
+	return l
+}
+
+// shown returns whether the given record passes all filters.
+func (l *LogView) shown(record *logRecord) bool {
+	if record.Level < l.level {
+		return false
+	}
+	if l.filter != "" && !strings.Contains(record.lowerText, l.filter) {
+		return false
+	}
+	if l.filterRegexp != nil && !l.filterRegexp.MatchString(record.text) {
+		return false
+	}
+	if l.filterFunc != nil && !l.filterFunc(&record.LogEntry) {
+		return false
+	}
+	return true
+}
+
+// SetTimeFormat sets the layout used to format the times of entries (see
+// [time.Time.Format]). The default is "15:04:05.000". An empty string hides
+// the times.
+func (l *LogView) SetTimeFormat(layout string) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.timeFormat = layout
+	l.timeFormatFunc = nil
+	return l
+}
+
+// SetTimeFormatFunc sets a function which formats the times of entries, for
+// example to show them relative to the start of the application or in a
+// different time zone. It overrides [LogView.SetTimeFormat]. A nil value
+// reverts to the layout set with [LogView.SetTimeFormat].
+func (l *LogView) SetTimeFormatFunc(handler func(t time.Time) string) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.timeFormatFunc = handler
+	return l
+}
+
+// SetShowSource sets whether the sources of entries are shown. They are shown
+// by default.
+func (l *LogView) SetShowSource(show bool) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.showSource = show
+	return l
+}
+
+// SetShowFields sets whether the fields of entries are shown. They are shown
+// by default.
+func (l *LogView) SetShowFields(show bool) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.showFields = show
+	return l
+}
+
+// SetFollow sets whether the log view follows new entries, i.e. always shows
+// the most recent entries. If set to true, the view scrolls to the end. It is
+// set to false automatically when the user scrolls up and to true when the
+// user scrolls to the end.
+func (l *LogView) SetFollow(follow bool) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.follow = follow
+	if !follow && l.topSeq == 0 {
+		l.topSeq = l.seq
+	}
+	return l
+}
+
+// IsFollowing returns whether the log view follows new entries.
+func (l *LogView) IsFollowing() bool {
+	l.Lock()
+	defer l.Unlock()
+	return l.follow
+}
+
+// SetLevelStyle sets the style of the level name of entries with the given
+// level. Levels between the ones for which a style was set use the style of
+// the next lower level.
+func (l *LogView) SetLevelStyle(level slog.Level, style tcell.Style) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.levelStyles[level] = style
+	return l
+}
+
+// SetTimeStyle sets the style of the times of entries.
+func (l *LogView) SetTimeStyle(style tcell.Style) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.timeStyle = style
+	return l
+}
+
+// SetSourceStyle sets the style of the sources of entries.
+func (l *LogView) SetSourceStyle(style tcell.Style) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.sourceStyle = style
+	return l
+}
+
+// SetMessageStyle sets the style of the messages of entries.
+func (l *LogView) SetMessageStyle(style tcell.Style) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.messageStyle = style
+	return l
+}
+
+// SetFieldStyles sets the styles of the keys and the values of the fields of
+// entries.
+func (l *LogView) SetFieldStyles(key, value tcell.Style) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.keyStyle, l.valueStyle = key, value
+	return l
+}
+
+// SetScrollBarVisibility sets when the vertical and the horizontal scroll bars
+// are shown (see [ScrollBarNever], [ScrollBarAuto], and [ScrollBarAlways]).
+// Note that in order to show the horizontal scroll bar, the log view needs to
+// determine the width of all visible lines.
+//
+// The thumb of a scroll bar can be dragged with the mouse.
+func (l *LogView) SetScrollBarVisibility(vertical, horizontal ScrollBarVisibility) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.scrollBars.setVisibility(vertical, horizontal)
+	return l
+}
+
+// SetScrollBarRunes sets the runes used to draw the scroll bars' tracks and
+// thumbs.
+func (l *LogView) SetScrollBarRunes(track, thumb rune) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.scrollBars.setRunes(track, thumb)
+	return l
+}
+
+// SetScrollBarStyles sets the styles of the scroll bars' tracks and thumbs.
+func (l *LogView) SetScrollBarStyles(track, thumb tcell.Style) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.scrollBars.setStyles(track, thumb)
+	return l
+}
+
+// SetDoneFunc sets a handler which is called when the user presses on the
+// following keys: Escape, Enter, Tab, Backtab. The key is passed to the
+// handler.
+func (l *LogView) SetDoneFunc(handler func(key tcell.Key)) *LogView {
+	l.Lock()
+	defer l.Unlock()
+	l.done = handler
+	return l
+}
+
+// logFormat determines how a [LogView] or a [LogHandler] formats log entries.
+type logFormat struct {
+	// The layout used to format times. If empty, times are not shown.
+	timeFormat string
+
+	// An optional function which formats times, overriding timeFormat.
+	timeFormatFunc func(t time.Time) string
+
+	// Whether the source is shown.
+	showSource bool
+
+	// Whether fields are shown.
+	showFields bool
+
+	// Styles.
+	timeStyle, sourceStyle, messageStyle, keyStyle, valueStyle tcell.Style
+
+	// Styles for each level. Levels between those in this map use the style
+	// of the next lower level.
+	levelStyles map[slog.Level]tcell.Style
+}
+
+// newLogFormat returns the default log format.
+func newLogFormat() logFormat {
+	return logFormat{
+		timeFormat:   "15:04:05.000",
+		showSource:   true,
+		showFields:   true,
+		timeStyle:    tcell.StyleDefault.Foreground(tcell.ColorGray),
+		sourceStyle:  tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		messageStyle: tcell.StyleDefault.Foreground(Styles.PrimaryTextColor),
+		keyStyle:     tcell.StyleDefault.Foreground(Styles.TertiaryTextColor),
+		valueStyle:   tcell.StyleDefault.Foreground(Styles.PrimaryTextColor),
+		levelStyles: map[slog.Level]tcell.Style{
+			slog.LevelDebug: tcell.StyleDefault.Foreground(tcell.ColorGray),
+			slog.LevelInfo:  tcell.StyleDefault.Foreground(Styles.TertiaryTextColor),
+			slog.LevelWarn:  tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+			slog.LevelError: tcell.StyleDefault.Foreground(tcell.ColorRed).Bold(true),
+		},
+	}
+}
+
+// levelStyle returns the style of the given level.
+func (f *logFormat) levelStyle(level slog.Level) tcell.Style {
+	style, found, best := tcell.StyleDefault, false, slog.Level(0)
+	for styleLevel, levelStyle := range f.levelStyles {
+		if styleLevel <= level && (!found || styleLevel > best) {
+			style, found, best = levelStyle, true, styleLevel
+		}
+	}
+	return style
+}
+
+// logFields calls the "field" function for each field, prefixing the keys of
+// fields in groups with the group name.
+func logFields(prefix string, fields []slog.Attr, field func(key, value string)) {
+	for _, attr := range fields {
+		value := attr.Value.Resolve()
+		if value.Kind() == slog.KindGroup {
+			groupPrefix := prefix
+			if attr.Key != "" {
+				groupPrefix += attr.Key + "."
+			}
+			logFields(groupPrefix, value.Group(), field)
+			continue
+		}
+		if attr.Equal(slog.Attr{}) {
+			continue
+		}
+		text := value.String()
+		if text == "" || strings.ContainsAny(text, " =\"\n") {
+			text = fmt.Sprintf("%q", text)
+		}
+		field(prefix+attr.Key, text)
+	}
+}
+
+// logEntryText returns the text of an entry searched by the text filters.
+func logEntryText(entry *LogEntry) string {
+	var b strings.Builder
+	b.WriteString(entry.Source)
+	b.WriteByte(' ')
+	b.WriteString(entry.Message)
+	logFields("", entry.Fields, func(key, value string) {
+		fmt.Fprintf(&b, " %s=%s", key, value)
+	})
+	return b.String()
+}
+
+// format returns the line shown for the given entry, including style tags.
+func (f *logFormat) format(entry *LogEntry) string {
+	var b strings.Builder
+	var timestamp string
+	if f.timeFormatFunc != nil {
+		timestamp = f.timeFormatFunc(entry.Time)
+	} else if f.timeFormat != "" {
+		timestamp = entry.Time.Format(f.timeFormat)
+	}
+	if timestamp != "" {
+		b.WriteString(styleTag(f.timeStyle) + Escape(timestamp) + " ")
+	}
+	fmt.Fprintf(&b, "%s%-5s ", styleTag(f.levelStyle(entry.Level)), entry.Level)
+	if f.showSource && entry.Source != "" {
+		b.WriteString(styleTag(f.sourceStyle) + "[" + Escape(entry.Source) + "[] ")
+	}
+	b.WriteString(styleTag(f.messageStyle) + Escape(entry.Message))
+	if f.showFields {
+		keyTag, valueTag := styleTag(f.keyStyle), styleTag(f.valueStyle)
+		logFields("", entry.Fields, func(key, value string) {
+			b.WriteString(" " + keyTag + Escape(key) + "=" + valueTag + Escape(value))
+		})
+	}
+	return b.String()
+}
+
+// Draw draws this primitive onto the screen.
+func (l *LogView) Draw(screen tcell.Screen) {
+	l.Box.DrawForSubclass(screen, l)
+
+	l.Lock()
+	defer l.Unlock()
+
+	x, y, width, height := l.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Determine the visible entries.
+	var visible []*logRecord
+	for index := range l.entries {
+		record := &l.entries[(l.start+index)%len(l.entries)]
+		if l.shown(record) {
+			visible = append(visible, record)
+		}
+	}
+
+	// Make room for the scroll bars.
+	l.scrollBars.hide()
+	verticalBar := width > 1 && l.scrollBars.vertical.needed(len(visible), height)
+	if verticalBar {
+		width--
+	}
+	var lines []string
+	var contentWidth int
+	if l.scrollBars.horizontal.visibility != ScrollBarNever && height > 1 {
+		lines = make([]string, len(visible))
+		for index, record := range visible {
+			lines[index] = l.format(&record.LogEntry)
+			contentWidth = max(contentWidth, TaggedStringWidth(lines[index]))
+		}
+	}
+	horizontalBar := height > 1 && l.scrollBars.horizontal.needed(contentWidth, width)
+	if horizontalBar {
+		height--
+	}
+	l.pageSize = height
+
+	// Determine the first visible entry.
+	lastOffset := max(len(visible)-height, 0)
+	var offset int
+	if l.scrollTo >= 0 {
+		offset = l.scrollTo
+	} else if l.follow {
+		offset = lastOffset
+	} else {
+		for offset < len(visible) && visible[offset].seq < l.topSeq {
+			offset++
+		}
+	}
+	offset = max(min(offset+l.scrollDelta, lastOffset), 0)
+	if l.scrollDelta > 0 || l.scrollTo >= 0 {
+		l.follow = offset >= lastOffset
+	} else if l.scrollDelta < 0 {
+		l.follow = false
+	}
+	l.scrollDelta, l.scrollTo = 0, -1
+	if offset < len(visible) {
+		l.topSeq = visible[offset].seq
+	} else {
+		l.topSeq = l.seq + 1
+	}
+	if contentWidth > 0 {
+		l.columnOffset = min(l.columnOffset, max(contentWidth-width, 0))
+	}
+	l.columnOffset = max(l.columnOffset, 0)
+
+	// Draw the entries.
+	for row := 0; row < height && offset+row < len(visible); row++ {
+		var line string
+		if lines != nil {
+			line = lines[offset+row]
+		} else {
+			line = l.format(&visible[offset+row].LogEntry)
+		}
+		printWithStyle(screen, line, x, y+row, l.columnOffset, width, AlignLeft, tcell.StyleDefault.Background(l.backgroundColor), true)
+	}
+
+	// Draw the scroll bars.
+	if verticalBar {
+		l.scrollBars.vertical.draw(screen, x+width, y, height, len(visible), height, offset)
+	}
+	if horizontalBar {
+		l.scrollBars.horizontal.draw(screen, x, y+height, width, contentWidth, width, l.columnOffset)
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (l *LogView) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return l.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		key := event.Key()
+		if key == tcell.KeyEscape || key == tcell.KeyEnter || key == tcell.KeyTab || key == tcell.KeyBacktab {
+			l.Lock()
+			done := l.done
+			l.Unlock()
+			if done != nil {
+				done(key)
+			}
+			return
+		}
+
+		l.Lock()
+		defer l.Unlock()
+		home := func() {
+			l.follow = false
+			l.scrollTo = 0
+			l.columnOffset = 0
+		}
+		end := func() {
+			l.follow = true
+			l.scrollDelta = 0
+			l.columnOffset = 0
+		}
+		switch key {
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'g':
+				home()
+			case 'G':
+				end()
+			case 'j':
+				l.scrollDelta++
+			case 'k':
+				l.scrollDelta--
+			case 'h':
+				l.columnOffset--
+			case 'l':
+				l.columnOffset++
+			}
+		case tcell.KeyHome:
+			home()
+		case tcell.KeyEnd:
+			end()
+		case tcell.KeyUp:
+			l.scrollDelta--
+		case tcell.KeyDown:
+			l.scrollDelta++
+		case tcell.KeyLeft:
+			l.columnOffset--
+		case tcell.KeyRight:
+			l.columnOffset++
+		case tcell.KeyPgDn, tcell.KeyCtrlF:
+			l.scrollDelta += max(l.pageSize, 1)
+		case tcell.KeyPgUp, tcell.KeyCtrlB:
+			l.scrollDelta -= max(l.pageSize, 1)
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (l *LogView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return l.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		l.Lock()
+		defer l.Unlock()
+
+		// Scroll bars.
+		if consumed, capture := l.scrollBars.mouseHandler(l, action, event, setFocus, func(vertical bool, offset int) {
+			if vertical {
+				l.scrollTo = offset
+			} else {
+				l.columnOffset = offset
+			}
+		}); consumed {
+			return consumed, capture
+		}
+
+		if !l.InRect(event.Position()) {
+			return false, nil
+		}
+
+		switch action {
+		case MouseLeftDown:
+			setFocus(l)
+			consumed = true
+		case MouseScrollUp:
+			l.scrollDelta--
+			consumed = true
+		case MouseScrollDown:
+			l.scrollDelta++
+			consumed = true
+		case MouseScrollLeft:
+			l.columnOffset--
+			consumed = true
+		case MouseScrollRight:
+			l.columnOffset++
+			consumed = true
+		}
+		return
+	})
+}
@@ -0,0 +1,694 @@
+package tview
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/uniseg"
+)
+
+// mdBullets are the bullets of unordered lists, by nesting level.
+var mdBullets = []string{"•", "◦", "▪"}
+
+// MarkdownHeading describes a heading of a [Markdown] document.
+type MarkdownHeading struct {
+	// The heading level, from 1 to 6.
+	Level int
+
+	// The text of the heading, without formatting.
+	Text string
+
+	// The heading's identifier, derived from its text in the same way as
+	// GitHub does it, e.g. "getting-started" for "Getting Started". If
+	// multiple headings have the same text, a number is added to the
+	// identifiers of the second and all subsequent headings ("-1", "-2", ...).
+	ID string
+}
+
+// Markdown is a scrollable view of a document written in Markdown. It renders
+// the CommonMark block elements (headings, paragraphs, block quotes, ordered
+// and unordered lists, fenced and indented code blocks, thematic breaks),
+// tables and task lists as known from GitHub, and inline emphasis, strong
+// emphasis, strike-through, code spans, links, and images (which are shown as
+// links). HTML is not interpreted and is shown as is.
+//
+// The text is reflowed to the width of the primitive. Lines of code blocks are
+// not wrapped, they can be scrolled horizontally instead. Links are shown
+// using the URL field of style tags such that terminals which support
+// hyperlinks allow the user to open them.
+//
+// Headings are highlightable regions (see [TextView]). Use
+// [Markdown.GetHeadings] to get a list of the document's headings and
+// [Markdown.ScrollToHeading] to navigate to one of them. Links to headings
+// within the document, e.g. "[see below](#installation)", navigate to the
+// heading when clicked.
+//
+// The following keys are supported, in addition to those of [TextView]:
+//
+//   - n: Move to the next heading.
+//   - p: Move to the previous heading.
+type Markdown struct {
+	*Box
+
+	// The text view which shows the rendered document.
+	textView *TextView
+
+	// The Markdown source.
+	text string
+
+	// The parsed document and its link reference definitions.
+	blocks      []*mdBlock
+	definitions map[string]string
+
+	// The document's headings and the indices of the headings by ID.
+	headings     []MarkdownHeading
+	headingIndex map[string]int
+
+	// The index of the current heading, or -1 if there is none.
+	currentHeading int
+
+	// The width for which the document was last rendered, or -1 if it needs
+	// to be rendered again.
+	width int
+
+	// If not empty, the region ID of the heading to scroll to during the next
+	// draw call.
+	scrollTo string
+
+	// Styles.
+	textStyle, codeStyle, codeBlockStyle, linkStyle, graphicsStyle tcell.Style
+
+	// The styles of the headings, by level.
+	headingStyles [6]tcell.Style
+}
+
+// NewMarkdown returns a new, empty Markdown view.
+func NewMarkdown() *Markdown {
+	headingStyle := tcell.StyleDefault.Foreground(Styles.TitleColor).Bold(true)
+	m := &Markdown{
+		Box: NewBox(),
+		textView: NewTextView().
+			SetDynamicColors(true).
+			SetRegions(true).
+			SetWrap(false),
+		currentHeading: -1,
+		width:          -1,
+		textStyle:      tcell.StyleDefault.Foreground(Styles.PrimaryTextColor),
+		codeStyle:      tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		codeBlockStyle: tcell.StyleDefault.Foreground(Styles.PrimaryTextColor).Background(Styles.ContrastBackgroundColor),
+		linkStyle:      tcell.StyleDefault.Foreground(Styles.TertiaryTextColor).Underline(true),
+		graphicsStyle:  tcell.StyleDefault.Foreground(Styles.GraphicsColor),
+		headingStyles: [6]tcell.Style{
+			headingStyle.Underline(true),
+			headingStyle,
+			headingStyle.Foreground(Styles.SecondaryTextColor),
+			headingStyle.Foreground(Styles.SecondaryTextColor),
+			headingStyle.Foreground(Styles.SecondaryTextColor),
+			headingStyle.Foreground(Styles.SecondaryTextColor),
+		},
+	}
+	m.Box.Primitive = m
+	m.textView.SetHighlightedFunc(m.highlighted)
+	return m
+}
+
+// SetText sets the Markdown document to be shown.
+func (m *Markdown) SetText(markdown string) *Markdown {
+	m.text = markdown
+	m.blocks, m.definitions = parseMarkdown(markdown)
+	m.currentHeading = -1
+	m.width = -1
+
+	// Determine the headings and their IDs.
+	m.headings = nil
+	m.headingIndex = make(map[string]int)
+	ids := make(map[string]int)
+	var walk func(blocks []*mdBlock)
+	walk = func(blocks []*mdBlock) {
+		for _, block := range blocks {
+			if block.kind != mdHeading {
+				walk(block.children)
+				continue
+			}
+			text := m.plainText(block.text)
+			id := mdSlug(text)
+			if count, ok := ids[id]; ok {
+				ids[id] = count + 1
+				id += "-" + strconv.Itoa(count+1)
+			} else {
+				ids[id] = 0
+			}
+			block.id = id
+			m.headingIndex[id] = len(m.headings)
+			m.headings = append(m.headings, MarkdownHeading{
+				Level: block.level,
+				Text:  text,
+				ID:    id,
+			})
+		}
+	}
+	walk(m.blocks)
+
+	m.textView.Highlight()
+	m.textView.ScrollToBeginning()
+	return m
+}
+
+// GetText returns the Markdown document.
+func (m *Markdown) GetText() string {
+	return m.text
+}
+
+// GetHeadings returns the headings of the document, in the order in which
+// they appear.
+func (m *Markdown) GetHeadings() []MarkdownHeading {
+	return append([]MarkdownHeading(nil), m.headings...)
+}
+
+// ScrollToHeading highlights the heading with the given ID (see
+// [MarkdownHeading]) and scrolls it into view the next time the document is
+// drawn. Unknown IDs are ignored.
+func (m *Markdown) ScrollToHeading(id string) *Markdown {
+	if index, ok := m.headingIndex[id]; ok {
+		m.currentHeading = index
+		m.scrollTo = mdHeadingRegion(index)
+	}
+	return m
+}
+
+// mdHeadingRegion returns the region ID of the heading with the given index.
+// (Heading IDs cannot be used as region IDs as they may contain characters
+// which are not allowed in region tags.)
+func mdHeadingRegion(index int) string {
+	return "heading." + strconv.Itoa(index)
+}
+
+// SetTextStyle sets the style of normal text.
+func (m *Markdown) SetTextStyle(style tcell.Style) *Markdown {
+	m.textStyle = style
+	m.width = -1
+	return m
+}
+
+// SetHeadingStyle sets the style of headings of the given level (1 to 6).
+func (m *Markdown) SetHeadingStyle(level int, style tcell.Style) *Markdown {
+	if level >= 1 && level <= len(m.headingStyles) {
+		m.headingStyles[level-1] = style
+		m.width = -1
+	}
+	return m
+}
+
+// SetCodeStyles sets the style of code spans within text and the style of
+// code blocks.
+func (m *Markdown) SetCodeStyles(inline, block tcell.Style) *Markdown {
+	m.codeStyle = inline
+	m.codeBlockStyle = block
+	m.width = -1
+	return m
+}
+
+// SetLinkStyle sets the style of links.
+func (m *Markdown) SetLinkStyle(style tcell.Style) *Markdown {
+	m.linkStyle = style
+	m.width = -1
+	return m
+}
+
+// SetGraphicsStyle sets the style of graphical elements such as list bullets,
+// the bars of block quotes, thematic breaks, and table borders.
+func (m *Markdown) SetGraphicsStyle(style tcell.Style) *Markdown {
+	m.graphicsStyle = style
+	m.width = -1
+	return m
+}
+
+// SetScrollBarVisibility sets when the vertical and the horizontal scroll bars
+// are shown (see [ScrollBarNever], [ScrollBarAuto], and [ScrollBarAlways]).
+func (m *Markdown) SetScrollBarVisibility(vertical, horizontal ScrollBarVisibility) *Markdown {
+	m.textView.SetScrollBarVisibility(vertical, horizontal)
+	return m
+}
+
+// SetDoneFunc sets a handler which is called when the user presses the
+// Escape, Enter, Tab, or Backtab key. The key is passed to the handler.
+func (m *Markdown) SetDoneFunc(handler func(key tcell.Key)) *Markdown {
+	m.textView.SetDoneFunc(handler)
+	return m
+}
+
+// highlighted is called when the highlighted regions of the text view change.
+// It follows links to headings and keeps track of the current heading.
+func (m *Markdown) highlighted(added, removed, remaining []string) {
+	for _, id := range added {
+		kind, number, _ := strings.Cut(id, ".")
+		index, err := strconv.Atoi(number)
+		if err != nil || index < 0 || index >= len(m.headings) {
+			continue
+		}
+		switch kind {
+		case "link":
+			m.ScrollToHeading(m.headings[index].ID)
+			return
+		case "heading":
+			m.currentHeading = index
+		}
+	}
+}
+
+// plainText returns the given inline Markdown without formatting.
+func (m *Markdown) plainText(text string) string {
+	p := &mdParser{definitions: m.definitions}
+	var b strings.Builder
+	for _, span := range p.parseInline(text, 0, "") {
+		b.WriteString(span.text)
+	}
+	return strings.TrimSpace(strings.ReplaceAll(b.String(), "\n", " "))
+}
+
+// mdPiece is a piece of text with uniform style, ready for rendering.
+type mdPiece struct {
+	text  string
+	style tcell.Style
+
+	// The link target, if any. Targets starting with "#" are rendered as
+	// regions.
+	url string
+}
+
+// tagged returns the given pieces as one string with style tags.
+func (m *Markdown) tagged(pieces []mdPiece) string {
+	var (
+		b               strings.Builder
+		style           tcell.Style
+		url, region     string
+		styled, started bool
+	)
+	for _, piece := range pieces {
+		if piece.text == "" {
+			continue
+		}
+
+		// Links to headings are regions, other links use the URL field.
+		var pieceURL, pieceRegion string
+		if target, ok := strings.CutPrefix(piece.url, "#"); ok {
+			if index, ok := m.headingIndex[target]; ok {
+				pieceRegion = "link." + strconv.Itoa(index)
+			}
+		}
+		if pieceRegion == "" {
+			pieceURL = strings.NewReplacer("[", "%5B", "]", "%5D").Replace(piece.url)
+		}
+
+		if started && pieceRegion != region && region != "" {
+			b.WriteString(`[""]`)
+		}
+		if pieceRegion != region && pieceRegion != "" {
+			b.WriteString(`["` + pieceRegion + `"]`)
+		}
+		if !styled || piece.style != style {
+			b.WriteString(styleTag(piece.style))
+		}
+		if pieceURL != url {
+			if pieceURL == "" {
+				b.WriteString("[:::-]")
+			} else {
+				b.WriteString("[:::" + pieceURL + "]")
+			}
+		}
+		b.WriteString(Escape(piece.text))
+		style, url, region, styled, started = piece.style, pieceURL, pieceRegion, true, true
+	}
+	if url != "" {
+		b.WriteString("[:::-]")
+	}
+	if region != "" {
+		b.WriteString(`[""]`)
+	}
+	return b.String()
+}
+
+// pieces parses inline Markdown and returns its styled pieces.
+func (m *Markdown) pieces(text string, base tcell.Style) []mdPiece {
+	p := &mdParser{definitions: m.definitions}
+	spans := p.parseInline(text, 0, "")
+	pieces := make([]mdPiece, len(spans))
+	for index, span := range spans {
+		style := base
+		switch {
+		case span.format&mdCodeSpan != 0:
+			style = m.codeStyle
+		case span.format&mdLink != 0:
+			style = m.linkStyle
+		}
+		if span.format&mdBold != 0 {
+			style = style.Bold(true)
+		}
+		if span.format&mdItalic != 0 {
+			style = style.Italic(true)
+		}
+		if span.format&mdStrike != 0 {
+			style = style.StrikeThrough(true)
+		}
+		pieces[index] = mdPiece{text: span.text, style: style, url: span.url}
+	}
+	return pieces
+}
+
+// wrap breaks the given pieces into lines which do not exceed the given width
+// and returns the lines as pieces. Lines are broken at spaces and at hard line
+// breaks. Words which are longer than the width are broken anywhere.
+func (m *Markdown) wrap(pieces []mdPiece, width int) (lines [][]mdPiece) {
+	width = max(width, 1)
+
+	// Split the pieces into words.
+	type word struct {
+		pieces  []mdPiece
+		width   int
+		space   *mdPiece // The space before the word, if any.
+		newLine bool     // Whether there is a hard line break before the word.
+	}
+	var (
+		words   []*word
+		current = &word{}
+	)
+	endWord := func() {
+		if len(current.pieces) > 0 {
+			words = append(words, current)
+			current = &word{}
+		}
+	}
+	for _, piece := range pieces {
+		for index, part := range strings.Split(piece.text, "\n") {
+			if index > 0 {
+				endWord()
+				current.newLine = true
+				current.space = nil
+			}
+			for fieldIndex, field := range strings.Split(part, " ") {
+				if fieldIndex > 0 {
+					endWord()
+					if !current.newLine {
+						current.space = &mdPiece{text: " ", style: piece.style, url: piece.url}
+					}
+				}
+				if field != "" {
+					current.pieces = append(current.pieces, mdPiece{text: field, style: piece.style, url: piece.url})
+					current.width += uniseg.StringWidth(field)
+				}
+			}
+		}
+	}
+	endWord()
+
+	// Lay out the words.
+	var (
+		line      []mdPiece
+		lineWidth int
+	)
+	for _, w := range words {
+		if w.newLine || lineWidth > 0 && lineWidth+1+w.width > width {
+			lines = append(lines, line)
+			line, lineWidth = nil, 0
+		}
+		if lineWidth > 0 && w.space != nil {
+			line = append(line, *w.space)
+			lineWidth++
+		}
+		if lineWidth+w.width <= width {
+			line = append(line, w.pieces...)
+			lineWidth += w.width
+			continue
+		}
+
+		// Break long words.
+		for _, piece := range w.pieces {
+			text := piece.text
+			state := -1
+			for text != "" {
+				var cluster string
+				var clusterWidth int
+				cluster, text, clusterWidth, state = uniseg.FirstGraphemeClusterInString(text, state)
+				if lineWidth+clusterWidth > width && lineWidth > 0 {
+					lines = append(lines, line)
+					line, lineWidth = nil, 0
+				}
+				line = append(line, mdPiece{text: cluster, style: piece.style, url: piece.url})
+				lineWidth += clusterWidth
+			}
+		}
+	}
+	return append(lines, line)
+}
+
+// render renders the given blocks for the given width and returns the lines
+// with style tags. The blocks are separated by blank lines unless "tight" is
+// set.
+func (m *Markdown) render(blocks []*mdBlock, width, depth int, tight bool) (lines []string) {
+	width = max(width, 1)
+	for index, block := range blocks {
+		if index > 0 && !tight {
+			lines = append(lines, "")
+		}
+		lines = append(lines, m.renderBlock(block, width, depth)...)
+	}
+	return
+}
+
+// renderBlock renders one block for the given width. The depth is the nesting
+// level of lists.
+func (m *Markdown) renderBlock(block *mdBlock, width, depth int) (lines []string) {
+	graphics := styleTag(m.graphicsStyle)
+	switch block.kind {
+	case mdParagraph:
+		for _, line := range m.wrap(m.pieces(block.text, m.textStyle), width) {
+			lines = append(lines, m.tagged(line))
+		}
+
+	case mdHeading:
+		for _, line := range m.wrap(m.pieces(block.text, m.headingStyles[block.level-1]), width) {
+			lines = append(lines, `["`+mdHeadingRegion(m.headingIndex[block.id])+`"]`+m.tagged(line)+`[""]`)
+		}
+
+	case mdCode:
+		style := styleTag(m.codeBlockStyle)
+		for line := range strings.SplitSeq(block.text, "\n") {
+			line = " " + strings.ReplaceAll(line, "\t", "    ")
+			padding := max(width-uniseg.StringWidth(line), 0)
+			lines = append(lines, style+Escape(line)+strings.Repeat(" ", padding))
+		}
+
+	case mdQuote:
+		for _, line := range m.render(block.children, width-2, depth, false) {
+			lines = append(lines, graphics+"│ "+line)
+		}
+
+	case mdList:
+		// Determine the markers.
+		markers := make([]string, len(block.children))
+		var markerWidth int
+		for index, item := range block.children {
+			switch {
+			case item.task == 0:
+				markers[index] = "☐"
+			case item.task == 1:
+				markers[index] = "☑"
+			case block.ordered:
+				markers[index] = strconv.Itoa(block.start+index) + "."
+			default:
+				markers[index] = mdBullets[depth%len(mdBullets)]
+			}
+			markerWidth = max(markerWidth, uniseg.StringWidth(markers[index])+1)
+		}
+
+		// Render the items.
+		for index, item := range block.children {
+			if index > 0 && block.loose {
+				lines = append(lines, "")
+			}
+			itemLines := m.render(item.children, width-markerWidth, depth+1, !block.loose)
+			if len(itemLines) == 0 {
+				itemLines = []string{""}
+			}
+			marker := markers[index]
+			if block.ordered && item.task < 0 {
+				marker = strings.Repeat(" ", markerWidth-1-uniseg.StringWidth(marker)) + marker // Right-align numbers.
+			}
+			for lineIndex, line := range itemLines {
+				if lineIndex == 0 {
+					lines = append(lines, graphics+marker+strings.Repeat(" ", markerWidth-uniseg.StringWidth(marker))+line)
+				} else {
+					lines = append(lines, strings.Repeat(" ", markerWidth)+line)
+				}
+			}
+		}
+
+	case mdRule:
+		lines = append(lines, graphics+strings.Repeat("─", width))
+
+	case mdTable:
+		lines = m.renderTable(block, width)
+	}
+	return
+}
+
+// renderTable renders a table for the given width.
+func (m *Markdown) renderTable(block *mdBlock, width int) (lines []string) {
+	columns := len(block.aligns)
+	graphics := styleTag(m.graphicsStyle)
+
+	// Parse the cells and determine the natural column widths.
+	cells := make([][][]mdPiece, len(block.rows))
+	widths := make([]int, columns)
+	for row, texts := range block.rows {
+		style := m.textStyle
+		if row == 0 {
+			style = style.Bold(true)
+		}
+		cells[row] = make([][]mdPiece, columns)
+		for column, text := range texts {
+			cells[row][column] = m.pieces(text, style)
+			var cellWidth int
+			for _, piece := range cells[row][column] {
+				cellWidth += uniseg.StringWidth(piece.text)
+			}
+			widths[column] = max(widths[column], cellWidth, 1)
+		}
+	}
+
+	// Shrink the widest columns until the table fits.
+	available := width - 1 - 3*columns
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	for total > max(available, columns) {
+		widest := 0
+		for column, w := range widths {
+			if w > widths[widest] {
+				widest = column
+			}
+		}
+		widths[widest]--
+		total--
+	}
+
+	// border returns a horizontal border line.
+	border := func(left, middle, right string) string {
+		var b strings.Builder
+		b.WriteString(graphics + left)
+		for column, w := range widths {
+			if column > 0 {
+				b.WriteString(middle)
+			}
+			b.WriteString(strings.Repeat("─", w+2))
+		}
+		b.WriteString(right)
+		return b.String()
+	}
+
+	// Draw the rows.
+	lines = append(lines, border("┌", "┬", "┐"))
+	for row := range cells {
+		wrapped := make([][][]mdPiece, columns)
+		height := 1
+		for column := range columns {
+			wrapped[column] = m.wrap(cells[row][column], widths[column])
+			height = max(height, len(wrapped[column]))
+		}
+		for lineIndex := range height {
+			var b strings.Builder
+			b.WriteString(graphics + "│")
+			for column := range columns {
+				var text string
+				var textWidth int
+				if lineIndex < len(wrapped[column]) {
+					text = m.tagged(wrapped[column][lineIndex])
+					textWidth = TaggedStringWidth(text)
+				}
+				padding := max(widths[column]-textWidth, 0)
+				var left int
+				switch block.aligns[column] {
+				case mdAlignCenter:
+					left = padding / 2
+				case mdAlignRight:
+					left = padding
+				}
+				b.WriteString(strings.Repeat(" ", left+1) + text + "[-:-:-]" + strings.Repeat(" ", padding-left+1) + graphics + "│")
+			}
+			lines = append(lines, b.String())
+		}
+		if row == 0 && len(cells) > 1 {
+			lines = append(lines, border("├", "┼", "┤"))
+		}
+	}
+	lines = append(lines, border("└", "┴", "┘"))
+	return
+}
+
+// Draw draws this primitive onto the screen.
+func (m *Markdown) Draw(screen tcell.Screen) {
+	m.Box.DrawForSubclass(screen, m)
+	x, y, width, height := m.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Render the document if necessary.
+	if width != m.width {
+		m.width = width
+		lines := m.render(m.blocks, width, 0, false)
+		m.textView.SetText(strings.Join(lines, "\n"))
+	}
+
+	m.textView.SetRect(x, y, width, height)
+	if _, background, _ := m.textView.textStyle.Decompose(); background != m.backgroundColor {
+		m.textView.SetBackgroundColor(m.backgroundColor)
+	}
+	if m.scrollTo != "" {
+		m.textView.Highlight(m.scrollTo).ScrollToHighlight()
+		m.scrollTo = ""
+	}
+	m.textView.Draw(screen)
+}
+
+// InputHandler returns the handler for this primitive.
+func (m *Markdown) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return m.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if event.Key() == tcell.KeyRune {
+			switch event.Rune() {
+			case 'n':
+				if m.currentHeading+1 < len(m.headings) {
+					m.ScrollToHeading(m.headings[m.currentHeading+1].ID)
+				}
+				return
+			case 'p':
+				if m.currentHeading > 0 {
+					m.ScrollToHeading(m.headings[m.currentHeading-1].ID)
+				}
+				return
+			}
+		}
+		m.textView.InputHandler()(event, func(p Primitive) {
+			setFocus(m)
+		})
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (m *Markdown) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return m.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		consumed, capture = m.textView.MouseHandler()(action, event, func(p Primitive) {
+			setFocus(m)
+		})
+		if capture != nil {
+			capture = m
+		}
+		if !consumed && action == MouseLeftDown && m.InRect(event.Position()) {
+			setFocus(m)
+			consumed = true
+		}
+		return
+	})
+}
@@ -0,0 +1,697 @@
+package tview
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Types of Markdown blocks.
+const (
+	mdParagraph = iota
+	mdHeading
+	mdCode
+	mdQuote
+	mdList
+	mdItem
+	mdRule
+	mdTable
+)
+
+// Column alignments of Markdown tables.
+const (
+	mdAlignNone = iota
+	mdAlignLeft
+	mdAlignCenter
+	mdAlignRight
+)
+
+var (
+	mdHeadingPattern    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))??(?:[ \t]+#+)?[ \t]*$`)
+	mdRulePattern       = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdFencePattern      = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`]*)$")
+	mdItemPattern       = regexp.MustCompile(`^( {0,3})([-*+]|[0-9]{1,9}[.)])([ \t]+|$)`)
+	mdTaskPattern       = regexp.MustCompile(`^\[([ xX])\][ \t]+`)
+	mdDelimiterPattern  = regexp.MustCompile(`^ {0,3}\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	mdDefinitionPattern = regexp.MustCompile(`^ {0,3}\[([^\]]+)\]:[ \t]*<?([^ \t>]+)>?(?:[ \t]+(?:"[^"]*"|'[^']*'|\([^)]*\)))?[ \t]*$`)
+	mdSetextPattern     = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	mdAutolinkPattern   = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^ <>]*|[a-zA-Z0-9.!#$%&'*+/=?^_{|}~-]+@[a-zA-Z0-9.-]+)>`)
+)
+
+// mdBlock is a block of a Markdown document.
+type mdBlock struct {
+	// The block type, one of the md constants.
+	kind int
+
+	// The heading level (1 to 6) and the heading's identifier.
+	level int
+	id    string
+
+	// The raw inline text of paragraphs, headings, and table cells, or the
+	// content of code blocks.
+	text string
+
+	// The info string of fenced code blocks, e.g. the language.
+	info string
+
+	// The contained blocks of block quotes, lists (which contain items), and
+	// list items.
+	children []*mdBlock
+
+	// Whether the list is ordered and the number of its first item.
+	ordered bool
+	start   int
+
+	// Whether the list is loose, i.e. its items are separated by blank lines.
+	loose bool
+
+	// The state of a task list item: -1 if this is not a task, 0 if it is not
+	// done, 1 if it is done.
+	task int
+
+	// The rows of a table (the first row is the header) and the alignment of
+	// its columns.
+	rows   [][]string
+	aligns []int
+}
+
+// mdParser parses Markdown documents.
+type mdParser struct {
+	// Link reference definitions, with lower case labels.
+	definitions map[string]string
+}
+
+// parseMarkdown parses the given Markdown document and returns its blocks and
+// its link reference definitions.
+func parseMarkdown(text string) ([]*mdBlock, map[string]string) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+	for index, line := range lines {
+		lines[index] = mdExpandTabs(line)
+	}
+	p := &mdParser{definitions: make(map[string]string)}
+	return p.parseBlocks(lines), p.definitions
+}
+
+// mdExpandTabs replaces tabs in the leading whitespace of a line with spaces
+// (with tab stops every 4 characters).
+func mdExpandTabs(line string) string {
+	var b strings.Builder
+	for index, r := range line {
+		switch r {
+		case ' ':
+			b.WriteByte(' ')
+		case '\t':
+			b.WriteString(strings.Repeat(" ", 4-b.Len()%4))
+		default:
+			return b.String() + line[index:]
+		}
+	}
+	return b.String()
+}
+
+// mdIndent returns the number of leading spaces of a line.
+func mdIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// mdBlank returns whether a line is blank.
+func mdBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// mdStripIndent removes up to the given number of leading spaces.
+func mdStripIndent(line string, indent int) string {
+	return line[min(indent, mdIndent(line)):]
+}
+
+// mdInterrupts returns whether the given line starts a block which interrupts
+// a paragraph.
+func mdInterrupts(line string) bool {
+	if mdHeadingPattern.MatchString(line) || mdRulePattern.MatchString(line) || mdFencePattern.MatchString(line) {
+		return true
+	}
+	trimmed := strings.TrimLeft(line, " ")
+	if mdIndent(line) < 4 && strings.HasPrefix(trimmed, ">") {
+		return true
+	}
+	if match := mdItemPattern.FindStringSubmatch(line); match != nil && strings.TrimSpace(line[len(match[0]):]) != "" {
+		// Only bullets and ordered lists starting with 1 interrupt paragraphs.
+		return !unicode.IsDigit(rune(match[2][0])) || strings.TrimLeft(match[2][:len(match[2])-1], "0") == "1"
+	}
+	return false
+}
+
+// mdSplitRow splits a table row into its cells.
+func mdSplitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var (
+		cells []string
+		cell  strings.Builder
+		code  bool
+	)
+	for index := 0; index < len(line); index++ {
+		switch c := line[index]; {
+		case c == '\\' && index+1 < len(line) && line[index+1] == '|':
+			cell.WriteByte('|')
+			index++
+		case c == '`':
+			code = !code
+			cell.WriteByte(c)
+		case c == '|' && !code:
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(c)
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// parseBlocks parses the given lines into blocks.
+func (p *mdParser) parseBlocks(lines []string) (blocks []*mdBlock) {
+	for index := 0; index < len(lines); {
+		line := lines[index]
+		if mdBlank(line) {
+			index++
+			continue
+		}
+		indent := mdIndent(line)
+
+		// Indented code block.
+		if indent >= 4 {
+			var code []string
+			for ; index < len(lines) && (mdBlank(lines[index]) || mdIndent(lines[index]) >= 4); index++ {
+				code = append(code, mdStripIndent(lines[index], 4))
+			}
+			for len(code) > 0 && mdBlank(code[len(code)-1]) {
+				code = code[:len(code)-1]
+			}
+			blocks = append(blocks, &mdBlock{kind: mdCode, text: strings.Join(code, "\n")})
+			continue
+		}
+
+		// Fenced code block.
+		if match := mdFencePattern.FindStringSubmatch(line); match != nil {
+			fenceIndent, fence := len(match[1]), match[2]
+			var code []string
+			for index++; index < len(lines); index++ {
+				trimmed := strings.TrimSpace(lines[index])
+				if mdIndent(lines[index]) < 4 && strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+					index++
+					break
+				}
+				code = append(code, mdStripIndent(lines[index], fenceIndent))
+			}
+			info, _, _ := strings.Cut(strings.TrimSpace(match[3]), " ")
+			blocks = append(blocks, &mdBlock{kind: mdCode, text: strings.Join(code, "\n"), info: info})
+			continue
+		}
+
+		// ATX heading.
+		if match := mdHeadingPattern.FindStringSubmatch(line); match != nil {
+			blocks = append(blocks, &mdBlock{kind: mdHeading, level: len(match[1]), text: strings.TrimSpace(match[2])})
+			index++
+			continue
+		}
+
+		// Thematic break.
+		if mdRulePattern.MatchString(line) {
+			blocks = append(blocks, &mdBlock{kind: mdRule})
+			index++
+			continue
+		}
+
+		// Block quote.
+		if strings.HasPrefix(line[indent:], ">") {
+			var quoted []string
+			for ; index < len(lines); index++ {
+				line := lines[index]
+				trimmed := strings.TrimLeft(line, " ")
+				if mdIndent(line) < 4 && strings.HasPrefix(trimmed, ">") {
+					trimmed = trimmed[1:]
+					if strings.HasPrefix(trimmed, " ") {
+						trimmed = trimmed[1:]
+					}
+					quoted = append(quoted, trimmed)
+				} else if !mdBlank(line) && len(quoted) > 0 && !mdBlank(quoted[len(quoted)-1]) && !mdInterrupts(line) {
+					quoted = append(quoted, line) // Lazy continuation.
+				} else {
+					break
+				}
+			}
+			blocks = append(blocks, &mdBlock{kind: mdQuote, children: p.parseBlocks(quoted)})
+			continue
+		}
+
+		// List.
+		if match := mdItemPattern.FindStringSubmatch(line); match != nil {
+			var list *mdBlock
+			index, list = p.parseList(lines, index)
+			blocks = append(blocks, list)
+			continue
+		}
+
+		// Table.
+		if index+1 < len(lines) && strings.Contains(line, "|") && mdDelimiterPattern.MatchString(lines[index+1]) {
+			header := mdSplitRow(line)
+			delimiters := mdSplitRow(lines[index+1])
+			if len(header) == len(delimiters) {
+				table := &mdBlock{kind: mdTable, rows: [][]string{header}}
+				for _, delimiter := range delimiters {
+					align := mdAlignNone
+					switch {
+					case strings.HasPrefix(delimiter, ":") && strings.HasSuffix(delimiter, ":"):
+						align = mdAlignCenter
+					case strings.HasPrefix(delimiter, ":"):
+						align = mdAlignLeft
+					case strings.HasSuffix(delimiter, ":"):
+						align = mdAlignRight
+					}
+					table.aligns = append(table.aligns, align)
+				}
+				for index += 2; index < len(lines) && !mdBlank(lines[index]) && !mdInterrupts(lines[index]); index++ {
+					row := mdSplitRow(lines[index])
+					row = append(row, make([]string, max(len(header)-len(row), 0))...)
+					table.rows = append(table.rows, row[:len(header)])
+				}
+				blocks = append(blocks, table)
+				continue
+			}
+		}
+
+		// Link reference definition.
+		if match := mdDefinitionPattern.FindStringSubmatch(line); match != nil {
+			label := strings.ToLower(strings.Join(strings.Fields(match[1]), " "))
+			if _, ok := p.definitions[label]; !ok {
+				p.definitions[label] = match[2]
+			}
+			index++
+			continue
+		}
+
+		// Paragraph, possibly a setext heading.
+		paragraph := []string{strings.TrimSpace(line)}
+		level := 0
+		for index++; index < len(lines); index++ {
+			line := lines[index]
+			if mdBlank(line) {
+				break
+			}
+			if match := mdSetextPattern.FindStringSubmatch(line); match != nil {
+				level = 1
+				if match[1][0] == '-' {
+					level = 2
+				}
+				index++
+				break
+			}
+			if mdInterrupts(line) {
+				break
+			}
+			paragraph = append(paragraph, strings.TrimLeft(line, " "))
+		}
+		text := strings.Join(paragraph, "\n")
+		if level > 0 {
+			blocks = append(blocks, &mdBlock{kind: mdHeading, level: level, text: text})
+		} else {
+			blocks = append(blocks, &mdBlock{kind: mdParagraph, text: text})
+		}
+	}
+	return
+}
+
+// parseList parses a list starting at the line with the given index. It
+// returns the index of the first line after the list and the list block.
+func (p *mdParser) parseList(lines []string, index int) (int, *mdBlock) {
+	list := &mdBlock{kind: mdList}
+	var marker byte // The bullet character or the delimiter of ordered lists.
+	for index < len(lines) {
+		match := mdItemPattern.FindStringSubmatch(lines[index])
+		if match == nil {
+			break
+		}
+		itemMarker := match[2][len(match[2])-1]
+		ordered := unicode.IsDigit(rune(match[2][0]))
+		if list.children == nil {
+			marker = itemMarker
+			list.ordered = ordered
+			if ordered {
+				list.start, _ = strconv.Atoi(match[2][:len(match[2])-1])
+			}
+		} else if itemMarker != marker || ordered != list.ordered {
+			break
+		}
+
+		// Determine the indentation of the item's content.
+		contentIndent := len(match[1]) + len(match[2]) + len(match[3])
+		first := lines[index][len(match[0]):]
+		if len(match[3]) > 4 {
+			// Indented code at the start of an item.
+			contentIndent = len(match[1]) + len(match[2]) + 1
+			first = strings.Repeat(" ", len(match[3])-1) + first
+		} else if mdBlank(first) {
+			contentIndent = len(match[1]) + len(match[2]) + 1
+		}
+
+		// Collect the item's lines.
+		item := &mdBlock{kind: mdItem, task: -1}
+		content := []string{first}
+		var blankBefore bool
+		for index++; index < len(lines); index++ {
+			line := lines[index]
+			if mdBlank(line) {
+				blankBefore = true
+				content = append(content, "")
+				continue
+			}
+			if mdIndent(line) >= contentIndent {
+				if blankBefore && len(content) > 1 {
+					list.loose = true
+				}
+				blankBefore = false
+				content = append(content, line[contentIndent:])
+				continue
+			}
+			if !blankBefore && !mdInterrupts(line) && !mdItemPattern.MatchString(line) {
+				content = append(content, line) // Lazy continuation.
+				continue
+			}
+			break
+		}
+		for len(content) > 0 && mdBlank(content[len(content)-1]) {
+			content = content[:len(content)-1]
+		}
+		if next := mdItemPattern.FindStringSubmatch(lines[min(index, len(lines)-1)]); blankBefore && index < len(lines) && next != nil && next[2][len(next[2])-1] == marker {
+			list.loose = true // Blank line between two items of this list.
+		}
+
+		// Task list items. (Empty items have no content.)
+		if len(content) > 0 {
+			if task := mdTaskPattern.FindStringSubmatch(content[0]); task != nil {
+				item.task = 0
+				if task[1] != " " {
+					item.task = 1
+				}
+				content[0] = content[0][len(task[0]):]
+			}
+		}
+
+		item.children = p.parseBlocks(content)
+		list.children = append(list.children, item)
+	}
+	return index, list
+}
+
+// mdSpan is a piece of inline text with uniform formatting.
+type mdSpan struct {
+	// The text. A newline denotes a hard line break.
+	text string
+
+	// The formatting, a combination of the md format flags.
+	format int
+
+	// The link target, if this is part of a link.
+	url string
+}
+
+// Formatting flags of inline text.
+const (
+	mdBold = 1 << iota
+	mdItalic
+	mdStrike
+	mdCodeSpan
+	mdLink
+)
+
+// mdPunctuation returns whether the given byte is ASCII punctuation, which may
+// be escaped with a backslash.
+func mdPunctuation(c byte) bool {
+	return c < utf8.RuneSelf && unicode.IsPunct(rune(c)) || strings.IndexByte("$+<=>^`|~", c) >= 0
+}
+
+// parseInline parses inline Markdown into spans.
+func (p *mdParser) parseInline(text string, format int, url string) (spans []mdSpan) {
+	var plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			spans = append(spans, mdSpan{text: plain.String(), format: format, url: url})
+			plain.Reset()
+		}
+	}
+	add := func(text string, format int, url string) {
+		flush()
+		spans = append(spans, mdSpan{text: text, format: format, url: url})
+	}
+
+	for index := 0; index < len(text); {
+		c := text[index]
+		switch {
+		case c == '\\' && index+1 < len(text) && text[index+1] == '\n': // Hard line break.
+			add("\n", format, url)
+			index += 2
+			continue
+		case c == '\\' && index+1 < len(text) && mdPunctuation(text[index+1]):
+			plain.WriteByte(text[index+1])
+			index += 2
+			continue
+		case c == '\n':
+			if strings.HasSuffix(plain.String(), "  ") {
+				trimmed := strings.TrimRight(plain.String(), " ")
+				plain.Reset()
+				plain.WriteString(trimmed)
+				add("\n", format, url)
+			} else {
+				plain.WriteByte(' ')
+			}
+			index++
+			continue
+		case c == '`':
+			run := len(text[index:]) - len(strings.TrimLeft(text[index:], "`"))
+			delimiter := text[index : index+run]
+			end := index + run
+			for end < len(text) {
+				next := strings.Index(text[end:], delimiter)
+				if next < 0 {
+					end = len(text)
+					break
+				}
+				end += next
+				if end+run < len(text) && text[end+run] == '`' {
+					end += run + len(text[end+run:]) - len(strings.TrimLeft(text[end+run:], "`"))
+					continue
+				}
+				break
+			}
+			if end >= len(text) {
+				plain.WriteString(delimiter)
+				index += run
+				continue
+			}
+			code := strings.ReplaceAll(text[index+run:end], "\n", " ")
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+				code = code[1 : len(code)-1]
+			}
+			add(code, format|mdCodeSpan, url)
+			index = end + run
+			continue
+		case c == '<':
+			if match := mdAutolinkPattern.FindStringSubmatch(text[index:]); match != nil {
+				target := match[1]
+				if !strings.Contains(target, ":") {
+					target = "mailto:" + target
+				}
+				add(match[1], format|mdLink, target)
+				index += len(match[0])
+				continue
+			}
+		case c == '[' || c == '!' && index+1 < len(text) && text[index+1] == '[':
+			image := c == '!'
+			start := index + 1
+			if image {
+				start++
+			}
+			if label, target, end, ok := p.parseLink(text, start); ok {
+				flush()
+				if image && label == "" {
+					label = "image" // Images are shown as links with their alternative text.
+				}
+				spans = append(spans, p.parseInline(label, format|mdLink, target)...)
+				index = end
+				continue
+			}
+		case c == '*' || c == '_' || c == '~':
+			run := len(text[index:]) - len(strings.TrimLeft(text[index:], string(c)))
+			if c == '~' && run != 2 {
+				break
+			}
+			if end, inner := mdEmphasis(text, index, run); end > 0 {
+				var innerFormat int
+				switch {
+				case c == '~':
+					innerFormat = mdStrike
+				case inner == 1:
+					innerFormat = mdItalic
+				case inner == 2:
+					innerFormat = mdBold
+				default:
+					innerFormat = mdBold | mdItalic
+				}
+				flush()
+				spans = append(spans, p.parseInline(text[index+inner:end], format|innerFormat, url)...)
+				index = end + inner
+				continue
+			}
+			plain.WriteString(text[index : index+run])
+			index += run
+			continue
+		}
+		plain.WriteByte(c)
+		index++
+	}
+	flush()
+	return
+}
+
+// parseLink parses a link or image starting after the opening bracket at the
+// given position. It returns the link text, the link target, and the position
+// after the link.
+func (p *mdParser) parseLink(text string, start int) (label, target string, end int, ok bool) {
+	// Find the closing bracket.
+	depth := 1
+	close := -1
+	for index := start; index < len(text) && close < 0; index++ {
+		switch text[index] {
+		case '\\':
+			index++
+		case '`':
+			if next := strings.IndexByte(text[index+1:], '`'); next >= 0 {
+				index += next + 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				close = index
+			}
+		}
+	}
+	if close < 0 {
+		return
+	}
+	label = text[start:close]
+
+	// Inline link.
+	if close+1 < len(text) && text[close+1] == '(' {
+		rest := text[close+2:]
+		closeParen := -1
+		depth := 1
+		for index := 0; index < len(rest); index++ {
+			switch rest[index] {
+			case '\\':
+				index++
+			case '(':
+				depth++
+			case ')':
+				depth--
+				if depth == 0 {
+					closeParen = index
+				}
+			}
+			if closeParen >= 0 {
+				break
+			}
+		}
+		if closeParen < 0 {
+			return
+		}
+		destination := strings.TrimSpace(rest[:closeParen])
+		if strings.HasPrefix(destination, "<") {
+			if end := strings.IndexByte(destination, '>'); end > 0 {
+				destination = destination[1:end]
+			}
+		} else if space := strings.IndexAny(destination, " \t\n"); space >= 0 {
+			destination = destination[:space] // Strip the title.
+		}
+		return label, destination, close + 2 + closeParen + 1, true
+	}
+
+	// Reference links: [text][label], [label][], and [label].
+	reference, end := label, close+1
+	if close+1 < len(text) && text[close+1] == '[' {
+		if closeRef := strings.IndexByte(text[close+2:], ']'); closeRef >= 0 {
+			if ref := text[close+2 : close+2+closeRef]; ref != "" {
+				reference = ref
+			}
+			end = close + 2 + closeRef + 1
+		}
+	}
+	target, ok = p.definitions[strings.ToLower(strings.Join(strings.Fields(reference), " "))]
+	return label, target, end, ok
+}
+
+// mdEmphasis finds the end of emphasis starting with a delimiter run of the
+// given length at the given position. It returns the position of the closing
+// delimiter run and the number of delimiter characters used (up to 3), or 0 if
+// the delimiter run does not open emphasis.
+func mdEmphasis(text string, start, run int) (end, used int) {
+	c := text[start]
+	after := start + run
+	if after >= len(text) || text[after] == ' ' || text[after] == '\n' {
+		return 0, 0 // Not left-flanking.
+	}
+	if c == '_' && start > 0 && mdWordChar(text[start-1]) {
+		return 0, 0 // Intraword underscores don't open emphasis.
+	}
+	used = min(run, 3)
+	for index := after; index < len(text); index++ {
+		switch text[index] {
+		case '\\':
+			index++
+			continue
+		case '`':
+			if next := strings.IndexByte(text[index+1:], '`'); next >= 0 {
+				index += next + 1
+			}
+			continue
+		case c:
+		default:
+			continue
+		}
+		closeRun := len(text[index:]) - len(strings.TrimLeft(text[index:], string(c)))
+		if closeRun >= used && index > after && text[index-1] != ' ' && text[index-1] != '\n' &&
+			(c != '_' || index+closeRun >= len(text) || !mdWordChar(text[index+closeRun])) {
+			return index + closeRun - used, used
+		}
+		index += closeRun - 1
+	}
+	return 0, 0
+}
+
+// mdWordChar returns whether the given byte is part of a word.
+func mdWordChar(c byte) bool {
+	return c >= utf8.RuneSelf || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// mdSlug returns the identifier of a heading with the given text, in the
+// style of GitHub: lower case, with spaces replaced by hyphens and most
+// punctuation removed.
+func mdSlug(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
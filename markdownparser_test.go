@@ -0,0 +1,47 @@
+package tview
+
+import "testing"
+
+// TestMarkdownEmptyListItems tests that empty list items are parsed into empty
+// item blocks.
+func TestMarkdownEmptyListItems(t *testing.T) {
+	for _, test := range []struct {
+		text    string
+		ordered bool
+		items   int
+	}{
+		{"-", false, 1},
+		{"* ", false, 1},
+		{"- a\n-", false, 2},
+		{"-\n-\n", false, 2},
+		{"1.", true, 1},
+		{"1.\n", true, 1},
+		{"1) a\n2)", true, 2},
+	} {
+		blocks, _ := parseMarkdown(test.text)
+		if len(blocks) != 1 || blocks[0].kind != mdList {
+			t.Errorf("%q: expected a single list, got %d blocks", test.text, len(blocks))
+			continue
+		}
+		list := blocks[0]
+		if list.ordered != test.ordered {
+			t.Errorf("%q: expected ordered=%t, got %t", test.text, test.ordered, list.ordered)
+		}
+		if len(list.children) != test.items {
+			t.Errorf("%q: expected %d items, got %d", test.text, test.items, len(list.children))
+			continue
+		}
+		last := list.children[len(list.children)-1]
+		if last.kind != mdItem || last.task != -1 || len(last.children) != 0 {
+			t.Errorf("%q: expected an empty non-task item, got kind %d, task %d, %d children", test.text, last.kind, last.task, len(last.children))
+		}
+	}
+}
+
+// TestMarkdownEmptyListItemsSetText tests that empty list items don't crash
+// the Markdown primitive.
+func TestMarkdownEmptyListItemsSetText(t *testing.T) {
+	for _, text := range []string{"-", "* ", "1.\n", "- a\n-", "- [ ]"} {
+		NewMarkdown().SetText(text)
+	}
+}
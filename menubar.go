@@ -0,0 +1,1180 @@
+package tview
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// MenuItem represents one entry of a [MenuBar]. Top-level items are shown in
+// the bar itself, their child items are shown in a pull-down menu when the
+// top-level item is activated. Child items which have child items of their own
+// open a submenu.
+//
+// An item may also be a separator (see [NewMenuSeparator]), a checkable item
+// (see [MenuItem.SetCheckable]), or a radio item (see
+// [MenuItem.SetRadioGroup]).
+type MenuItem struct {
+	// The item's text. May contain style tags.
+	text string
+
+	// The character which selects this item when typed while its menu is open
+	// (or together with the Alt key for top-level items). 0 if there is none.
+	mnemonic rune
+
+	// The key which selects this item even when its menu is closed.
+	hasShortcut  bool
+	shortcutKey  tcell.Key
+	shortcutRune rune
+	shortcutMod  tcell.ModMask
+
+	// The text shown next to the item's text. If empty, it is derived from
+	// the shortcut key.
+	shortcutLabel string
+
+	// Whether or not this item is a separator line.
+	separator bool
+
+	// Whether or not this item toggles its checked state when selected.
+	checkable bool
+
+	// The name of the item's radio group. Selecting a radio item checks it and
+	// unchecks all other items of the same menu in the same radio group.
+	radioGroup string
+
+	// Whether or not this item is checked.
+	checked bool
+
+	// Whether or not this item can be selected.
+	disabled bool
+
+	// The child items. If there are any, selecting this item opens a submenu.
+	items []*MenuItem
+
+	// The reference object.
+	reference interface{}
+
+	// An optional function which is called when the user selects this item.
+	selected func()
+}
+
+// NewMenuItem returns a new menu item with the given text.
+func NewMenuItem(text string) *MenuItem {
+	return &MenuItem{
+		text: text,
+	}
+}
+
+// NewMenuSeparator returns a new menu item which is drawn as a horizontal line
+// and which cannot be selected.
+func NewMenuSeparator() *MenuItem {
+	return &MenuItem{
+		separator: true,
+	}
+}
+
+// SetText sets the item's text.
+func (i *MenuItem) SetText(text string) *MenuItem {
+	i.text = text
+	return i
+}
+
+// GetText returns the item's text.
+func (i *MenuItem) GetText() string {
+	return i.text
+}
+
+// SetMnemonic sets the character which selects this item when it is typed
+// while the item's menu is open. Top-level items are also activated when the
+// character is typed together with the Alt key. The first occurrence of the
+// character in the item's text is underlined. Set to 0 to remove the mnemonic.
+func (i *MenuItem) SetMnemonic(mnemonic rune) *MenuItem {
+	i.mnemonic = mnemonic
+	return i
+}
+
+// GetMnemonic returns the item's mnemonic character or 0 if it has none.
+func (i *MenuItem) GetMnemonic() rune {
+	return i.mnemonic
+}
+
+// SetShortcut sets a key which selects this item even when its menu is not
+// open. The arguments are the same as for [tcell.NewEventKey], for example:
+//
+//	item.SetShortcut(tcell.KeyCtrlS, 0, tcell.ModCtrl)
+//	item.SetShortcut(tcell.KeyRune, 'q', tcell.ModAlt)
+//
+// Unless a label was set with [MenuItem.SetShortcutLabel], a description of
+// the key is shown next to the item's text.
+func (i *MenuItem) SetShortcut(key tcell.Key, ch rune, modifiers tcell.ModMask) *MenuItem {
+	if key >= tcell.KeyCtrlA && key <= tcell.KeyCtrlZ {
+		modifiers |= tcell.ModCtrl
+	}
+	i.hasShortcut = true
+	i.shortcutKey = key
+	i.shortcutRune = ch
+	i.shortcutMod = modifiers
+	return i
+}
+
+// SetShortcutLabel sets the text shown next to the item's text. This is
+// typically a description of the item's shortcut key. If left empty (the
+// default), the label is derived from the key set with
+// [MenuItem.SetShortcut].
+func (i *MenuItem) SetShortcutLabel(label string) *MenuItem {
+	i.shortcutLabel = label
+	return i
+}
+
+// GetShortcutLabel returns the text shown next to the item's text.
+func (i *MenuItem) GetShortcutLabel() string {
+	if i.shortcutLabel != "" || !i.hasShortcut {
+		return i.shortcutLabel
+	}
+	name := tcell.NewEventKey(i.shortcutKey, i.shortcutRune, i.shortcutMod).Name()
+	return strings.Replace(name, "Rune["+string(i.shortcutRune)+"]", string(i.shortcutRune), 1)
+}
+
+// IsSeparator returns whether or not this item is a separator.
+func (i *MenuItem) IsSeparator() bool {
+	return i.separator
+}
+
+// SetCheckable sets whether or not this item toggles its checked state when it
+// is selected. A checkable item shows a check mark when it is checked.
+func (i *MenuItem) SetCheckable(checkable bool) *MenuItem {
+	i.checkable = checkable
+	return i
+}
+
+// SetRadioGroup turns this item into a radio item belonging to the radio group
+// with the given name. Selecting a radio item checks it and unchecks all other
+// items in the same menu which belong to the same radio group. Provide an
+// empty string to turn this item back into a normal item.
+func (i *MenuItem) SetRadioGroup(group string) *MenuItem {
+	i.radioGroup = group
+	return i
+}
+
+// GetRadioGroup returns the name of the item's radio group or an empty string
+// if this is not a radio item.
+func (i *MenuItem) GetRadioGroup() string {
+	return i.radioGroup
+}
+
+// SetChecked sets the checked state of a checkable or radio item. Note that
+// for radio items, this does not uncheck other items of the same group.
+func (i *MenuItem) SetChecked(checked bool) *MenuItem {
+	i.checked = checked
+	return i
+}
+
+// IsChecked returns whether or not this item is checked.
+func (i *MenuItem) IsChecked() bool {
+	return i.checked
+}
+
+// SetDisabled sets whether or not this item is disabled. Disabled items are
+// drawn differently and cannot be selected. The child items of a disabled
+// item cannot be selected either.
+func (i *MenuItem) SetDisabled(disabled bool) *MenuItem {
+	i.disabled = disabled
+	return i
+}
+
+// GetDisabled returns whether or not this item is disabled.
+func (i *MenuItem) GetDisabled() bool {
+	return i.disabled
+}
+
+// AddItem adds a child item to this item. Items with child items open a
+// submenu when selected.
+func (i *MenuItem) AddItem(item *MenuItem) *MenuItem {
+	i.items = append(i.items, item)
+	return i
+}
+
+// SetItems replaces this item's child items with the given ones.
+func (i *MenuItem) SetItems(items []*MenuItem) *MenuItem {
+	i.items = items
+	return i
+}
+
+// GetItems returns this item's child items.
+func (i *MenuItem) GetItems() []*MenuItem {
+	return i.items
+}
+
+// RemoveItem removes the given child item from this item. If the item cannot
+// be found, nothing happens.
+func (i *MenuItem) RemoveItem(item *MenuItem) *MenuItem {
+	for index, child := range i.items {
+		if child == item {
+			i.items = append(i.items[:index], i.items[index+1:]...)
+			break
+		}
+	}
+	return i
+}
+
+// ClearItems removes all child items from this item.
+func (i *MenuItem) ClearItems() *MenuItem {
+	i.items = nil
+	return i
+}
+
+// SetReference allows you to store a reference of any type in this item.
+func (i *MenuItem) SetReference(reference interface{}) *MenuItem {
+	i.reference = reference
+	return i
+}
+
+// GetReference returns this item's reference object.
+func (i *MenuItem) GetReference() interface{} {
+	return i.reference
+}
+
+// SetSelectedFunc sets a function which is called when the user selects this
+// item. It is not called for items with child items.
+func (i *MenuItem) SetSelectedFunc(handler func()) *MenuItem {
+	i.selected = handler
+	return i
+}
+
+// isSelectable returns whether or not the user can navigate to this item.
+func (i *MenuItem) isSelectable() bool {
+	return !i.separator && !i.disabled
+}
+
+// matchesShortcut returns whether the given key event corresponds to the
+// item's shortcut.
+func (i *MenuItem) matchesShortcut(event *tcell.EventKey) bool {
+	if !i.hasShortcut || event.Key() != i.shortcutKey {
+		return false
+	}
+	if i.shortcutKey == tcell.KeyRune && event.Rune() != i.shortcutRune {
+		return false
+	}
+	modifiers := event.Modifiers()
+	if i.shortcutKey >= tcell.KeyCtrlA && i.shortcutKey <= tcell.KeyCtrlZ {
+		modifiers |= tcell.ModCtrl
+	}
+	return modifiers == i.shortcutMod
+}
+
+// matchesMnemonic returns whether the given rune corresponds to the item's
+// mnemonic character.
+func (i *MenuItem) matchesMnemonic(ch rune) bool {
+	return i.mnemonic != 0 && unicode.ToLower(ch) == unicode.ToLower(i.mnemonic)
+}
+
+// menuRect is the position and size of an open pull-down menu.
+type menuRect struct {
+	x, y, width, height int
+}
+
+// MenuBar is a horizontal bar of menus. Each top-level entry opens a pull-down
+// menu which may contain submenus, separators, checkable and radio items, as
+// well as disabled items. Pull-down menus are drawn on top of anything below
+// the menu bar, no [Pages] layer is needed.
+//
+// The menu bar is usually given a content primitive (see
+// [MenuBar.SetContent]) which fills the space below the bar. In this case, the
+// menu bar sees all key events before they are forwarded to the content, so
+// the menus can be opened from anywhere within the content. If the menu bar is
+// used on its own, e.g. as a one-row item of a [Flex], it only receives key
+// events when it has focus.
+//
+// The following keys are available while no menu is open:
+//
+//   - F10: Activate the menu bar (see [MenuBar.SetActivationKey]).
+//   - Alt + mnemonic: Open the top-level menu with that mnemonic (see
+//     [MenuItem.SetMnemonic]).
+//   - Shortcut keys: Select the item with that shortcut (see
+//     [MenuItem.SetShortcut]).
+//
+// And while the menu bar is active:
+//
+//   - Left / right arrow: Move to the previous / next top-level menu, or close
+//     / open a submenu.
+//   - Up / down arrow: Move to the previous / next item of the open menu.
+//   - Home / End: Move to the first / last item of the open menu.
+//   - Enter / Space: Select the current item or open its submenu.
+//   - Mnemonic characters: Select the corresponding item of the open menu.
+//   - Escape: Close the innermost menu or deactivate the menu bar.
+//
+// The menu bar can also be operated entirely with the mouse.
+type MenuBar struct {
+	*Box
+
+	// The top-level items.
+	items []*MenuItem
+
+	// The primitive shown below the bar. May be nil.
+	content Primitive
+
+	// Whether or not the menu bar has taken over keyboard input.
+	active bool
+
+	// The indices of the highlighted items, starting with the highlighted
+	// top-level item. The pull-down menu at level n (starting at 1) is open if
+	// there are more than n elements. An index may be negative if no item of
+	// an open menu is highlighted.
+	selection []int
+
+	// The primitive which had focus before the menu bar was activated.
+	previousFocus Primitive
+
+	// The key which activates the menu bar.
+	activationKey tcell.Key
+
+	// The style of the bar and its highlighted entry.
+	barStyle, barSelectedStyle tcell.Style
+
+	// The style of the pull-down menus and their highlighted item.
+	menuStyle, menuSelectedStyle tcell.Style
+
+	// The style of disabled items.
+	disabledStyle tcell.Style
+
+	// The style of shortcut labels.
+	shortcutStyle tcell.Style
+
+	// The strings drawn in front of checkable items.
+	checkedString, uncheckedString string
+
+	// The strings drawn in front of radio items.
+	radioCheckedString, radioUncheckedString string
+
+	// The horizontal positions and widths of the top-level entries, as
+	// determined during the last call to Draw().
+	barPositions [][2]int
+
+	// The positions of the open pull-down menus, as determined during the last
+	// call to Draw().
+	menuRects []menuRect
+
+	// Set to true if an item was selected with a mouse up event so the
+	// following click event is not forwarded to other primitives.
+	swallowClick bool
+
+	// Keep a reference in case we need it when we change the content.
+	setFocus func(p Primitive)
+
+	// An optional function which is called when the user selects an item.
+	selected func(item *MenuItem)
+}
+
+// NewMenuBar returns a new [MenuBar] without any menus.
+func NewMenuBar() *MenuBar {
+	m := &MenuBar{
+		Box:                  NewBox(),
+		activationKey:        tcell.KeyF10,
+		barStyle:             tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		barSelectedStyle:     tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
+		menuStyle:            tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		menuSelectedStyle:    tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
+		disabledStyle:        tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.ContrastSecondaryTextColor),
+		shortcutStyle:        tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.SecondaryTextColor),
+		checkedString:        "✓",
+		uncheckedString:      " ",
+		radioCheckedString:   "●",
+		radioUncheckedString: "○",
+	}
+	m.Box.Primitive = m
+	return m
+}
+
+// AddItem adds a top-level item to the menu bar. Its child items make up its
+// pull-down menu. Top-level items without child items are selected directly
+// when activated.
+func (m *MenuBar) AddItem(item *MenuItem) *MenuBar {
+	m.items = append(m.items, item)
+	return m
+}
+
+// SetItems replaces all top-level items with the given ones.
+func (m *MenuBar) SetItems(items []*MenuItem) *MenuBar {
+	m.items = items
+	m.selection = nil
+	return m
+}
+
+// GetItems returns the top-level items.
+func (m *MenuBar) GetItems() []*MenuItem {
+	return m.items
+}
+
+// SetContent sets the primitive which fills the space below the bar. Set to
+// nil to remove it. Pull-down menus are drawn on top of this primitive.
+func (m *MenuBar) SetContent(p Primitive) *MenuBar {
+	var hasFocus bool
+	if m.content != nil {
+		hasFocus = m.content.HasFocus()
+	}
+	m.content = p
+	if hasFocus && p != nil && m.setFocus != nil {
+		m.setFocus(p) // Restore focus.
+	}
+	return m
+}
+
+// GetContent returns the primitive shown below the bar.
+func (m *MenuBar) GetContent() Primitive {
+	return m.content
+}
+
+// SetActivationKey sets the key which activates the menu bar without opening a
+// pull-down menu. The default is F10.
+func (m *MenuBar) SetActivationKey(key tcell.Key) *MenuBar {
+	m.activationKey = key
+	return m
+}
+
+// SetBarStyles sets the style of the bar and the style of its highlighted
+// entry.
+func (m *MenuBar) SetBarStyles(normal, selected tcell.Style) *MenuBar {
+	m.barStyle = normal
+	m.barSelectedStyle = selected
+	return m
+}
+
+// SetMenuStyles sets the style of the pull-down menus (including their
+// borders) and the style of their highlighted item.
+func (m *MenuBar) SetMenuStyles(normal, selected tcell.Style) *MenuBar {
+	m.menuStyle = normal
+	m.menuSelectedStyle = selected
+	return m
+}
+
+// SetDisabledStyle sets the style of disabled items.
+func (m *MenuBar) SetDisabledStyle(style tcell.Style) *MenuBar {
+	m.disabledStyle = style
+	return m
+}
+
+// SetShortcutStyle sets the style of the shortcut labels shown next to the
+// item texts (unless the item is highlighted or disabled).
+func (m *MenuBar) SetShortcutStyle(style tcell.Style) *MenuBar {
+	m.shortcutStyle = style
+	return m
+}
+
+// SetCheckStrings sets the strings drawn in front of checked and unchecked
+// checkable items (defaults to "✓" and " "). The strings may contain style
+// tags.
+func (m *MenuBar) SetCheckStrings(checked, unchecked string) *MenuBar {
+	m.checkedString = checked
+	m.uncheckedString = unchecked
+	return m
+}
+
+// SetRadioStrings sets the strings drawn in front of checked and unchecked
+// radio items (defaults to "●" and "○"). The strings may contain style tags.
+func (m *MenuBar) SetRadioStrings(checked, unchecked string) *MenuBar {
+	m.radioCheckedString = checked
+	m.radioUncheckedString = unchecked
+	return m
+}
+
+// SetSelectedFunc sets a function which is called when the user selects an
+// item which has no child items. It is called after the item's own "selected"
+// callback and after the state of checkable and radio items has been updated.
+func (m *MenuBar) SetSelectedFunc(handler func(item *MenuItem)) *MenuBar {
+	m.selected = handler
+	return m
+}
+
+// IsActive returns whether or not the menu bar currently receives keyboard
+// input, i.e. whether a menu is open or the bar is highlighted.
+func (m *MenuBar) IsActive() bool {
+	return m.active
+}
+
+// menuItems returns the items of the pull-down menu at the given level (1 for
+// the menu of a top-level item) along with their parent item, based on the
+// current selection.
+func (m *MenuBar) menuItems(level int) (parent *MenuItem, items []*MenuItem) {
+	parent = m.items[m.selection[0]]
+	for index := 1; index < level; index++ {
+		parent = parent.items[m.selection[index]]
+	}
+	return parent, parent.items
+}
+
+// nextSelectable returns the index of the first selectable item of the given
+// items, starting at index "from" and moving in the given direction (1 or -1),
+// wrapping around if necessary. Returns -1 if there is no such item.
+func nextSelectable(items []*MenuItem, from, direction int) int {
+	for count := 0; count < len(items); count++ {
+		index := ((from+direction*count)%len(items) + len(items)) % len(items)
+		if items[index].isSelectable() {
+			return index
+		}
+	}
+	return -1
+}
+
+// openTop highlights the top-level item with the given index and opens its
+// pull-down menu if it has one.
+func (m *MenuBar) openTop(index int) {
+	m.selection = []int{index}
+	if items := m.items[index].items; len(items) > 0 {
+		m.selection = append(m.selection, nextSelectable(items, 0, 1))
+	}
+}
+
+// openSubmenu opens the submenu of the item currently highlighted in the
+// innermost open menu, if it has one. Returns true if a submenu was opened.
+func (m *MenuBar) openSubmenu() bool {
+	level := len(m.selection) - 1
+	if level < 1 {
+		return false
+	}
+	_, items := m.menuItems(level)
+	index := m.selection[level]
+	if index < 0 || !items[index].isSelectable() || len(items[index].items) == 0 {
+		return false
+	}
+	m.selection = append(m.selection, nextSelectable(items[index].items, 0, 1))
+	return true
+}
+
+// activate hands keyboard input over to the menu bar.
+func (m *MenuBar) activate(setFocus func(p Primitive)) {
+	if m.active {
+		return
+	}
+	m.active = true
+	m.previousFocus = nil
+	if m.content != nil {
+		chain := make([]Primitive, 0, 10)
+		if m.content.focusChain(&chain) && len(chain) > 0 {
+			m.previousFocus = chain[0]
+		}
+	}
+	if !m.hasFocus {
+		setFocus(m)
+	}
+}
+
+// deactivate closes all menus and returns keyboard input to the primitive
+// which had focus before the menu bar was activated.
+func (m *MenuBar) deactivate(setFocus func(p Primitive)) {
+	if !m.active {
+		return
+	}
+	m.active = false
+	m.selection = nil
+	previousFocus := m.previousFocus
+	m.previousFocus = nil
+	if previousFocus != nil {
+		setFocus(previousFocus)
+	} else if m.content != nil {
+		setFocus(m.content)
+	}
+}
+
+// selectItem selects the given item, updating its checked state and invoking
+// the "selected" callbacks. The parent may be nil for top-level items.
+func (m *MenuBar) selectItem(parent, item *MenuItem) {
+	if item.radioGroup != "" {
+		siblings := m.items
+		if parent != nil {
+			siblings = parent.items
+		}
+		for _, sibling := range siblings {
+			if sibling.radioGroup == item.radioGroup {
+				sibling.checked = false
+			}
+		}
+		item.checked = true
+	} else if item.checkable {
+		item.checked = !item.checked
+	}
+	if item.selected != nil {
+		item.selected()
+	}
+	if m.selected != nil {
+		m.selected(item)
+	}
+}
+
+// findShortcut returns the selectable item whose shortcut matches the given
+// key event, along with its parent (nil for top-level items). Items of
+// disabled menus are not considered.
+func (m *MenuBar) findShortcut(event *tcell.EventKey) (parent, item *MenuItem) {
+	var find func(parent *MenuItem, items []*MenuItem) (*MenuItem, *MenuItem)
+	find = func(parent *MenuItem, items []*MenuItem) (*MenuItem, *MenuItem) {
+		for _, item := range items {
+			if !item.isSelectable() {
+				continue
+			}
+			if len(item.items) > 0 {
+				if p, i := find(item, item.items); i != nil {
+					return p, i
+				}
+			} else if item.matchesShortcut(event) {
+				return parent, item
+			}
+		}
+		return nil, nil
+	}
+	return find(nil, m.items)
+}
+
+// findTopMnemonic returns the index of the selectable top-level item with the
+// given mnemonic or -1 if there is none.
+func (m *MenuBar) findTopMnemonic(ch rune) int {
+	for index, item := range m.items {
+		if item.isSelectable() && item.matchesMnemonic(ch) {
+			return index
+		}
+	}
+	return -1
+}
+
+// Draw draws this primitive onto the screen.
+func (m *MenuBar) Draw(screen tcell.Screen) {
+	m.Box.DrawForSubclass(screen, m)
+	x, y, width, height := m.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Draw the bar.
+	for index := 0; index < width; index++ {
+		screen.SetContent(x+index, y, ' ', nil, m.barStyle)
+	}
+	m.barPositions = m.barPositions[:0]
+	pos := x + 1
+	for index, item := range m.items {
+		label := " " + item.text + " "
+		labelWidth := TaggedStringWidth(label)
+		style := m.barStyle
+		if item.disabled {
+			style = m.disabledStyle
+		} else if m.active && len(m.selection) > 0 && m.selection[0] == index {
+			style = m.barSelectedStyle
+		}
+		if pos < x+width {
+			printWithStyle(screen, label, pos, y, 0, x+width-pos, AlignLeft, style, false)
+			underlineMnemonic(screen, item.text, pos+1, y, x+width-pos-1, item.mnemonic)
+		}
+		m.barPositions = append(m.barPositions, [2]int{pos, labelWidth})
+		pos += labelWidth
+	}
+
+	// Draw the content.
+	if m.content != nil && height > 1 {
+		m.content.SetRect(x, y+1, width, height-1)
+		m.content.Draw(screen)
+	}
+
+	// Draw the open menus on top.
+	m.menuRects = m.menuRects[:0]
+	if !m.active || len(m.selection) == 0 || m.selection[0] >= len(m.barPositions) {
+		return
+	}
+	screenWidth, screenHeight := screen.Size()
+	menuX, menuY := m.barPositions[m.selection[0]][0], y+1
+	for level := 1; level < len(m.selection); level++ {
+		_, items := m.menuItems(level)
+		layout := m.layoutMenu(items)
+		menuWidth, menuHeight := layout.width(), len(items)+2
+		if level > 1 {
+			parent := m.menuRects[level-2]
+			menuX = parent.x + parent.width
+			menuY = parent.y + m.selection[level-1]
+			if menuX+menuWidth > screenWidth {
+				menuX = parent.x - menuWidth // No space to the right. Open to the left.
+			}
+		}
+		if menuX+menuWidth > screenWidth {
+			menuX = screenWidth - menuWidth
+		}
+		if menuX < 0 {
+			menuX = 0
+		}
+		if menuY+menuHeight > screenHeight {
+			menuY = screenHeight - menuHeight
+		}
+		if menuY < 0 {
+			menuY = 0
+		}
+		rect := menuRect{x: menuX, y: menuY, width: menuWidth, height: menuHeight}
+		m.menuRects = append(m.menuRects, rect)
+		m.drawMenu(screen, items, rect, layout, m.selection[level])
+	}
+}
+
+// menuLayout describes the widths of the columns of a pull-down menu.
+type menuLayout struct {
+	check, text, shortcut, arrow int
+}
+
+// width returns the total width of a pull-down menu with this layout,
+// including its border.
+func (l menuLayout) width() int {
+	return 4 + l.check + l.text + l.shortcut + l.arrow
+}
+
+// layoutMenu calculates the column widths of a pull-down menu with the given
+// items.
+func (m *MenuBar) layoutMenu(items []*MenuItem) (layout menuLayout) {
+	for _, item := range items {
+		if item.separator {
+			continue
+		}
+		if item.checkable || item.radioGroup != "" {
+			for _, str := range []string{m.checkedString, m.uncheckedString, m.radioCheckedString, m.radioUncheckedString} {
+				if width := TaggedStringWidth(str) + 1; width > layout.check {
+					layout.check = width
+				}
+			}
+		}
+		if width := TaggedStringWidth(item.text); width > layout.text {
+			layout.text = width
+		}
+		if width := TaggedStringWidth(item.GetShortcutLabel()); width > 0 && width+2 > layout.shortcut {
+			layout.shortcut = width + 2
+		}
+		if len(item.items) > 0 {
+			layout.arrow = 2
+		}
+	}
+	return
+}
+
+// drawMenu draws one pull-down menu at the given position. "current" is the
+// index of the highlighted item.
+func (m *MenuBar) drawMenu(screen tcell.Screen, items []*MenuItem, rect menuRect, layout menuLayout, current int) {
+	// Background and border.
+	right, bottom := rect.x+rect.width-1, rect.y+rect.height-1
+	for y := rect.y; y <= bottom; y++ {
+		for x := rect.x; x <= right; x++ {
+			ch := ' '
+			switch {
+			case x == rect.x && y == rect.y:
+				ch = Borders.TopLeft
+			case x == right && y == rect.y:
+				ch = Borders.TopRight
+			case x == rect.x && y == bottom:
+				ch = Borders.BottomLeft
+			case x == right && y == bottom:
+				ch = Borders.BottomRight
+			case y == rect.y || y == bottom:
+				ch = Borders.Horizontal
+			case x == rect.x || x == right:
+				ch = Borders.Vertical
+			}
+			screen.SetContent(x, y, ch, nil, m.menuStyle)
+		}
+	}
+
+	// Items.
+	for index, item := range items {
+		y := rect.y + 1 + index
+		if y >= bottom {
+			break
+		}
+		if item.separator {
+			screen.SetContent(rect.x, y, Borders.LeftT, nil, m.menuStyle)
+			for x := rect.x + 1; x < right; x++ {
+				screen.SetContent(x, y, Borders.Horizontal, nil, m.menuStyle)
+			}
+			screen.SetContent(right, y, Borders.RightT, nil, m.menuStyle)
+			continue
+		}
+		style, shortcutStyle := m.menuStyle, m.shortcutStyle
+		if item.disabled {
+			style, shortcutStyle = m.disabledStyle, m.disabledStyle
+		} else if index == current {
+			style, shortcutStyle = m.menuSelectedStyle, m.menuSelectedStyle
+		}
+		for x := rect.x + 1; x < right; x++ {
+			screen.SetContent(x, y, ' ', nil, style)
+		}
+		x := rect.x + 2
+		if item.checkable || item.radioGroup != "" {
+			str := m.uncheckedString
+			if item.radioGroup != "" {
+				str = m.radioUncheckedString
+				if item.checked {
+					str = m.radioCheckedString
+				}
+			} else if item.checked {
+				str = m.checkedString
+			}
+			printWithStyle(screen, str, x, y, 0, layout.check, AlignLeft, style, false)
+		}
+		x += layout.check
+		printWithStyle(screen, item.text, x, y, 0, layout.text, AlignLeft, style, false)
+		underlineMnemonic(screen, item.text, x, y, layout.text, item.mnemonic)
+		x += layout.text
+		if label := item.GetShortcutLabel(); label != "" {
+			printWithStyle(screen, label, x+2, y, 0, layout.shortcut-2, AlignRight, shortcutStyle, false)
+		}
+		x += layout.shortcut
+		if len(item.items) > 0 {
+			printWithStyle(screen, "▶", x+1, y, 0, 1, AlignLeft, style, false)
+		}
+	}
+}
+
+// underlineMnemonic underlines the first occurrence of the mnemonic character
+// in the given text which was printed at the given position.
+func underlineMnemonic(screen tcell.Screen, text string, x, y, maxWidth int, mnemonic rune) {
+	if mnemonic == 0 {
+		return
+	}
+	var (
+		state  *stepState
+		offset int
+		ch     string
+	)
+	for len(text) > 0 && offset < maxWidth {
+		ch, text, state = step(text, state, stepOptionsStyle)
+		if strings.EqualFold(ch, string(mnemonic)) {
+			mainc, combc, style, _ := screen.GetContent(x+offset, y)
+			screen.SetContent(x+offset, y, mainc, combc, style.Underline(true))
+			return
+		}
+		offset += state.Width()
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (m *MenuBar) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return m.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		key := event.Key()
+		if !m.active {
+			// Keys which activate the menu bar.
+			if key == m.activationKey {
+				if index := nextSelectable(m.items, 0, 1); index >= 0 {
+					m.activate(setFocus)
+					m.selection = []int{index}
+				}
+				return
+			}
+			if key == tcell.KeyRune && event.Modifiers()&tcell.ModAlt != 0 {
+				if index := m.findTopMnemonic(event.Rune()); index >= 0 {
+					m.activate(setFocus)
+					m.openTop(index)
+					return
+				}
+			}
+			if parent, item := m.findShortcut(event); item != nil {
+				m.selectItem(parent, item)
+				return
+			}
+
+			// Forward everything else to the content.
+			if m.content != nil && m.content.HasFocus() {
+				if handler := m.content.InputHandler(); handler != nil {
+					handler(event, setFocus)
+				}
+				return
+			}
+
+			// The bar itself has focus. Enter and the down arrow open the first
+			// menu.
+			if key != tcell.KeyEnter && key != tcell.KeyDown {
+				return
+			}
+			index := nextSelectable(m.items, 0, 1)
+			if index < 0 {
+				return
+			}
+			m.activate(setFocus)
+			m.selection = []int{index}
+		}
+
+		// The menu bar is active.
+		level := len(m.selection) - 1
+		if level < 0 {
+			m.deactivate(setFocus)
+			return
+		}
+		moveTop := func(direction int) {
+			index := nextSelectable(m.items, m.selection[0]+direction, direction)
+			if index < 0 {
+				return
+			}
+			if level > 0 {
+				m.openTop(index)
+			} else {
+				m.selection = []int{index}
+			}
+		}
+		enter := func() {
+			if level == 0 {
+				item := m.items[m.selection[0]]
+				if len(item.items) > 0 {
+					m.openTop(m.selection[0])
+				} else {
+					m.deactivate(setFocus)
+					m.selectItem(nil, item)
+				}
+				return
+			}
+			parent, items := m.menuItems(level)
+			index := m.selection[level]
+			if index < 0 || !items[index].isSelectable() {
+				return
+			}
+			if !m.openSubmenu() {
+				m.deactivate(setFocus)
+				m.selectItem(parent, items[index])
+			}
+		}
+
+		switch key {
+		case m.activationKey:
+			m.deactivate(setFocus)
+		case tcell.KeyEscape:
+			if level == 0 {
+				m.deactivate(setFocus)
+			} else {
+				m.selection = m.selection[:level]
+			}
+		case tcell.KeyLeft:
+			if level >= 2 {
+				m.selection = m.selection[:level]
+			} else {
+				moveTop(-1)
+			}
+		case tcell.KeyRight:
+			if !m.openSubmenu() {
+				moveTop(1)
+			}
+		case tcell.KeyDown, tcell.KeyUp:
+			direction := 1
+			if key == tcell.KeyUp {
+				direction = -1
+			}
+			if level == 0 {
+				if len(m.items[m.selection[0]].items) > 0 {
+					m.openTop(m.selection[0])
+					if direction < 0 {
+						m.selection[1] = nextSelectable(m.items[m.selection[0]].items, -1, -1)
+					}
+				}
+				break
+			}
+			_, items := m.menuItems(level)
+			from := m.selection[level] + direction
+			if m.selection[level] < 0 && direction > 0 {
+				from = 0
+			}
+			m.selection[level] = nextSelectable(items, from, direction)
+		case tcell.KeyHome, tcell.KeyEnd:
+			if level > 0 {
+				_, items := m.menuItems(level)
+				if key == tcell.KeyHome {
+					m.selection[level] = nextSelectable(items, 0, 1)
+				} else {
+					m.selection[level] = nextSelectable(items, -1, -1)
+				}
+			}
+		case tcell.KeyEnter:
+			enter()
+		case tcell.KeyRune:
+			ch := event.Rune()
+			if ch == ' ' {
+				enter()
+				break
+			}
+			if level == 0 || event.Modifiers()&tcell.ModAlt != 0 {
+				if index := m.findTopMnemonic(ch); index >= 0 {
+					m.openTop(index)
+				}
+				break
+			}
+			_, items := m.menuItems(level)
+			for index, item := range items {
+				if item.isSelectable() && item.matchesMnemonic(ch) {
+					m.selection[level] = index
+					enter()
+					break
+				}
+			}
+		}
+	})
+}
+
+// barIndexAtPoint returns the index of the top-level item at the given
+// position or -1 if there is none.
+func (m *MenuBar) barIndexAtPoint(x, y int) int {
+	_, barY, _, _ := m.GetInnerRect()
+	if y != barY {
+		return -1
+	}
+	for index, position := range m.barPositions {
+		if x >= position[0] && x < position[0]+position[1] {
+			return index
+		}
+	}
+	return -1
+}
+
+// menuItemAtPoint returns the level of the open pull-down menu at the given
+// position (0 if there is none) and the index of the item at that position
+// (-1 if there is none).
+func (m *MenuBar) menuItemAtPoint(x, y int) (level, index int) {
+	for l := len(m.menuRects); l > 0; l-- {
+		rect := m.menuRects[l-1]
+		if l >= len(m.selection) {
+			continue // Outdated position.
+		}
+		if x < rect.x || x >= rect.x+rect.width || y < rect.y || y >= rect.y+rect.height {
+			continue
+		}
+		if y == rect.y || y == rect.y+rect.height-1 {
+			return l, -1
+		}
+		return l, y - rect.y - 1
+	}
+	return 0, -1
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (m *MenuBar) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return m.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		x, y := event.Position()
+
+		// Swallow the click following a selection on mouse up.
+		if m.swallowClick {
+			m.swallowClick = false
+			if action == MouseLeftClick {
+				return true, nil
+			}
+		}
+
+		barIndex := m.barIndexAtPoint(x, y)
+		if !m.active {
+			// Open a menu when its bar entry is pressed.
+			if barIndex >= 0 && action == MouseLeftDown && m.items[barIndex].isSelectable() {
+				m.activate(setFocus)
+				m.openTop(barIndex)
+				return true, m
+			}
+
+			// Other events on the bar are not forwarded.
+			_, barY, _, _ := m.GetInnerRect()
+			if y == barY && m.InRect(x, y) {
+				return action != MouseMove, nil
+			}
+
+			// Pass everything else on to the content.
+			if m.content != nil {
+				return m.content.MouseHandler()(action, event, setFocus)
+			}
+			return false, nil
+		}
+
+		// As long as the menu bar is active, we capture all mouse events.
+		consumed, capture = true, m
+		level, index := m.menuItemAtPoint(x, y)
+		switch action {
+		case MouseMove:
+			if barIndex >= 0 {
+				if len(m.selection) > 1 && barIndex != m.selection[0] && m.items[barIndex].isSelectable() {
+					m.openTop(barIndex)
+				}
+				break
+			}
+			if level == 0 || index < 0 || m.selection[level] == index && len(m.selection) > level+1 {
+				break
+			}
+			_, items := m.menuItems(level)
+			if !items[index].isSelectable() {
+				break
+			}
+			m.selection = append(m.selection[:level], index)
+			m.openSubmenu()
+		case MouseLeftDown:
+			if barIndex >= 0 {
+				if barIndex == m.selection[0] && len(m.selection) > 1 {
+					m.deactivate(setFocus) // Clicking an open menu's entry closes it.
+				} else if m.items[barIndex].isSelectable() {
+					m.openTop(barIndex)
+				}
+			} else if level == 0 {
+				m.deactivate(setFocus) // Clicked outside of the menus.
+			}
+		case MouseLeftUp, MouseLeftClick:
+			var parent, item *MenuItem
+			if barIndex >= 0 {
+				if top := m.items[barIndex]; len(top.items) == 0 && top.isSelectable() {
+					item = top
+				}
+			} else if level > 0 && index >= 0 {
+				var items []*MenuItem
+				parent, items = m.menuItems(level)
+				if items[index].isSelectable() {
+					m.selection = append(m.selection[:level], index)
+					if !m.openSubmenu() {
+						item = items[index]
+					}
+				}
+			}
+			if item != nil {
+				m.deactivate(setFocus)
+				m.selectItem(parent, item)
+				if action == MouseLeftUp {
+					m.swallowClick = true
+					return true, m
+				}
+			}
+		}
+		if !m.active {
+			capture = nil
+		}
+		return
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (m *MenuBar) PasteHandler() func(pastedText string, setFocus func(p Primitive)) {
+	return m.WrapPasteHandler(func(pastedText string, setFocus func(p Primitive)) {
+		if m.active || m.content == nil || !m.content.HasFocus() {
+			return
+		}
+		if handler := m.content.PasteHandler(); handler != nil {
+			handler(pastedText, setFocus)
+		}
+	})
+}
+
+// Focus is called when this primitive receives focus.
+func (m *MenuBar) Focus(delegate func(p Primitive)) {
+	m.setFocus = delegate
+	if !m.active && m.content != nil && delegate != nil {
+		delegate(m.content)
+		return
+	}
+	m.Box.Focus(delegate)
+}
+
+// Blur is called when this primitive loses focus.
+func (m *MenuBar) Blur() {
+	m.active = false
+	m.selection = nil
+	m.previousFocus = nil
+	m.Box.Blur()
+}
+
+// focusChain implements the [Primitive]'s focusChain method.
+func (m *MenuBar) focusChain(chain *[]Primitive) bool {
+	if m.content != nil {
+		if hasFocus := m.content.focusChain(chain); hasFocus {
+			if chain != nil {
+				*chain = append(*chain, m)
+			}
+			return true
+		}
+	}
+	return m.Box.focusChain(chain)
+}
@@ -0,0 +1,467 @@
+package tview
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// NumberField is a one-line form item for entering a number. The number is
+// limited to a range (see [NumberField.SetRange]) and rounded to a number of
+// decimal places (see [NumberField.SetPrecision]). How numbers are displayed
+// and how entered text is converted to a number can be customized (see
+// [NumberField.SetFormatFunc] and [NumberField.SetParseFunc]).
+//
+// Typing replaces the displayed number with the entered text, Backspace edits
+// the displayed number. The entered text is applied when the user presses
+// Enter, Tab, Backtab, or an arrow key, or when the field loses focus. If the
+// text cannot be converted to a number, the previous number is restored.
+//
+// The following keys are available:
+//
+//   - Up arrow, Down arrow: Increase or decrease the number by one step (see
+//     [NumberField.SetStep]).
+//   - Page up, page down: Increase or decrease the number by ten steps.
+//   - Escape: Discard the entered text. Done (see [NumberField.SetDoneFunc]).
+//   - Tab, Backtab: Done.
+//
+// With the mouse, use the scroll wheel to change the number.
+type NumberField struct {
+	*Box
+
+	// Whether or not this number field is disabled/read-only.
+	disabled bool
+
+	// The current value.
+	value float64
+
+	// The range of values.
+	minValue, maxValue float64
+
+	// The step by which the arrow keys change the value.
+	step float64
+
+	// The number of decimal places of the value. If negative, it is derived
+	// from the step.
+	precision int
+
+	// Whether or not the user is entering text.
+	editing bool
+
+	// The text entered by the user.
+	text string
+
+	// Optional functions which convert between values and text.
+	format func(value float64) string
+	parse  func(text string) (float64, error)
+
+	// The text to be displayed before the input area.
+	label string
+
+	// The screen width of the label area. A value of 0 means use the width of
+	// the label text.
+	labelWidth int
+
+	// The screen width of the input area. A value of 0 means derive it from
+	// the range.
+	fieldWidth int
+
+	// Styles.
+	labelStyle, fieldStyle, focusedStyle, disabledStyle tcell.Style
+
+	// An optional function which is called when the value changes.
+	changed func(value float64)
+
+	// An optional function which is called when the user leaves the number
+	// field.
+	done func(key tcell.Key)
+}
+
+// NewNumberField returns a new number field with an unlimited range and a step
+// of 1.
+func NewNumberField() *NumberField {
+	box := NewBox()
+	n := &NumberField{
+		Box:           box,
+		minValue:      math.Inf(-1),
+		maxValue:      math.Inf(1),
+		step:          1,
+		precision:     -1,
+		labelStyle:    tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		fieldStyle:    tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		focusedStyle:  tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
+		disabledStyle: tcell.StyleDefault.Background(box.backgroundColor).Foreground(Styles.SecondaryTextColor),
+	}
+	n.Box.Primitive = n
+	return n
+}
+
+// SetValue sets the number field's value. It is limited to the range and
+// rounded to the precision. Any text entered by the user is discarded. This
+// also triggers the "changed" callback if the value changes with this call.
+// NaN values are ignored.
+func (n *NumberField) SetValue(value float64) *NumberField {
+	n.editing, n.text = false, ""
+	if math.IsNaN(value) {
+		return n
+	}
+	value = roundDecimals(min(max(value, n.minValue), n.maxValue), n.decimals())
+	if value != n.value {
+		n.value = value
+		if n.changed != nil {
+			n.changed(value)
+		}
+	}
+	return n
+}
+
+// GetValue returns the number field's current value. Text the user is still
+// entering is not taken into account.
+func (n *NumberField) GetValue() float64 {
+	return n.value
+}
+
+// SetRange sets the minimum and the maximum value (defaults to negative and
+// positive infinity). The current value is adjusted to the new range.
+func (n *NumberField) SetRange(minimum, maximum float64) *NumberField {
+	n.minValue, n.maxValue = min(minimum, maximum), max(minimum, maximum)
+	return n.SetValue(n.value)
+}
+
+// GetRange returns the minimum and the maximum value.
+func (n *NumberField) GetRange() (minimum, maximum float64) {
+	return n.minValue, n.maxValue
+}
+
+// SetStep sets the step by which the arrow keys and the mouse wheel change the
+// value (defaults to 1).
+func (n *NumberField) SetStep(step float64) *NumberField {
+	n.step = math.Abs(step)
+	return n
+}
+
+// SetPrecision sets the number of decimal places of the value. If negative
+// (the default), the number of decimal places of the step is used. The
+// current value is rounded accordingly.
+func (n *NumberField) SetPrecision(precision int) *NumberField {
+	n.precision = precision
+	return n.SetValue(n.value)
+}
+
+// SetFormatFunc sets a function which converts the value to the text displayed
+// in the field. If nil (the default), the value is formatted with the number
+// of decimal places given by the precision.
+func (n *NumberField) SetFormatFunc(format func(value float64) string) *NumberField {
+	n.format = format
+	return n
+}
+
+// SetParseFunc sets a function which converts the text entered by the user to
+// a value. If it returns an error, the previous value is restored. If nil (the
+// default), [strconv.ParseFloat] is used on the text without surrounding
+// white space.
+func (n *NumberField) SetParseFunc(parse func(text string) (float64, error)) *NumberField {
+	n.parse = parse
+	return n
+}
+
+// SetLabel sets the text to be displayed before the input area.
+func (n *NumberField) SetLabel(label string) *NumberField {
+	n.label = label
+	return n
+}
+
+// GetLabel returns the text to be displayed before the input area.
+func (n *NumberField) GetLabel() string {
+	return n.label
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause the
+// primitive to use the width of the label string.
+func (n *NumberField) SetLabelWidth(width int) *NumberField {
+	n.labelWidth = width
+	return n
+}
+
+// SetLabelStyle sets the style of the label.
+func (n *NumberField) SetLabelStyle(style tcell.Style) *NumberField {
+	n.labelStyle = style
+	return n
+}
+
+// SetFieldStyle sets the style of the input area.
+func (n *NumberField) SetFieldStyle(style tcell.Style) *NumberField {
+	n.fieldStyle = style
+	return n
+}
+
+// SetFocusedStyle sets the style of the input area when the number field has
+// focus.
+func (n *NumberField) SetFocusedStyle(style tcell.Style) *NumberField {
+	n.focusedStyle = style
+	return n
+}
+
+// SetDisabledStyle sets the style of the input area when the number field is
+// disabled.
+func (n *NumberField) SetDisabledStyle(style tcell.Style) *NumberField {
+	n.disabledStyle = style
+	return n
+}
+
+// SetFieldWidth sets the screen width of the input area. A value of 0 (the
+// default) means the width is derived from the range.
+func (n *NumberField) SetFieldWidth(width int) *NumberField {
+	n.fieldWidth = width
+	return n
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (n *NumberField) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
+	n.labelWidth = labelWidth
+	n.labelStyle = n.labelStyle.Foreground(labelColor)
+	n.SetBackgroundColor(bgColor)
+	n.fieldStyle = tcell.StyleDefault.Foreground(fieldTextColor).Background(fieldBgColor)
+	return n
+}
+
+// GetFieldWidth returns this primitive's field screen width.
+func (n *NumberField) GetFieldWidth() int {
+	if n.fieldWidth > 0 {
+		return n.fieldWidth
+	}
+	width := 1
+	for _, value := range []float64{n.minValue, n.maxValue} {
+		if math.IsInf(value, 0) {
+			width = max(width, 10)
+		} else {
+			width = max(width, TaggedStringWidth(n.formatValue(value)))
+		}
+	}
+	return width + 1 // Leave room for the cursor.
+}
+
+// GetFieldHeight returns this primitive's field height.
+func (n *NumberField) GetFieldHeight() int {
+	return 1
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (n *NumberField) SetDisabled(disabled bool) FormItem {
+	n.disabled = disabled
+	return n
+}
+
+// GetDisabled returns whether or not the item is disabled / read-only.
+func (n *NumberField) GetDisabled() bool {
+	return n.disabled
+}
+
+// SetChangedFunc sets a handler which is called when the value of the number
+// field changes. The handler receives the new value.
+func (n *NumberField) SetChangedFunc(handler func(value float64)) *NumberField {
+	n.changed = handler
+	return n
+}
+
+// SetDoneFunc sets a handler which is called when the user is done entering
+// the number. The callback function is provided with the key that was pressed,
+// which is one of the following:
+//
+//   - KeyEscape: Abort entry.
+//   - KeyTab: Move to the next field.
+//   - KeyBacktab: Move to the previous field.
+func (n *NumberField) SetDoneFunc(handler func(key tcell.Key)) *NumberField {
+	n.done = handler
+	return n
+}
+
+// AllowExit returns whether or not this primitive will allow a containing form
+// to move focus away from this primitive.
+func (n *NumberField) AllowExit(event *tcell.EventKey) bool {
+	return true
+}
+
+// Blur is called when this primitive loses focus.
+func (n *NumberField) Blur() {
+	n.Box.Blur()
+	n.apply()
+}
+
+// decimals returns the number of decimal places of the value.
+func (n *NumberField) decimals() int {
+	if n.precision >= 0 {
+		return n.precision
+	}
+	return stepDecimals(n.step)
+}
+
+// formatValue returns the given value as it is displayed in the field.
+func (n *NumberField) formatValue(value float64) string {
+	if n.format != nil {
+		return n.format(value)
+	}
+	return strconv.FormatFloat(value, 'f', n.decimals(), 64)
+}
+
+// apply converts the text entered by the user, if any, to the field's value.
+func (n *NumberField) apply() {
+	if !n.editing {
+		return
+	}
+	text := n.text
+	n.editing, n.text = false, ""
+	var (
+		value float64
+		err   error
+	)
+	if n.parse != nil {
+		value, err = n.parse(text)
+	} else {
+		value, err = strconv.ParseFloat(strings.TrimSpace(text), 64)
+	}
+	if err == nil && !math.IsNaN(value) {
+		n.SetValue(value)
+	}
+}
+
+// spin applies the entered text and then changes the value by the given
+// number of steps.
+func (n *NumberField) spin(steps int) {
+	n.apply()
+	n.SetValue(n.value + float64(steps)*n.step)
+}
+
+// Draw draws this primitive onto the screen.
+func (n *NumberField) Draw(screen tcell.Screen) {
+	n.Box.DrawForSubclass(screen, n)
+
+	// Prepare.
+	x, y, width, height := n.GetInnerRect()
+	rightLimit := x + width
+	if height < 1 || rightLimit <= x {
+		return
+	}
+
+	// Draw label.
+	if n.labelWidth > 0 {
+		labelWidth := min(n.labelWidth, rightLimit-x)
+		printWithStyle(screen, n.label, x, y, 0, labelWidth, AlignLeft, n.labelStyle, true)
+		x += labelWidth
+	} else {
+		_, _, drawnWidth := printWithStyle(screen, n.label, x, y, 0, rightLimit-x, AlignLeft, n.labelStyle, true)
+		x += drawnWidth
+	}
+
+	// Draw the input area.
+	fieldWidth := min(n.GetFieldWidth(), rightLimit-x)
+	style := n.fieldStyle
+	if n.disabled {
+		style = n.disabledStyle
+	} else if n.HasFocus() {
+		style = n.focusedStyle
+	}
+	for index := range fieldWidth {
+		screen.SetContent(x+index, y, ' ', nil, style)
+	}
+	text := Escape(n.formatValue(n.value))
+	if n.editing {
+		text = Escape(n.text)
+	}
+	textWidth := TaggedStringWidth(text)
+	skip := 0
+	if n.editing {
+		skip = max(textWidth-fieldWidth+1, 0) // Keep the end of the text visible.
+	}
+	printWithStyle(screen, text, x, y, skip, fieldWidth, AlignLeft, style, false)
+
+	// Show the cursor while the user is entering text.
+	if n.editing && n.HasFocus() && fieldWidth > 0 {
+		screen.ShowCursor(x+textWidth-skip, y)
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (n *NumberField) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return n.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if n.disabled {
+			return
+		}
+
+		switch key := event.Key(); key {
+		case tcell.KeyUp:
+			n.spin(1)
+		case tcell.KeyDown:
+			n.spin(-1)
+		case tcell.KeyPgUp:
+			n.spin(10)
+		case tcell.KeyPgDn:
+			n.spin(-10)
+		case tcell.KeyRune:
+			if !n.editing {
+				n.editing, n.text = true, ""
+			}
+			n.text += string(event.Rune())
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if !n.editing {
+				n.editing, n.text = true, n.formatValue(n.value)
+			}
+			if runes := []rune(n.text); len(runes) > 0 {
+				n.text = string(runes[:len(runes)-1])
+			}
+		case tcell.KeyEnter:
+			n.apply()
+		case tcell.KeyTab, tcell.KeyBacktab:
+			n.apply()
+			if n.done != nil {
+				n.done(key)
+			}
+		case tcell.KeyEscape:
+			n.editing, n.text = false, ""
+			if n.done != nil {
+				n.done(key)
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (n *NumberField) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return n.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if n.disabled {
+			return false, nil
+		}
+		x, y := event.Position()
+		if !n.InRect(x, y) {
+			return false, nil
+		}
+		switch action {
+		case MouseLeftDown:
+			setFocus(n)
+			consumed = true
+		case MouseScrollUp:
+			n.spin(1)
+			consumed = true
+		case MouseScrollDown:
+			n.spin(-1)
+			consumed = true
+		}
+		return
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (n *NumberField) PasteHandler() func(pastedText string, setFocus func(p Primitive)) {
+	return n.WrapPasteHandler(func(pastedText string, setFocus func(p Primitive)) {
+		if n.disabled {
+			return
+		}
+		if !n.editing {
+			n.editing, n.text = true, ""
+		}
+		n.text += regexp.MustCompile(`\r?\n`).ReplaceAllString(pastedText, "")
+	})
+}
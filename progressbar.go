@@ -0,0 +1,464 @@
+package tview
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// progressBarEighths contains the block elements used to draw partially filled
+// cells of a progress bar, indexed by the number of filled eighths.
+var progressBarEighths = [8]rune{
+	' ',
+	BlockLeftOneEighthBlock,
+	BlockLeftOneQuarterBlock,
+	BlockLeftThreeEighthsBlock,
+	BlockLeftHalfBlock,
+	BlockLeftFiveEighthsBlock,
+	BlockLeftThreeQuartersBlock,
+	BlockLeftSevenEighthsBlock,
+}
+
+// progressBarThreshold changes the color of a progress bar's filled area once
+// the progress reaches a certain fraction.
+type progressBarThreshold struct {
+	fraction float64
+	color    tcell.Color
+}
+
+// ProgressBar is a horizontal bar which visualizes the progress of an
+// operation. In determinate mode (the default), the bar is filled according to
+// the ratio of its value (see [ProgressBar.SetValue]) and its maximum value
+// (see [ProgressBar.SetMax]), with a precision of one eighth of a cell. In
+// indeterminate mode (see [ProgressBar.SetIndeterminate]), a short segment
+// moves back and forth each time [ProgressBar.Tick] is called.
+//
+// A text (see [ProgressBar.SetText]) and the percentage (see
+// [ProgressBar.SetShowPercentage]) may be shown on top of the bar. The color of
+// the filled area can change when the progress reaches certain thresholds (see
+// [ProgressBar.AddThreshold]).
+//
+// Progress bars implement the [FormItem] interface but they are read-only and
+// are skipped over in a form. All functions of the progress bar may be called
+// from any goroutine. As with [TextView], changes do not cause an automatic
+// redraw, so call [Application.Draw] afterwards:
+//
+//	go func() {
+//	  for n := range 100 {
+//	    doWork(n)
+//	    bar.SetValue(float64(n + 1))
+//	    app.Draw()
+//	  }
+//	}()
+type ProgressBar struct {
+	sync.Mutex
+	*Box
+
+	// The current value.
+	value float64
+
+	// The value at which the bar is completely filled.
+	max float64
+
+	// Whether the progress is unknown.
+	indeterminate bool
+
+	// The number of times Tick() was called, used for the animation in
+	// indeterminate mode.
+	ticks int
+
+	// The text shown on top of the bar.
+	text string
+
+	// Whether the percentage is shown on top of the bar.
+	showPercentage bool
+
+	// The fill colors, sorted by fraction.
+	thresholds []progressBarThreshold
+
+	// The text to be displayed before the bar.
+	label string
+
+	// The screen width of the label area. A value of 0 means use the width of
+	// the label text.
+	labelWidth int
+
+	// The screen width of the bar. A value of 0 means use all available space.
+	fieldWidth int
+
+	// The label style.
+	labelStyle tcell.Style
+
+	// The style of the unfilled area of the bar and of the text shown on top of
+	// it.
+	fieldStyle tcell.Style
+
+	// The color of the filled area of the bar.
+	fillColor tcell.Color
+}
+
+// NewProgressBar returns a new, empty progress bar with a maximum value of 100.
+func NewProgressBar() *ProgressBar {
+	p := &ProgressBar{
+		Box:        NewBox(),
+		max:        100,
+		labelStyle: tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		fieldStyle: tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		fillColor:  Styles.TertiaryTextColor,
+	}
+	p.Box.Primitive = p
+	return p
+}
+
+// SetValue sets the current value of the progress bar. It is clamped to the
+// range from 0 to the maximum value.
+func (p *ProgressBar) SetValue(value float64) *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	p.value = min(max(value, 0), p.max)
+	return p
+}
+
+// GetValue returns the current value of the progress bar.
+func (p *ProgressBar) GetValue() float64 {
+	p.Lock()
+	defer p.Unlock()
+	return p.value
+}
+
+// AddValue adds the given (possibly negative) amount to the current value of
+// the progress bar. This is useful when several goroutines contribute to the
+// progress.
+func (p *ProgressBar) AddValue(amount float64) *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	p.value = min(max(p.value+amount, 0), p.max)
+	return p
+}
+
+// SetMax sets the value at which the progress bar is completely filled. It
+// must be positive. The default is 100.
+func (p *ProgressBar) SetMax(maxValue float64) *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	if maxValue > 0 {
+		p.max = maxValue
+		p.value = min(p.value, maxValue)
+	}
+	return p
+}
+
+// GetMax returns the value at which the progress bar is completely filled.
+func (p *ProgressBar) GetMax() float64 {
+	p.Lock()
+	defer p.Unlock()
+	return p.max
+}
+
+// GetProgress returns the ratio of the current value and the maximum value, a
+// number between 0 and 1.
+func (p *ProgressBar) GetProgress() float64 {
+	p.Lock()
+	defer p.Unlock()
+	return p.value / p.max
+}
+
+// IsComplete returns whether the current value has reached the maximum value.
+func (p *ProgressBar) IsComplete() bool {
+	p.Lock()
+	defer p.Unlock()
+	return p.value >= p.max
+}
+
+// SetIndeterminate sets whether the progress of the operation is unknown. In
+// that case, the bar does not show the current value but a segment which moves
+// back and forth each time [ProgressBar.Tick] is called.
+func (p *ProgressBar) SetIndeterminate(indeterminate bool) *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	p.indeterminate = indeterminate
+	p.ticks = 0
+	return p
+}
+
+// IsIndeterminate returns whether the progress bar is in indeterminate mode.
+func (p *ProgressBar) IsIndeterminate() bool {
+	p.Lock()
+	defer p.Unlock()
+	return p.indeterminate
+}
+
+// Tick advances the animation of the progress bar in indeterminate mode. It has
+// no visible effect in determinate mode. Call it at regular intervals, for
+// example from a goroutine using a [time.Ticker].
+func (p *ProgressBar) Tick() *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	p.ticks++
+	return p
+}
+
+// SetText sets a text which is shown centered on top of the bar. It may
+// contain style tags. The text is shown in the field style on the unfilled
+// area of the bar and in inverted colors on the filled area.
+func (p *ProgressBar) SetText(text string) *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	p.text = text
+	return p
+}
+
+// GetText returns the text shown on top of the bar.
+func (p *ProgressBar) GetText() string {
+	p.Lock()
+	defer p.Unlock()
+	return p.text
+}
+
+// SetShowPercentage sets whether the progress is shown as a percentage on top
+// of the bar, after the text set with [ProgressBar.SetText]. The percentage is
+// never shown in indeterminate mode.
+func (p *ProgressBar) SetShowPercentage(show bool) *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	p.showPercentage = show
+	return p
+}
+
+// AddThreshold causes the filled area of the bar to be drawn in the given color
+// once the progress (the ratio of the current value and the maximum value)
+// reaches the given fraction, a number between 0 and 1. When several thresholds
+// are reached, the color of the one with the largest fraction is used. For
+// example, a disk usage bar could turn yellow at 0.75 and red at 0.9.
+func (p *ProgressBar) AddThreshold(fraction float64, color tcell.Color) *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	p.thresholds = append(p.thresholds, progressBarThreshold{fraction: fraction, color: color})
+	sort.SliceStable(p.thresholds, func(i, j int) bool {
+		return p.thresholds[i].fraction < p.thresholds[j].fraction
+	})
+	return p
+}
+
+// ClearThresholds removes all thresholds added with [ProgressBar.AddThreshold].
+func (p *ProgressBar) ClearThresholds() *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	p.thresholds = nil
+	return p
+}
+
+// SetLabel sets the text to be displayed before the bar.
+func (p *ProgressBar) SetLabel(label string) *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	p.label = label
+	return p
+}
+
+// GetLabel returns the text to be displayed before the bar.
+func (p *ProgressBar) GetLabel() string {
+	p.Lock()
+	defer p.Unlock()
+	return p.label
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause the
+// primitive to use the width of the label string.
+func (p *ProgressBar) SetLabelWidth(width int) *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	p.labelWidth = width
+	return p
+}
+
+// SetLabelStyle sets the style of the label.
+func (p *ProgressBar) SetLabelStyle(style tcell.Style) *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	p.labelStyle = style
+	return p
+}
+
+// SetFieldStyle sets the style of the unfilled area of the bar. Its foreground
+// color is used for the text shown on top of the bar.
+func (p *ProgressBar) SetFieldStyle(style tcell.Style) *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	p.fieldStyle = style
+	return p
+}
+
+// SetFillColor sets the color of the filled area of the bar, used until the
+// progress reaches a threshold (see [ProgressBar.AddThreshold]).
+func (p *ProgressBar) SetFillColor(color tcell.Color) *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	p.fillColor = color
+	return p
+}
+
+// SetFieldWidth sets the screen width of the bar. A value of 0 (the default)
+// causes the bar to use all available space.
+func (p *ProgressBar) SetFieldWidth(width int) *ProgressBar {
+	p.Lock()
+	defer p.Unlock()
+	p.fieldWidth = width
+	return p
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (p *ProgressBar) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
+	p.Lock()
+	defer p.Unlock()
+	p.labelWidth = labelWidth
+	p.backgroundColor = bgColor
+	p.labelStyle = p.labelStyle.Foreground(labelColor)
+	p.fieldStyle = tcell.StyleDefault.Foreground(fieldTextColor).Background(fieldBgColor)
+	return p
+}
+
+// GetFieldWidth returns this primitive's field width.
+func (p *ProgressBar) GetFieldWidth() int {
+	p.Lock()
+	defer p.Unlock()
+	return p.fieldWidth
+}
+
+// GetFieldHeight returns this primitive's field height.
+func (p *ProgressBar) GetFieldHeight() int {
+	return 1
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (p *ProgressBar) SetDisabled(disabled bool) FormItem {
+	return p // Progress bars are always read-only.
+}
+
+// GetDisabled returns whether or not the item is disabled / read-only.
+func (p *ProgressBar) GetDisabled() bool {
+	return true // Progress bars are always read-only.
+}
+
+// AllowExit returns whether or not this primitive will allow a containing form
+// to move focus away from this primitive.
+func (p *ProgressBar) AllowExit(event *tcell.EventKey) bool {
+	return true
+}
+
+// Draw draws this primitive onto the screen.
+func (p *ProgressBar) Draw(screen tcell.Screen) {
+	p.Box.DrawForSubclass(screen, p)
+
+	p.Lock()
+	defer p.Unlock()
+
+	// Prepare.
+	x, y, width, height := p.GetInnerRect()
+	if height < 1 || width < 1 {
+		return
+	}
+
+	// Draw label.
+	_, labelBg, _ := p.labelStyle.Decompose()
+	if p.labelWidth > 0 {
+		labelWidth := min(p.labelWidth, width)
+		printWithStyle(screen, p.label, x, y, 0, labelWidth, AlignLeft, p.labelStyle, labelBg == tcell.ColorDefault)
+		x += labelWidth
+		width -= labelWidth
+	} else {
+		_, _, drawnWidth := printWithStyle(screen, p.label, x, y, 0, width, AlignLeft, p.labelStyle, labelBg == tcell.ColorDefault)
+		x += drawnWidth
+		width -= drawnWidth
+	}
+	if p.fieldWidth > 0 {
+		width = min(width, p.fieldWidth)
+	}
+	if width <= 0 {
+		return
+	}
+
+	// Determine the filled area, in eighths of a cell.
+	var from, to int
+	progress := p.value / p.max
+	if p.indeterminate {
+		segment := max(width/5, 1) * 8
+		span := width*8 - segment
+		if span > 0 {
+			from = (p.ticks * 4) % (2 * span)
+			if from > span {
+				from = 2*span - from
+			}
+		}
+		to = from + segment
+	} else {
+		to = int(math.Round(progress * float64(width) * 8))
+	}
+
+	// Draw the bar.
+	_, fieldBg, _ := p.fieldStyle.Decompose()
+	fillColor := p.fillColor
+	for _, threshold := range p.thresholds {
+		if !p.indeterminate && progress >= threshold.fraction {
+			fillColor = threshold.color
+		}
+	}
+	filled := make([]bool, width)
+	runes := make([]rune, width)
+	for index := range width {
+		start, end := max(from-index*8, 0), min(to-index*8, 8)
+		style := p.fieldStyle.Foreground(fillColor)
+		switch {
+		case start >= 8 || end <= 0:
+			runes[index] = ' '
+			style = p.fieldStyle
+		case start == 0 && end == 8:
+			runes[index] = BlockFullBlock
+			filled[index] = true
+		case start == 0:
+			runes[index] = progressBarEighths[end]
+			filled[index] = end >= 4
+		default:
+			// The segment starts within this cell. Draw the inverse of the
+			// unfilled part.
+			runes[index] = progressBarEighths[start]
+			style = tcell.StyleDefault.Foreground(fieldBg).Background(fillColor)
+			filled[index] = start < 4
+		}
+		screen.SetContent(x+index, y, runes[index], nil, style)
+	}
+
+	// Draw the text on top of the bar.
+	text := p.text
+	if p.showPercentage && !p.indeterminate {
+		percentage := fmt.Sprintf("%d%%", int(progress*100))
+		if text != "" {
+			text += " "
+		}
+		text += percentage
+	}
+	if text == "" {
+		return
+	}
+	printWithStyle(screen, text, x, y, 0, width, AlignCenter, p.fieldStyle, true)
+	fieldFg, _, _ := p.fieldStyle.Decompose()
+	for index := range width {
+		ch, combining, style, _ := screen.GetContent(x+index, y)
+		if ch == runes[index] && len(combining) == 0 {
+			continue // Not part of the text.
+		}
+		if filled[index] {
+			textFg, _, _ := style.Decompose()
+			if textFg == fieldFg {
+				textFg = fieldBg
+			}
+			style = style.Foreground(textFg).Background(fillColor)
+		} else {
+			style = style.Background(fieldBg)
+		}
+		screen.SetContent(x+index, y, ch, combining, style)
+	}
+}
@@ -0,0 +1,496 @@
+package tview
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// radioOption is one option of a [RadioGroup].
+type radioOption struct {
+	text     string // The text to be displayed.
+	disabled bool   // Whether or not the option can be selected.
+}
+
+// RadioGroup implements a group of radio buttons, i.e. a list of mutually
+// exclusive options which are all visible. At most one option can be selected
+// at a time. Options are arranged vertically (the default) or horizontally
+// (see [RadioGroup.SetHorizontal]). Individual options may be disabled.
+//
+// The following keys can be used to select an option:
+//
+//   - Up arrow, left arrow: Select the previous option.
+//   - Down arrow, right arrow: Select the next option.
+//   - Home: Select the first option.
+//   - End: Select the last option.
+//   - Space: Select the highlighted option if none is selected yet.
+//
+// If no option is selected, the arrow keys select the highlighted option, i.e.
+// the first option which is not disabled.
+//
+// Options can also be selected with the mouse.
+type RadioGroup struct {
+	*Box
+
+	// Whether or not this radio group is disabled/read-only.
+	disabled bool
+
+	// The options.
+	options []*radioOption
+
+	// The index of the selected option, or -1 if no option is selected.
+	currentOption int
+
+	// If set to true, options are arranged horizontally.
+	horizontal bool
+
+	// The text to be displayed before the options.
+	label string
+
+	// The screen width of the label area. A value of 0 means use the width of
+	// the label text.
+	labelWidth int
+
+	// The label style.
+	labelStyle tcell.Style
+
+	// The style of the options.
+	optionStyle tcell.Style
+
+	// The style of the highlighted option when the radio group has focus.
+	focusStyle tcell.Style
+
+	// The style of disabled options.
+	disabledStyle tcell.Style
+
+	// The string used to display a selected option.
+	checkedString string
+
+	// The string used to display an option which is not selected.
+	uncheckedString string
+
+	// An optional function which is called when the user selects an option.
+	changed func(text string, index int)
+
+	// An optional function which is called when the user indicated that they
+	// are done selecting options. The key which was pressed is provided (tab,
+	// shift-tab, or escape).
+	done func(tcell.Key)
+}
+
+// NewRadioGroup returns a new [RadioGroup] without options.
+func NewRadioGroup() *RadioGroup {
+	r := &RadioGroup{
+		Box:             NewBox(),
+		currentOption:   -1,
+		labelStyle:      tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		optionStyle:     tcell.StyleDefault.Foreground(Styles.PrimaryTextColor),
+		focusStyle:      tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
+		disabledStyle:   tcell.StyleDefault.Foreground(Styles.TertiaryTextColor),
+		checkedString:   "◉",
+		uncheckedString: "○",
+	}
+	r.Box.Primitive = r
+	return r
+}
+
+// SetOptions replaces all current options with the ones provided and installs
+// a callback function which is called when the user selects an option. It
+// is called with the option's text and its index into the options slice. The
+// "changed" parameter may be nil. No option will be selected after calling
+// this function.
+func (r *RadioGroup) SetOptions(texts []string, changed func(text string, index int)) *RadioGroup {
+	r.options = nil
+	for _, text := range texts {
+		r.AddOption(text)
+	}
+	r.currentOption = -1
+	r.changed = changed
+	return r
+}
+
+// AddOption adds a new option to the radio group.
+func (r *RadioGroup) AddOption(text string) *RadioGroup {
+	r.options = append(r.options, &radioOption{text: text})
+	return r
+}
+
+// GetOptionCount returns the number of options in the radio group.
+func (r *RadioGroup) GetOptionCount() int {
+	return len(r.options)
+}
+
+// SetOptionDisabled sets whether or not the option with the given index is
+// disabled. Disabled options are shown but cannot be selected by the user. A
+// selected option remains selected when it is disabled. Panics if the index is
+// out of range.
+func (r *RadioGroup) SetOptionDisabled(index int, disabled bool) *RadioGroup {
+	r.options[index].disabled = disabled
+	return r
+}
+
+// IsOptionDisabled returns whether or not the option with the given index is
+// disabled. Panics if the index is out of range.
+func (r *RadioGroup) IsOptionDisabled(index int) bool {
+	return r.options[index].disabled
+}
+
+// SetCurrentOption selects the option with the given index. This may be a
+// negative value to indicate that no option is selected. Calling this function
+// will also trigger the "changed" callback (if there is one) if the selection
+// changes.
+func (r *RadioGroup) SetCurrentOption(index int) *RadioGroup {
+	if index < 0 || index >= len(r.options) {
+		index = -1
+	}
+	if index != r.currentOption {
+		r.currentOption = index
+		if r.changed != nil {
+			index, text := r.GetCurrentOption()
+			r.changed(text, index)
+		}
+	}
+	return r
+}
+
+// GetCurrentOption returns the index of the selected option as well as its
+// text. If no option is selected, -1 and an empty string are returned.
+func (r *RadioGroup) GetCurrentOption() (int, string) {
+	if r.currentOption < 0 || r.currentOption >= len(r.options) {
+		return -1, ""
+	}
+	return r.currentOption, r.options[r.currentOption].text
+}
+
+// SetHorizontal sets whether the options are arranged from left to right
+// (true) or from top to bottom (false, the default).
+func (r *RadioGroup) SetHorizontal(horizontal bool) *RadioGroup {
+	r.horizontal = horizontal
+	return r
+}
+
+// SetLabel sets the text to be displayed before the options.
+func (r *RadioGroup) SetLabel(label string) *RadioGroup {
+	r.label = label
+	return r
+}
+
+// GetLabel returns the text to be displayed before the options.
+func (r *RadioGroup) GetLabel() string {
+	return r.label
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause the
+// primitive to use the width of the label string.
+func (r *RadioGroup) SetLabelWidth(width int) *RadioGroup {
+	r.labelWidth = width
+	return r
+}
+
+// SetLabelColor sets the color of the label.
+func (r *RadioGroup) SetLabelColor(color tcell.Color) *RadioGroup {
+	r.labelStyle = r.labelStyle.Foreground(color)
+	return r
+}
+
+// SetLabelStyle sets the style of the label.
+func (r *RadioGroup) SetLabelStyle(style tcell.Style) *RadioGroup {
+	r.labelStyle = style
+	return r
+}
+
+// SetOptionStyle sets the style of the options.
+func (r *RadioGroup) SetOptionStyle(style tcell.Style) *RadioGroup {
+	r.optionStyle = style
+	return r
+}
+
+// SetActivatedStyle sets the style of the highlighted option when the radio
+// group has focus.
+func (r *RadioGroup) SetActivatedStyle(style tcell.Style) *RadioGroup {
+	r.focusStyle = style
+	return r
+}
+
+// SetDisabledStyle sets the style of disabled options.
+func (r *RadioGroup) SetDisabledStyle(style tcell.Style) *RadioGroup {
+	r.disabledStyle = style
+	return r
+}
+
+// SetCheckedString sets the string to be displayed in front of the selected
+// option (defaults to "◉"). The string may contain color tags. See [Escape]
+// in case you want to display square brackets.
+func (r *RadioGroup) SetCheckedString(checked string) *RadioGroup {
+	r.checkedString = checked
+	return r
+}
+
+// SetUncheckedString sets the string to be displayed in front of options
+// which are not selected (defaults to "○"). The string may contain color tags.
+// It should have the same width as the string for the selected option. See
+// [Escape] in case you want to display square brackets.
+func (r *RadioGroup) SetUncheckedString(unchecked string) *RadioGroup {
+	r.uncheckedString = unchecked
+	return r
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (r *RadioGroup) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
+	r.labelWidth = labelWidth
+	r.SetLabelColor(labelColor)
+	r.backgroundColor = bgColor
+	r.optionStyle = r.optionStyle.Foreground(fieldTextColor)
+	r.focusStyle = r.focusStyle.Background(fieldTextColor).Foreground(fieldBgColor)
+	return r
+}
+
+// GetFieldWidth returns this primitive's field width.
+func (r *RadioGroup) GetFieldWidth() int {
+	var width int
+	for index, option := range r.options {
+		optionWidth := r.optionWidth(option)
+		if !r.horizontal {
+			width = max(width, optionWidth)
+		} else if index > 0 {
+			width += 2 + optionWidth
+		} else {
+			width = optionWidth
+		}
+	}
+	return width
+}
+
+// GetFieldHeight returns this primitive's field height.
+func (r *RadioGroup) GetFieldHeight() int {
+	if r.horizontal {
+		return 1
+	}
+	return max(len(r.options), 1)
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (r *RadioGroup) SetDisabled(disabled bool) FormItem {
+	r.disabled = disabled
+	return r
+}
+
+// GetDisabled returns whether or not the item is disabled / read-only.
+func (r *RadioGroup) GetDisabled() bool {
+	return r.disabled
+}
+
+// SetChangedFunc sets a handler which is called when the user selects an
+// option. The handler receives the option's text and its index.
+func (r *RadioGroup) SetChangedFunc(handler func(text string, index int)) *RadioGroup {
+	r.changed = handler
+	return r
+}
+
+// SetDoneFunc sets a handler which is called when the user is done using the
+// radio group. The callback function is provided with the key that was
+// pressed, which is one of the following:
+//
+//   - KeyEscape: Abort selection.
+//   - KeyTab: Move to the next field.
+//   - KeyBacktab: Move to the previous field.
+func (r *RadioGroup) SetDoneFunc(handler func(key tcell.Key)) *RadioGroup {
+	r.done = handler
+	return r
+}
+
+// AllowExit returns whether or not this primitive will allow a containing form
+// to move focus away from this primitive.
+func (r *RadioGroup) AllowExit(event *tcell.EventKey) bool {
+	return true
+}
+
+// Focus is called when this primitive receives focus.
+func (r *RadioGroup) Focus(delegate func(p Primitive)) {
+	r.Box.Focus(delegate)
+}
+
+// optionWidth returns the screen width of the given option, including the
+// checked or unchecked string.
+func (r *RadioGroup) optionWidth(option *radioOption) int {
+	glyphWidth := max(TaggedStringWidth(r.checkedString), TaggedStringWidth(r.uncheckedString))
+	return glyphWidth + 1 + TaggedStringWidth(option.text)
+}
+
+// layout returns the screen positions of the options (x, y, and width) for
+// the given field area.
+func (r *RadioGroup) layout(x, y, width, height int) [][3]int {
+	positions := make([][3]int, len(r.options))
+	startX := x
+	for index, option := range r.options {
+		optionWidth := r.optionWidth(option)
+		if r.horizontal {
+			positions[index] = [3]int{x, y, max(min(optionWidth, startX+width-x), 0)}
+			x += optionWidth + 2
+		} else if index < height {
+			positions[index] = [3]int{x, y + index, min(optionWidth, width)}
+		}
+	}
+	return positions
+}
+
+// highlighted returns the index of the option which is highlighted when the
+// radio group has focus: the selected option or, if there is none, the first
+// enabled option. Returns -1 if there is no such option.
+func (r *RadioGroup) highlighted() int {
+	if r.currentOption >= 0 {
+		return r.currentOption
+	}
+	for index, option := range r.options {
+		if !option.disabled {
+			return index
+		}
+	}
+	return -1
+}
+
+// Draw draws this primitive onto the screen.
+func (r *RadioGroup) Draw(screen tcell.Screen) {
+	r.Box.DrawForSubclass(screen, r)
+
+	// Prepare
+	x, y, width, height := r.GetInnerRect()
+	rightLimit := x + width
+	if height < 1 || rightLimit <= x {
+		return
+	}
+
+	// Draw label.
+	_, labelBg, _ := r.labelStyle.Decompose()
+	if r.labelWidth > 0 {
+		labelWidth := r.labelWidth
+		if labelWidth > width {
+			labelWidth = width
+		}
+		printWithStyle(screen, r.label, x, y, 0, labelWidth, AlignLeft, r.labelStyle, labelBg == tcell.ColorDefault)
+		x += labelWidth
+		width -= labelWidth
+	} else {
+		_, _, drawnWidth := printWithStyle(screen, r.label, x, y, 0, width, AlignLeft, r.labelStyle, labelBg == tcell.ColorDefault)
+		x += drawnWidth
+		width -= drawnWidth
+	}
+
+	// Draw options.
+	highlighted := -1
+	if r.HasFocus() && !r.disabled {
+		highlighted = r.highlighted()
+	}
+	for index, position := range r.layout(x, y, width, height) {
+		if position[2] <= 0 {
+			continue
+		}
+		option := r.options[index]
+		glyph := r.uncheckedString
+		if index == r.currentOption {
+			glyph = r.checkedString
+		}
+		style := r.optionStyle
+		if option.disabled || r.disabled {
+			style = r.disabledStyle
+		}
+		if index == highlighted {
+			style = r.focusStyle
+		}
+		_, background, _ := style.Decompose()
+		_, _, glyphWidth := printWithStyle(screen, glyph+" ", position[0], position[1], 0, position[2], AlignLeft, style, background == tcell.ColorDefault)
+		printWithStyle(screen, option.text, position[0]+glyphWidth, position[1], 0, position[2]-glyphWidth, AlignLeft, style, background == tcell.ColorDefault)
+	}
+}
+
+// selectNext selects the next enabled option after (forward is true) or
+// before (forward is false) the option with the given index, if there is one.
+func (r *RadioGroup) selectNext(index int, forward bool) {
+	for {
+		if forward {
+			index++
+		} else {
+			index--
+		}
+		if index < 0 || index >= len(r.options) {
+			return
+		}
+		if !r.options[index].disabled {
+			r.SetCurrentOption(index)
+			return
+		}
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (r *RadioGroup) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return r.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if r.disabled {
+			return
+		}
+
+		// If no option is selected yet, navigation keys select the
+		// highlighted option first.
+		key := event.Key()
+		switch key {
+		case tcell.KeyUp, tcell.KeyLeft, tcell.KeyDown, tcell.KeyRight, tcell.KeyRune:
+			if r.currentOption < 0 && (key != tcell.KeyRune || event.Rune() == ' ') {
+				if index := r.highlighted(); index >= 0 {
+					r.SetCurrentOption(index)
+				}
+				return
+			}
+		}
+
+		// Process key event.
+		switch key {
+		case tcell.KeyUp, tcell.KeyLeft:
+			r.selectNext(r.currentOption, false)
+		case tcell.KeyDown, tcell.KeyRight:
+			r.selectNext(r.currentOption, true)
+		case tcell.KeyHome:
+			r.selectNext(-1, true)
+		case tcell.KeyEnd:
+			r.selectNext(len(r.options), false)
+		case tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape: // We're done.
+			if r.done != nil {
+				r.done(key)
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (r *RadioGroup) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return r.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if r.disabled {
+			return false, nil
+		}
+
+		x, y := event.Position()
+		if !r.InRect(x, y) {
+			return false, nil
+		}
+
+		// Process mouse event.
+		switch action {
+		case MouseLeftDown:
+			setFocus(r)
+			consumed = true
+		case MouseLeftClick:
+			rectX, rectY, width, height := r.GetInnerRect()
+			labelWidth := r.labelWidth
+			if labelWidth == 0 {
+				labelWidth = TaggedStringWidth(r.label)
+			}
+			labelWidth = min(labelWidth, width)
+			for index, position := range r.layout(rectX+labelWidth, rectY, width-labelWidth, height) {
+				if y == position[1] && x >= position[0] && x < position[0]+position[2] && !r.options[index].disabled {
+					r.SetCurrentOption(index)
+					break
+				}
+			}
+			consumed = true
+		}
+
+		return
+	})
+}
@@ -0,0 +1,227 @@
+package tview
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// ScrollBarVisibility determines when a scroll bar is shown.
+type ScrollBarVisibility int
+
+// Scroll bar visibility modes.
+const (
+	ScrollBarNever  ScrollBarVisibility = iota // Never show the scroll bar (the default).
+	ScrollBarAuto                              // Show the scroll bar only if the content doesn't fit.
+	ScrollBarAlways                            // Always show the scroll bar.
+)
+
+// scrollBar is a vertical or horizontal scroll bar consisting of a track and a
+// thumb. Its size and position within the track indicate which part of the
+// content is visible.
+type scrollBar struct {
+	// When the scroll bar is shown.
+	visibility ScrollBarVisibility
+
+	// The runes used for the track and the thumb.
+	trackRune, thumbRune rune
+
+	// The styles used for the track and the thumb.
+	trackStyle, thumbStyle tcell.Style
+
+	// Whether this is a horizontal or a vertical scroll bar.
+	horizontal bool
+
+	// The following fields are set during the last call to draw().
+	shown  bool // Whether or not the scroll bar was drawn.
+	x, y   int  // The position of the first cell of the track.
+	length int  // The length of the track.
+	total  int  // The size of the content.
+	size   int  // The size of the visible part of the content.
+	offset int  // The offset of the visible part of the content.
+
+	// Whether or not the thumb is being dragged and at which position of the
+	// thumb it was grabbed.
+	dragging bool
+	grab     int
+}
+
+// needed returns true if the scroll bar should be shown for the given content
+// and visible sizes.
+func (s *scrollBar) needed(total, size int) bool {
+	return s.visibility == ScrollBarAlways || s.visibility == ScrollBarAuto && total > size
+}
+
+// thumb returns the start position (relative to the start of the track) and
+// the length of the thumb.
+func (s *scrollBar) thumb() (start, length int) {
+	if s.total <= s.size || s.total <= 0 {
+		return 0, s.length
+	}
+	length = max(s.length*s.size/s.total, 1)
+	offset := max(min(s.offset, s.total-s.size), 0)
+	start = ((s.length-length)*offset + (s.total-s.size)/2) / (s.total - s.size)
+	if offset > 0 && start == 0 && s.length-length > 1 {
+		start = 1 // Indicate that we're not at the beginning.
+	}
+	if offset < s.total-s.size && start == s.length-length && start > 1 {
+		start-- // Indicate that we're not at the end.
+	}
+	return
+}
+
+// draw draws the scroll bar at the given position with the given track length
+// for a content of the given total size, of which "size" cells are visible,
+// starting at the given offset.
+func (s *scrollBar) draw(screen tcell.Screen, x, y, length, total, size, offset int) {
+	s.shown = length > 0
+	s.x, s.y, s.length = x, y, length
+	s.total, s.size, s.offset = total, size, offset
+	if !s.shown {
+		return
+	}
+	start, thumbLength := s.thumb()
+	for pos := 0; pos < length; pos++ {
+		ch, style := s.trackRune, s.trackStyle
+		if pos >= start && pos < start+thumbLength {
+			ch, style = s.thumbRune, s.thumbStyle
+		}
+		if s.horizontal {
+			screen.SetContent(x+pos, y, ch, nil, style)
+		} else {
+			screen.SetContent(x, y+pos, ch, nil, style)
+		}
+	}
+}
+
+// contains returns true if the given screen position is on the scroll bar.
+func (s *scrollBar) contains(x, y int) bool {
+	if !s.shown {
+		return false
+	}
+	if s.horizontal {
+		return y == s.y && x >= s.x && x < s.x+s.length
+	}
+	return x == s.x && y >= s.y && y < s.y+s.length
+}
+
+// offsetAt returns the content offset which results in the thumb starting at
+// the given position (relative to the start of the track).
+func (s *scrollBar) offsetAt(start int) int {
+	_, length := s.thumb()
+	if s.length <= length || s.total <= s.size {
+		return 0
+	}
+	start = max(min(start, s.length-length), 0)
+	return (start*(s.total-s.size) + (s.length-length)/2) / (s.length - length)
+}
+
+// mouse handles a mouse event. If the event was on the scroll bar or if the
+// thumb is being dragged, it returns true. If the offset needs to change, the
+// "scroll" function is called with the new offset.
+func (s *scrollBar) mouse(action MouseAction, x, y int, scroll func(offset int)) bool {
+	pos := y - s.y
+	if s.horizontal {
+		pos = x - s.x
+	}
+
+	// Drag the thumb.
+	if s.dragging {
+		switch action {
+		case MouseMove:
+			scroll(s.offsetAt(pos - s.grab))
+		case MouseLeftUp:
+			s.dragging = false
+		}
+		return true
+	}
+
+	if !s.contains(x, y) {
+		return false
+	}
+	switch action {
+	case MouseLeftDown:
+		start, length := s.thumb()
+		if pos >= start && pos < start+length {
+			s.grab = pos - start
+		} else {
+			// Move the thumb's center to the clicked position.
+			s.grab = length / 2
+			scroll(s.offsetAt(pos - s.grab))
+		}
+		s.dragging = true
+	case MouseScrollUp, MouseScrollDown, MouseScrollLeft, MouseScrollRight:
+		return false // Let the primitive scroll.
+	}
+	return true
+}
+
+// scrollBars is a pair of scroll bars, one vertical and one horizontal, used
+// by scrollable primitives.
+type scrollBars struct {
+	vertical, horizontal scrollBar
+}
+
+// newScrollBars returns a new pair of scroll bars which are not shown.
+func newScrollBars() scrollBars {
+	bars := scrollBars{
+		vertical: scrollBar{
+			trackRune:  BlockLightShade,
+			thumbRune:  BlockFullBlock,
+			trackStyle: tcell.StyleDefault.Foreground(Styles.ContrastBackgroundColor).Background(Styles.PrimitiveBackgroundColor),
+			thumbStyle: tcell.StyleDefault.Foreground(Styles.PrimaryTextColor).Background(Styles.PrimitiveBackgroundColor),
+		},
+	}
+	bars.horizontal = bars.vertical
+	bars.horizontal.horizontal = true
+	return bars
+}
+
+// setVisibility sets the visibility modes of both scroll bars.
+func (s *scrollBars) setVisibility(vertical, horizontal ScrollBarVisibility) {
+	s.vertical.visibility = vertical
+	s.horizontal.visibility = horizontal
+}
+
+// setRunes sets the track and thumb runes of both scroll bars.
+func (s *scrollBars) setRunes(track, thumb rune) {
+	s.vertical.trackRune, s.vertical.thumbRune = track, thumb
+	s.horizontal.trackRune, s.horizontal.thumbRune = track, thumb
+}
+
+// setStyles sets the track and thumb styles of both scroll bars.
+func (s *scrollBars) setStyles(track, thumb tcell.Style) {
+	s.vertical.trackStyle, s.vertical.thumbStyle = track, thumb
+	s.horizontal.trackStyle, s.horizontal.thumbStyle = track, thumb
+}
+
+// hide marks both scroll bars as not shown. This is called at the beginning of
+// each Draw() call of the primitive.
+func (s *scrollBars) hide() {
+	s.vertical.shown = false
+	s.horizontal.shown = false
+}
+
+// mouseHandler handles mouse events on the scroll bars of the given primitive.
+// If the event was consumed, it returns true. The "scroll" function is called
+// when the user moves a scroll bar's thumb. Primitives call this function
+// before processing mouse events themselves.
+func (s *scrollBars) mouseHandler(p Primitive, action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive), scroll func(vertical bool, offset int)) (consumed bool, capture Primitive) {
+	x, y := event.Position()
+	if !s.vertical.dragging && !s.horizontal.dragging && !s.vertical.contains(x, y) && !s.horizontal.contains(x, y) {
+		return false, nil
+	}
+	if s.vertical.mouse(action, x, y, func(offset int) { scroll(true, offset) }) {
+		consumed = true
+	} else if s.horizontal.mouse(action, x, y, func(offset int) { scroll(false, offset) }) {
+		consumed = true
+	}
+	if !consumed {
+		return false, nil
+	}
+	if action == MouseLeftDown {
+		setFocus(p)
+	}
+	if s.vertical.dragging || s.horizontal.dragging {
+		capture = p
+	}
+	return
+}
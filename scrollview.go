@@ -0,0 +1,412 @@
+package tview
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// clippedScreen is a [tcell.Screen] which discards all drawing operations
+// outside of a rectangular area. All other functions are passed on to the
+// underlying screen.
+type clippedScreen struct {
+	tcell.Screen
+
+	// The visible area.
+	x, y, width, height int
+}
+
+// inside returns true if the given position is inside the visible area.
+func (c *clippedScreen) inside(x, y int) bool {
+	return x >= c.x && x < c.x+c.width && y >= c.y && y < c.y+c.height
+}
+
+// SetContent sets the contents of the given cell if it is visible.
+func (c *clippedScreen) SetContent(x, y int, primary rune, combining []rune, style tcell.Style) {
+	if c.inside(x, y) {
+		c.Screen.SetContent(x, y, primary, combining, style)
+	}
+}
+
+// SetCell sets the contents of the given cell if it is visible.
+func (c *clippedScreen) SetCell(x, y int, style tcell.Style, ch ...rune) {
+	if c.inside(x, y) {
+		c.Screen.SetCell(x, y, style, ch...)
+	}
+}
+
+// Fill fills the visible area with the given rune and style.
+func (c *clippedScreen) Fill(ch rune, style tcell.Style) {
+	for y := c.y; y < c.y+c.height; y++ {
+		for x := c.x; x < c.x+c.width; x++ {
+			c.Screen.SetContent(x, y, ch, nil, style)
+		}
+	}
+}
+
+// Clear clears the visible area.
+func (c *clippedScreen) Clear() {
+	c.Fill(' ', tcell.StyleDefault)
+}
+
+// ShowCursor shows the cursor at the given position if it is visible and hides
+// it otherwise.
+func (c *clippedScreen) ShowCursor(x, y int) {
+	if c.inside(x, y) {
+		c.Screen.ShowCursor(x, y)
+	} else {
+		c.Screen.HideCursor()
+	}
+}
+
+// ScrollView is a container for a single primitive (its content) which may be
+// larger than the area available to the scroll view. The content is given a
+// virtual size (see [ScrollView.SetContentSize]) and only the part of it which
+// fits into the scroll view's inner rectangle is shown. This is useful, for
+// example, for long forms on small terminals.
+//
+// The scroll view can be scrolled with the mouse wheel. When the focus moves to
+// a primitive within the content which is not visible, the scroll view
+// automatically scrolls it into view (see [ScrollView.SetTrackFocus]).
+//
+// By default, the content receives focus when the scroll view receives focus.
+// If the content is not interactive, call [ScrollView.SetFocusContent] with
+// false so that the scroll view itself receives focus. The following keys are
+// then available:
+//
+//   - h, left arrow: Scroll left.
+//   - l, right arrow: Scroll right.
+//   - j, down arrow: Scroll down.
+//   - k, up arrow: Scroll up.
+//   - g, home: Scroll to the top.
+//   - G, end: Scroll to the bottom.
+//   - Ctrl-F, page down: Scroll down by one page.
+//   - Ctrl-B, page up: Scroll up by one page.
+type ScrollView struct {
+	*Box
+
+	// The primitive which is scrolled.
+	content Primitive
+
+	// The virtual size of the content. A value of 0 means that the content
+	// uses the width or height of the scroll view's inner rectangle.
+	contentWidth, contentHeight int
+
+	// The scroll offsets.
+	rowOffset, columnOffset int
+
+	// Whether or not the content receives focus when the scroll view receives
+	// focus.
+	focusContent bool
+
+	// Whether or not the focused primitive is scrolled into view when focus
+	// changes.
+	trackFocus bool
+
+	// The focused primitive within the content during the last call to
+	// Draw().
+	lastFocus Primitive
+
+	// The screen used to clip the content. It is reused between draws.
+	clipped clippedScreen
+
+	// An optional handler which is called when the scroll offsets change.
+	changed func(row, column int)
+}
+
+// NewScrollView returns a new scroll view without any content.
+func NewScrollView() *ScrollView {
+	s := &ScrollView{
+		Box:          NewBox(),
+		focusContent: true,
+		trackFocus:   true,
+	}
+	s.Box.Primitive = s
+	return s
+}
+
+// SetContent sets the primitive which is shown inside the scroll view.
+func (s *ScrollView) SetContent(content Primitive) *ScrollView {
+	s.content = content
+	s.lastFocus = nil
+	return s
+}
+
+// GetContent returns the primitive shown inside the scroll view.
+func (s *ScrollView) GetContent() Primitive {
+	return s.content
+}
+
+// SetContentSize sets the virtual size of the content. A width or height of 0
+// means that the content uses the width or height of the scroll view's inner
+// rectangle, i.e. it does not scroll in that direction. If a value is smaller
+// than the scroll view's inner width or height, the content is extended to
+// fill the scroll view.
+func (s *ScrollView) SetContentSize(width, height int) *ScrollView {
+	s.contentWidth = width
+	s.contentHeight = height
+	return s
+}
+
+// GetContentSize returns the virtual size of the content as set with
+// [ScrollView.SetContentSize].
+func (s *ScrollView) GetContentSize() (width, height int) {
+	return s.contentWidth, s.contentHeight
+}
+
+// SetFocusContent sets whether or not the content receives focus when the
+// scroll view receives focus (the default). If set to false, the scroll view
+// itself receives focus and it can be scrolled with the keyboard.
+func (s *ScrollView) SetFocusContent(focusContent bool) *ScrollView {
+	s.focusContent = focusContent
+	return s
+}
+
+// SetTrackFocus sets whether or not the scroll view automatically scrolls the
+// focused primitive within the content into view whenever focus changes. This
+// is enabled by default.
+func (s *ScrollView) SetTrackFocus(trackFocus bool) *ScrollView {
+	s.trackFocus = trackFocus
+	return s
+}
+
+// SetChangedFunc sets a handler which is called when the scroll offsets
+// change.
+func (s *ScrollView) SetChangedFunc(handler func(row, column int)) *ScrollView {
+	s.changed = handler
+	return s
+}
+
+// ScrollTo scrolls to the specified row and column of the content, starting
+// with 0 for the top row and the left-most column. The offsets are clamped to
+// the available range when the scroll view is drawn.
+func (s *ScrollView) ScrollTo(row, column int) *ScrollView {
+	s.setOffsets(row, column)
+	return s
+}
+
+// ScrollToBeginning scrolls to the top left corner of the content.
+func (s *ScrollView) ScrollToBeginning() *ScrollView {
+	s.setOffsets(0, 0)
+	return s
+}
+
+// ScrollToEnd scrolls to the bottom of the content.
+func (s *ScrollView) ScrollToEnd() *ScrollView {
+	s.setOffsets(s.contentHeight, s.columnOffset)
+	return s
+}
+
+// GetScrollOffset returns the number of rows and columns that are skipped at
+// the top left corner of the content.
+func (s *ScrollView) GetScrollOffset() (row, column int) {
+	return s.rowOffset, s.columnOffset
+}
+
+// setOffsets sets the scroll offsets, clamped to the available range given
+// the current size of the scroll view, and calls the "changed" handler if they
+// changed.
+func (s *ScrollView) setOffsets(row, column int) {
+	_, _, width, height := s.GetInnerRect()
+	contentWidth, contentHeight := s.virtualSize()
+	row = max(min(row, contentHeight-height), 0)
+	column = max(min(column, contentWidth-width), 0)
+	if row == s.rowOffset && column == s.columnOffset {
+		return
+	}
+	s.rowOffset, s.columnOffset = row, column
+	if s.changed != nil {
+		s.changed(row, column)
+	}
+}
+
+// virtualSize returns the size of the content, taking into account the size of
+// the scroll view's inner rectangle.
+func (s *ScrollView) virtualSize() (width, height int) {
+	_, _, innerWidth, innerHeight := s.GetInnerRect()
+	return max(s.contentWidth, innerWidth), max(s.contentHeight, innerHeight)
+}
+
+// scrollIntoView scrolls such that the focused primitive within the content
+// is visible. It returns true if the offsets changed.
+func (s *ScrollView) scrollIntoView() bool {
+	var chain []Primitive
+	if s.content == nil || !s.content.focusChain(&chain) || len(chain) == 0 {
+		s.lastFocus = nil
+		return false
+	}
+	focused := chain[0]
+	if focused == s.lastFocus {
+		return false
+	}
+	s.lastFocus = focused
+
+	// Is the focused primitive visible?
+	x, y, width, height := s.GetInnerRect()
+	fx, fy, fWidth, fHeight := focused.GetRect()
+	row, column := s.rowOffset, s.columnOffset
+	if fy+fHeight > y+height {
+		row += fy + fHeight - y - height
+		fy -= fy + fHeight - y - height
+	}
+	if fy < y {
+		row -= y - fy
+	}
+	if fx+fWidth > x+width {
+		column += fx + fWidth - x - width
+		fx -= fx + fWidth - x - width
+	}
+	if fx < x {
+		column -= x - fx
+	}
+	previousRow, previousColumn := s.rowOffset, s.columnOffset
+	s.setOffsets(row, column)
+	return s.rowOffset != previousRow || s.columnOffset != previousColumn
+}
+
+// Draw draws this primitive onto the screen.
+func (s *ScrollView) Draw(screen tcell.Screen) {
+	s.Box.DrawForSubclass(screen, s)
+	if s.content == nil {
+		return
+	}
+	x, y, width, height := s.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	s.setOffsets(s.rowOffset, s.columnOffset) // Clamp to the current size.
+
+	// Draw the content, clipped to our inner rectangle.
+	s.clipped = clippedScreen{Screen: screen, x: x, y: y, width: width, height: height}
+	contentWidth, contentHeight := s.virtualSize()
+	s.content.SetRect(x-s.columnOffset, y-s.rowOffset, contentWidth, contentHeight)
+	s.content.Draw(&s.clipped)
+
+	// If the focus moved out of view, scroll and draw again.
+	if s.trackFocus && s.scrollIntoView() {
+		s.Box.DrawForSubclass(screen, s)
+		s.content.SetRect(x-s.columnOffset, y-s.rowOffset, contentWidth, contentHeight)
+		s.content.Draw(&s.clipped)
+	}
+}
+
+// Focus is called when this primitive receives focus.
+func (s *ScrollView) Focus(delegate func(p Primitive)) {
+	if s.content != nil && s.focusContent && delegate != nil {
+		delegate(s.content)
+		return
+	}
+	s.Box.Focus(delegate)
+}
+
+// focusChain implements the [Primitive]'s focusChain method.
+func (s *ScrollView) focusChain(chain *[]Primitive) bool {
+	if s.content != nil {
+		if hasFocus := s.content.focusChain(chain); hasFocus {
+			if chain != nil {
+				*chain = append(*chain, s)
+			}
+			return true
+		}
+	}
+	return s.Box.focusChain(chain)
+}
+
+// InputHandler returns the handler for this primitive.
+func (s *ScrollView) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return s.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		// Pass the event on to the content if it has focus.
+		if s.content != nil && s.content.HasFocus() {
+			if handler := s.content.InputHandler(); handler != nil {
+				handler(event, setFocus)
+			}
+			return
+		}
+
+		// Scroll.
+		_, _, _, height := s.GetInnerRect()
+		row, column := s.rowOffset, s.columnOffset
+		switch event.Key() {
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'g':
+				row = 0
+			case 'G':
+				row = s.contentHeight
+			case 'j':
+				row++
+			case 'k':
+				row--
+			case 'h':
+				column--
+			case 'l':
+				column++
+			}
+		case tcell.KeyHome:
+			row = 0
+		case tcell.KeyEnd:
+			row = s.contentHeight
+		case tcell.KeyUp:
+			row--
+		case tcell.KeyDown:
+			row++
+		case tcell.KeyLeft:
+			column--
+		case tcell.KeyRight:
+			column++
+		case tcell.KeyPgDn, tcell.KeyCtrlF:
+			row += height
+		case tcell.KeyPgUp, tcell.KeyCtrlB:
+			row -= height
+		}
+		s.setOffsets(row, column)
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (s *ScrollView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return s.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		x, y := event.Position()
+		if !s.InRect(x, y) {
+			return false, nil
+		}
+
+		// Pass the event on to the content if it's in the visible area.
+		rectX, rectY, width, height := s.GetInnerRect()
+		if s.content != nil && x >= rectX && x < rectX+width && y >= rectY && y < rectY+height {
+			consumed, capture = s.content.MouseHandler()(action, event, setFocus)
+			if consumed {
+				return
+			}
+		}
+
+		// Scroll.
+		switch action {
+		case MouseLeftDown:
+			setFocus(s)
+			consumed = true
+		case MouseScrollUp:
+			s.setOffsets(s.rowOffset-1, s.columnOffset)
+			consumed = true
+		case MouseScrollDown:
+			s.setOffsets(s.rowOffset+1, s.columnOffset)
+			consumed = true
+		case MouseScrollLeft:
+			s.setOffsets(s.rowOffset, s.columnOffset-1)
+			consumed = true
+		case MouseScrollRight:
+			s.setOffsets(s.rowOffset, s.columnOffset+1)
+			consumed = true
+		}
+		return
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (s *ScrollView) PasteHandler() func(pastedText string, setFocus func(p Primitive)) {
+	return s.WrapPasteHandler(func(pastedText string, setFocus func(p Primitive)) {
+		if s.content != nil && s.content.HasFocus() {
+			if handler := s.content.PasteHandler(); handler != nil {
+				handler(pastedText, setFocus)
+			}
+		}
+	})
+}
@@ -0,0 +1,454 @@
+package tview
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Slider is a form item which lets the user choose a number from a range of
+// values (see [Slider.SetRange]) by moving along a horizontal track. The track
+// is filled up to the current value with a precision of one eighth of a cell.
+// The value is a multiple of the step (see [Slider.SetStep]) and is shown to
+// the right of the track unless switched off (see [Slider.SetShowValue]).
+//
+// The following keys are available:
+//
+//   - Left arrow, Down arrow, -: Decrease the value by one step.
+//   - Right arrow, Up arrow, +: Increase the value by one step.
+//   - Page down, page up: Decrease or increase the value by ten steps.
+//   - Home, End: Set the value to the minimum or the maximum.
+//   - Tab, Backtab, Escape: Done (see [Slider.SetDoneFunc]).
+//
+// With the mouse, click on the track or drag along it to set the value and use
+// the scroll wheel to change it.
+type Slider struct {
+	*Box
+
+	// Whether or not this slider is disabled/read-only.
+	disabled bool
+
+	// The current value.
+	value float64
+
+	// The range of values.
+	minValue, maxValue float64
+
+	// The step by which the value changes. If 0, the value changes
+	// continuously.
+	step float64
+
+	// The number of decimal places of the displayed value. If negative, it is
+	// derived from the step.
+	precision int
+
+	// Whether or not the value is shown to the right of the track.
+	showValue bool
+
+	// The text to be displayed before the input area.
+	label string
+
+	// The screen width of the label area. A value of 0 means use the width of
+	// the label text.
+	labelWidth int
+
+	// The screen width of the track. A value of 0 means extend as much as
+	// possible.
+	fieldWidth int
+
+	// Styles.
+	labelStyle, trackStyle, focusedStyle, disabledStyle, valueStyle tcell.Style
+
+	// Set to true while the user drags the mouse along the track.
+	dragging bool
+
+	// An optional function which is called when the value changes.
+	changed func(value float64)
+
+	// An optional function which is called when the user leaves the slider.
+	done func(key tcell.Key)
+}
+
+// NewSlider returns a new slider with a range from 0 to 100 and a step of 1.
+func NewSlider() *Slider {
+	box := NewBox()
+	s := &Slider{
+		Box:           box,
+		maxValue:      100,
+		step:          1,
+		precision:     -1,
+		showValue:     true,
+		labelStyle:    tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		trackStyle:    tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		focusedStyle:  tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
+		disabledStyle: tcell.StyleDefault.Background(box.backgroundColor).Foreground(Styles.SecondaryTextColor),
+		valueStyle:    tcell.StyleDefault.Foreground(Styles.PrimaryTextColor),
+	}
+	s.Box.Primitive = s
+	return s
+}
+
+// SetValue sets the slider's value. It is rounded to a multiple of the step
+// and limited to the slider's range. This also triggers the "changed" callback
+// if the value changes with this call.
+func (s *Slider) SetValue(value float64) *Slider {
+	if s.step > 0 {
+		value = s.minValue + math.Round((value-s.minValue)/s.step)*s.step
+		value = roundDecimals(value, stepDecimals(s.step)) // Remove floating-point noise.
+	}
+	value = min(max(value, s.minValue), s.maxValue)
+	if value != s.value {
+		s.value = value
+		if s.changed != nil {
+			s.changed(value)
+		}
+	}
+	return s
+}
+
+// GetValue returns the slider's current value.
+func (s *Slider) GetValue() float64 {
+	return s.value
+}
+
+// SetRange sets the minimum and the maximum value of the slider (defaults to 0
+// and 100). The current value is adjusted to the new range.
+func (s *Slider) SetRange(minimum, maximum float64) *Slider {
+	s.minValue, s.maxValue = min(minimum, maximum), max(minimum, maximum)
+	return s.SetValue(s.value)
+}
+
+// GetRange returns the minimum and the maximum value of the slider.
+func (s *Slider) GetRange() (minimum, maximum float64) {
+	return s.minValue, s.maxValue
+}
+
+// SetStep sets the step by which the value changes (defaults to 1). Values are
+// always multiples of the step, counted from the minimum value. If the step is
+// 0, values are not rounded and the keys change the value by a hundredth of
+// the range.
+func (s *Slider) SetStep(step float64) *Slider {
+	s.step = max(step, 0)
+	return s.SetValue(s.value)
+}
+
+// SetPrecision sets the number of decimal places of the value shown next to
+// the track. If negative (the default), the number of decimal places of the
+// step is used. The precision only affects how the value is shown, not the
+// value itself.
+func (s *Slider) SetPrecision(precision int) *Slider {
+	s.precision = precision
+	return s.SetValue(s.value)
+}
+
+// SetShowValue sets whether or not the value is shown to the right of the
+// track. It is shown by default.
+func (s *Slider) SetShowValue(show bool) *Slider {
+	s.showValue = show
+	return s
+}
+
+// SetLabel sets the text to be displayed before the input area.
+func (s *Slider) SetLabel(label string) *Slider {
+	s.label = label
+	return s
+}
+
+// GetLabel returns the text to be displayed before the input area.
+func (s *Slider) GetLabel() string {
+	return s.label
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause the
+// primitive to use the width of the label string.
+func (s *Slider) SetLabelWidth(width int) *Slider {
+	s.labelWidth = width
+	return s
+}
+
+// SetLabelStyle sets the style of the label.
+func (s *Slider) SetLabelStyle(style tcell.Style) *Slider {
+	s.labelStyle = style
+	return s
+}
+
+// SetTrackStyle sets the style of the track. The foreground color is used for
+// the filled part of the track.
+func (s *Slider) SetTrackStyle(style tcell.Style) *Slider {
+	s.trackStyle = style
+	return s
+}
+
+// SetFocusedStyle sets the style of the track when the slider has focus.
+func (s *Slider) SetFocusedStyle(style tcell.Style) *Slider {
+	s.focusedStyle = style
+	return s
+}
+
+// SetDisabledStyle sets the style of the track when the slider is disabled.
+func (s *Slider) SetDisabledStyle(style tcell.Style) *Slider {
+	s.disabledStyle = style
+	return s
+}
+
+// SetValueStyle sets the style of the value shown next to the track.
+func (s *Slider) SetValueStyle(style tcell.Style) *Slider {
+	s.valueStyle = style
+	return s
+}
+
+// SetFieldWidth sets the screen width of the track. A value of 0 means extend
+// as much as possible.
+func (s *Slider) SetFieldWidth(width int) *Slider {
+	s.fieldWidth = width
+	return s
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (s *Slider) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
+	s.labelWidth = labelWidth
+	s.labelStyle = s.labelStyle.Foreground(labelColor)
+	s.SetBackgroundColor(bgColor)
+	s.trackStyle = tcell.StyleDefault.Foreground(fieldTextColor).Background(fieldBgColor)
+	return s
+}
+
+// GetFieldWidth returns this primitive's field screen width, including the
+// value shown next to the track.
+func (s *Slider) GetFieldWidth() int {
+	if s.fieldWidth == 0 {
+		return 0
+	}
+	if s.showValue {
+		return s.fieldWidth + s.valueWidth() + 1
+	}
+	return s.fieldWidth
+}
+
+// GetFieldHeight returns this primitive's field height.
+func (s *Slider) GetFieldHeight() int {
+	return 1
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (s *Slider) SetDisabled(disabled bool) FormItem {
+	s.disabled = disabled
+	return s
+}
+
+// GetDisabled returns whether or not the item is disabled / read-only.
+func (s *Slider) GetDisabled() bool {
+	return s.disabled
+}
+
+// SetChangedFunc sets a handler which is called when the value of the slider
+// changes. The handler receives the new value.
+func (s *Slider) SetChangedFunc(handler func(value float64)) *Slider {
+	s.changed = handler
+	return s
+}
+
+// SetDoneFunc sets a handler which is called when the user is done using the
+// slider. The callback function is provided with the key that was pressed,
+// which is one of the following:
+//
+//   - KeyEscape: Abort entry.
+//   - KeyTab: Move to the next field.
+//   - KeyBacktab: Move to the previous field.
+func (s *Slider) SetDoneFunc(handler func(key tcell.Key)) *Slider {
+	s.done = handler
+	return s
+}
+
+// AllowExit returns whether or not this primitive will allow a containing form
+// to move focus away from this primitive.
+func (s *Slider) AllowExit(event *tcell.EventKey) bool {
+	return true
+}
+
+// decimals returns the number of decimal places of the displayed value.
+func (s *Slider) decimals() int {
+	if s.precision >= 0 {
+		return s.precision
+	}
+	return stepDecimals(s.step)
+}
+
+// formatValue returns the given value as it is shown next to the track.
+func (s *Slider) formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', s.decimals(), 64)
+}
+
+// valueWidth returns the screen width of the value shown next to the track.
+func (s *Slider) valueWidth() int {
+	return max(len(s.formatValue(s.minValue)), len(s.formatValue(s.maxValue)))
+}
+
+// track returns the screen position and width of the track.
+func (s *Slider) track() (x, y, width int) {
+	rectX, rectY, rectWidth, _ := s.GetInnerRect()
+	labelWidth := s.labelWidth
+	if labelWidth == 0 {
+		labelWidth = TaggedStringWidth(s.label)
+	}
+	labelWidth = min(labelWidth, rectWidth)
+	width = rectWidth - labelWidth
+	if s.showValue {
+		width -= s.valueWidth() + 1
+	}
+	if s.fieldWidth > 0 {
+		width = min(width, s.fieldWidth)
+	}
+	return rectX + labelWidth, rectY, max(width, 0)
+}
+
+// spin changes the value by the given number of steps.
+func (s *Slider) spin(steps int) {
+	step := s.step
+	if step == 0 {
+		step = (s.maxValue - s.minValue) / 100
+	}
+	s.SetValue(s.value + float64(steps)*step)
+}
+
+// setValueAt sets the value according to the given horizontal screen
+// position on the track.
+func (s *Slider) setValueAt(x int) {
+	trackX, _, width := s.track()
+	if width <= 0 {
+		return
+	}
+	fraction := 0.0
+	if width > 1 {
+		fraction = min(max(float64(x-trackX)/float64(width-1), 0), 1)
+	} else if x >= trackX {
+		fraction = 1
+	}
+	s.SetValue(s.minValue + fraction*(s.maxValue-s.minValue))
+}
+
+// Draw draws this primitive onto the screen.
+func (s *Slider) Draw(screen tcell.Screen) {
+	s.Box.DrawForSubclass(screen, s)
+
+	// Prepare.
+	x, y, width, height := s.GetInnerRect()
+	rightLimit := x + width
+	if height < 1 || rightLimit <= x {
+		return
+	}
+
+	// Draw label.
+	if s.labelWidth > 0 {
+		labelWidth := min(s.labelWidth, rightLimit-x)
+		printWithStyle(screen, s.label, x, y, 0, labelWidth, AlignLeft, s.labelStyle, true)
+	} else {
+		printWithStyle(screen, s.label, x, y, 0, rightLimit-x, AlignLeft, s.labelStyle, true)
+	}
+
+	// Draw the track.
+	x, y, trackWidth := s.track()
+	style := s.trackStyle
+	if s.disabled {
+		style = s.disabledStyle
+	} else if s.HasFocus() {
+		style = s.focusedStyle
+	}
+	var fraction float64
+	if s.maxValue > s.minValue {
+		fraction = (s.value - s.minValue) / (s.maxValue - s.minValue)
+	}
+	eighths := int(math.Round(fraction * float64(trackWidth*8)))
+	for index := range trackWidth {
+		ch := BlockFullBlock
+		if filled := eighths - index*8; filled < 8 {
+			ch = progressBarEighths[max(filled, 0)]
+		}
+		screen.SetContent(x+index, y, ch, nil, style)
+	}
+
+	// Draw the value.
+	if s.showValue && x+trackWidth+1 < rightLimit {
+		valueWidth := min(s.valueWidth(), rightLimit-x-trackWidth-1)
+		printWithStyle(screen, s.formatValue(s.value), x+trackWidth+1, y, 0, valueWidth, AlignRight, s.valueStyle, true)
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (s *Slider) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return s.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if s.disabled {
+			return
+		}
+
+		switch key := event.Key(); key {
+		case tcell.KeyLeft, tcell.KeyDown:
+			s.spin(-1)
+		case tcell.KeyRight, tcell.KeyUp:
+			s.spin(1)
+		case tcell.KeyPgDn:
+			s.spin(-10)
+		case tcell.KeyPgUp:
+			s.spin(10)
+		case tcell.KeyHome:
+			s.SetValue(s.minValue)
+		case tcell.KeyEnd:
+			s.SetValue(s.maxValue)
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case '-':
+				s.spin(-1)
+			case '+':
+				s.spin(1)
+			}
+		case tcell.KeyEscape, tcell.KeyTab, tcell.KeyBacktab:
+			if s.done != nil {
+				s.done(key)
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (s *Slider) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return s.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if s.disabled {
+			return false, nil
+		}
+		x, y := event.Position()
+
+		// Drag along the track.
+		if s.dragging {
+			switch action {
+			case MouseMove:
+				s.setValueAt(x)
+				return true, s
+			case MouseLeftUp:
+				s.dragging = false
+				return true, nil
+			}
+		}
+
+		if !s.InRect(x, y) {
+			return false, nil
+		}
+		switch action {
+		case MouseLeftDown:
+			setFocus(s)
+			consumed = true
+			if trackX, trackY, width := s.track(); y == trackY && x >= trackX && x < trackX+width {
+				s.setValueAt(x)
+				s.dragging = true
+				capture = s
+			}
+		case MouseScrollUp:
+			s.spin(1)
+			consumed = true
+		case MouseScrollDown:
+			s.spin(-1)
+			consumed = true
+		}
+		return
+	})
+}
@@ -0,0 +1,143 @@
+package tview
+
+import (
+	"math"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Sparkline is a compact chart which shows a series of values as vertical bars
+// of one cell width each, drawn with block elements for a resolution of one
+// eighth of a cell. The bars are right-aligned so that the most recent values
+// are always visible. Sparklines are usually one row high but they may be
+// higher. Values which are NaN are shown as gaps.
+//
+// The range of the values is determined automatically, starting at 0 (or the
+// smallest value, if it is negative), unless a fixed range is set with
+// [Sparkline.SetRange].
+//
+// For streaming data, set a window (see [Sparkline.SetWindow]) and add values
+// with [Sparkline.AppendValues]. All functions of the sparkline may be called
+// from any goroutine. Changes do not cause an automatic redraw, so call
+// [Application.Draw] afterwards.
+type Sparkline struct {
+	sync.Mutex
+	*Box
+
+	// The values.
+	values []float64
+
+	// The maximum number of values kept, or 0 for no limit.
+	window int
+
+	// The fixed range of the values. If low >= high, the range is determined
+	// automatically.
+	low, high float64
+
+	// The color of the bars.
+	color tcell.Color
+}
+
+// NewSparkline returns a new sparkline without any values.
+func NewSparkline() *Sparkline {
+	s := &Sparkline{
+		Box:   NewBox(),
+		color: Styles.TertiaryTextColor,
+	}
+	s.Box.Primitive = s
+	return s
+}
+
+// SetValues replaces the values of the sparkline.
+func (s *Sparkline) SetValues(values ...float64) *Sparkline {
+	s.Lock()
+	defer s.Unlock()
+	s.values, _ = chartAppend(nil, s.window, values...)
+	return s
+}
+
+// AppendValues adds values to the end of the sparkline. If a window is set
+// (see [Sparkline.SetWindow]), the oldest values are discarded.
+func (s *Sparkline) AppendValues(values ...float64) *Sparkline {
+	s.Lock()
+	defer s.Unlock()
+	s.values, _ = chartAppend(s.values, s.window, values...)
+	return s
+}
+
+// GetValues returns a copy of the values of the sparkline.
+func (s *Sparkline) GetValues() []float64 {
+	s.Lock()
+	defer s.Unlock()
+	return append([]float64(nil), s.values...)
+}
+
+// SetWindow sets the maximum number of values kept by the sparkline. When more
+// values are added, the oldest values are discarded. A value of 0 (the default)
+// means there is no limit. Only as many values as fit into the sparkline's
+// width are shown in any case.
+func (s *Sparkline) SetWindow(window int) *Sparkline {
+	s.Lock()
+	defer s.Unlock()
+	s.window = max(window, 0)
+	s.values, _ = chartAppend(s.values, s.window)
+	return s
+}
+
+// SetRange sets a fixed range for the values. Values outside the range are
+// clipped. If low is not smaller than high (e.g. if both are 0, the default),
+// the range is determined from the visible values.
+func (s *Sparkline) SetRange(low, high float64) *Sparkline {
+	s.Lock()
+	defer s.Unlock()
+	s.low, s.high = low, high
+	return s
+}
+
+// SetColor sets the color of the bars.
+func (s *Sparkline) SetColor(color tcell.Color) *Sparkline {
+	s.Lock()
+	defer s.Unlock()
+	s.color = color
+	return s
+}
+
+// Draw draws this primitive onto the screen.
+func (s *Sparkline) Draw(screen tcell.Screen) {
+	s.Box.DrawForSubclass(screen, s)
+
+	s.Lock()
+	defer s.Unlock()
+
+	x, y, width, height := s.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Determine the visible values and their range.
+	values := s.values
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	low, high := s.low, s.high
+	if low >= high {
+		var ok bool
+		low, high, ok = chartRange(values)
+		low = min(low, 0)
+		if !ok || high <= low {
+			high = low + 1
+		}
+	}
+
+	// Draw the bars.
+	style := tcell.StyleDefault.Foreground(s.color).Background(s.backgroundColor)
+	x += width - len(values)
+	for index, value := range values {
+		if math.IsNaN(value) {
+			continue
+		}
+		eighths := int(math.Round((min(max(value, low), high) - low) / (high - low) * float64(height*8)))
+		drawVerticalBar(screen, x+index, y+height-1, 1, max(eighths, 1), style)
+	}
+}
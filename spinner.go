@@ -0,0 +1,277 @@
+package tview
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Frame sets for spinners. See [Spinner.SetFrames].
+var (
+	SpinnerLine      = []string{"|", "/", "-", "\\"}
+	SpinnerDots      = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	SpinnerCircle    = []string{"◐", "◓", "◑", "◒"}
+	SpinnerQuadrants = []string{
+		string(BlockQuadrantUpperLeft),
+		string(BlockQuadrantUpperRight),
+		string(BlockQuadrantLowerRight),
+		string(BlockQuadrantLowerLeft),
+	}
+	SpinnerBar = []string{
+		string(BlockLowerOneEighthBlock),
+		string(BlockLowerOneQuarterBlock),
+		string(BlockLowerThreeEighthsBlock),
+		string(BlockLowerHalfBlock),
+		string(BlockLowerFiveEighthsBlock),
+		string(BlockLowerThreeQuartersBlock),
+		string(BlockLowerSevenEighthsBlock),
+		string(BlockFullBlock),
+		string(BlockLowerSevenEighthsBlock),
+		string(BlockLowerThreeQuartersBlock),
+		string(BlockLowerFiveEighthsBlock),
+		string(BlockLowerHalfBlock),
+		string(BlockLowerThreeEighthsBlock),
+		string(BlockLowerOneQuarterBlock),
+	}
+)
+
+// Spinner is a small animation which indicates that an operation is in
+// progress, optionally followed by a text. The animation advances by one frame
+// each time [Spinner.Tick] is called. The frames may be changed with
+// [Spinner.SetFrames], for example to one of the predefined frame sets such as
+// [SpinnerDots].
+//
+// Spinners implement the [FormItem] interface but they are read-only and are
+// skipped over in a form. All functions of the spinner may be called from any
+// goroutine. Changes do not cause an automatic redraw, so call
+// [Application.Draw] afterwards:
+//
+//	go func() {
+//	  ticker := time.NewTicker(100 * time.Millisecond)
+//	  defer ticker.Stop()
+//	  for range ticker.C {
+//	    spinner.Tick()
+//	    app.Draw()
+//	  }
+//	}()
+type Spinner struct {
+	sync.Mutex
+	*Box
+
+	// The frames of the animation.
+	frames []string
+
+	// The index of the current frame.
+	frame int
+
+	// The text shown after the spinner.
+	text string
+
+	// The text to be displayed before the spinner.
+	label string
+
+	// The screen width of the label area. A value of 0 means use the width of
+	// the label text.
+	labelWidth int
+
+	// The label style.
+	labelStyle tcell.Style
+
+	// The style of the spinner.
+	spinnerStyle tcell.Style
+
+	// The style of the text shown after the spinner.
+	textStyle tcell.Style
+}
+
+// NewSpinner returns a new spinner using the [SpinnerLine] frames.
+func NewSpinner() *Spinner {
+	s := &Spinner{
+		Box:          NewBox(),
+		frames:       SpinnerLine,
+		labelStyle:   tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		spinnerStyle: tcell.StyleDefault.Foreground(Styles.TertiaryTextColor),
+		textStyle:    tcell.StyleDefault.Foreground(Styles.PrimaryTextColor),
+	}
+	s.Box.Primitive = s
+	return s
+}
+
+// SetFrames sets the frames of the animation. Frames may contain style tags
+// and should all have the same screen width. The animation restarts with the
+// first frame.
+func (s *Spinner) SetFrames(frames ...string) *Spinner {
+	s.Lock()
+	defer s.Unlock()
+	s.frames = frames
+	s.frame = 0
+	return s
+}
+
+// Tick advances the animation by one frame. Call it at regular intervals, for
+// example from a goroutine using a [time.Ticker].
+func (s *Spinner) Tick() *Spinner {
+	s.Lock()
+	defer s.Unlock()
+	if len(s.frames) > 0 {
+		s.frame = (s.frame + 1) % len(s.frames)
+	}
+	return s
+}
+
+// Reset restarts the animation with the first frame.
+func (s *Spinner) Reset() *Spinner {
+	s.Lock()
+	defer s.Unlock()
+	s.frame = 0
+	return s
+}
+
+// SetText sets the text shown after the spinner. It may contain style tags.
+func (s *Spinner) SetText(text string) *Spinner {
+	s.Lock()
+	defer s.Unlock()
+	s.text = text
+	return s
+}
+
+// GetText returns the text shown after the spinner.
+func (s *Spinner) GetText() string {
+	s.Lock()
+	defer s.Unlock()
+	return s.text
+}
+
+// SetLabel sets the text to be displayed before the spinner.
+func (s *Spinner) SetLabel(label string) *Spinner {
+	s.Lock()
+	defer s.Unlock()
+	s.label = label
+	return s
+}
+
+// GetLabel returns the text to be displayed before the spinner.
+func (s *Spinner) GetLabel() string {
+	s.Lock()
+	defer s.Unlock()
+	return s.label
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause the
+// primitive to use the width of the label string.
+func (s *Spinner) SetLabelWidth(width int) *Spinner {
+	s.Lock()
+	defer s.Unlock()
+	s.labelWidth = width
+	return s
+}
+
+// SetLabelStyle sets the style of the label.
+func (s *Spinner) SetLabelStyle(style tcell.Style) *Spinner {
+	s.Lock()
+	defer s.Unlock()
+	s.labelStyle = style
+	return s
+}
+
+// SetSpinnerStyle sets the style of the spinner's frames.
+func (s *Spinner) SetSpinnerStyle(style tcell.Style) *Spinner {
+	s.Lock()
+	defer s.Unlock()
+	s.spinnerStyle = style
+	return s
+}
+
+// SetTextStyle sets the style of the text shown after the spinner.
+func (s *Spinner) SetTextStyle(style tcell.Style) *Spinner {
+	s.Lock()
+	defer s.Unlock()
+	s.textStyle = style
+	return s
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (s *Spinner) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
+	s.Lock()
+	defer s.Unlock()
+	s.labelWidth = labelWidth
+	s.backgroundColor = bgColor
+	s.labelStyle = s.labelStyle.Foreground(labelColor)
+	// We ignore the field background color because this is a read-only element.
+	s.textStyle = s.textStyle.Foreground(fieldTextColor)
+	return s
+}
+
+// GetFieldWidth returns this primitive's field width.
+func (s *Spinner) GetFieldWidth() int {
+	s.Lock()
+	defer s.Unlock()
+	var width int
+	for _, frame := range s.frames {
+		width = max(width, TaggedStringWidth(frame))
+	}
+	if s.text != "" {
+		width += 1 + TaggedStringWidth(s.text)
+	}
+	return width
+}
+
+// GetFieldHeight returns this primitive's field height.
+func (s *Spinner) GetFieldHeight() int {
+	return 1
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (s *Spinner) SetDisabled(disabled bool) FormItem {
+	return s // Spinners are always read-only.
+}
+
+// GetDisabled returns whether or not the item is disabled / read-only.
+func (s *Spinner) GetDisabled() bool {
+	return true // Spinners are always read-only.
+}
+
+// AllowExit returns whether or not this primitive will allow a containing form
+// to move focus away from this primitive.
+func (s *Spinner) AllowExit(event *tcell.EventKey) bool {
+	return true
+}
+
+// Draw draws this primitive onto the screen.
+func (s *Spinner) Draw(screen tcell.Screen) {
+	s.Box.DrawForSubclass(screen, s)
+
+	s.Lock()
+	defer s.Unlock()
+
+	// Prepare.
+	x, y, width, height := s.GetInnerRect()
+	if height < 1 || width < 1 {
+		return
+	}
+
+	// Draw label.
+	_, labelBg, _ := s.labelStyle.Decompose()
+	if s.labelWidth > 0 {
+		labelWidth := min(s.labelWidth, width)
+		printWithStyle(screen, s.label, x, y, 0, labelWidth, AlignLeft, s.labelStyle, labelBg == tcell.ColorDefault)
+		x += labelWidth
+		width -= labelWidth
+	} else {
+		_, _, drawnWidth := printWithStyle(screen, s.label, x, y, 0, width, AlignLeft, s.labelStyle, labelBg == tcell.ColorDefault)
+		x += drawnWidth
+		width -= drawnWidth
+	}
+
+	// Draw the current frame.
+	if len(s.frames) > 0 {
+		_, _, drawnWidth := printWithStyle(screen, s.frames[s.frame], x, y, 0, width, AlignLeft, s.spinnerStyle, true)
+		x += drawnWidth + 1
+		width -= drawnWidth + 1
+	}
+
+	// Draw the text.
+	if width > 0 {
+		printWithStyle(screen, s.text, x, y, 0, width, AlignLeft, s.textStyle, true)
+	}
+}
@@ -0,0 +1,736 @@
+package tview
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// splitPaneItem holds layout options for one pane of a [SplitPane].
+type splitPaneItem struct {
+	Item        Primitive // The item to be positioned. May be nil for an empty pane.
+	InitialSize int       // The size of the pane when it is laid out for the first time, 0 to use the proportion.
+	Proportion  int       // The initial relative size of the pane if it has no initial size.
+	Min, Max    int       // The pane's minimum and maximum size, 0 for no limit.
+	Collapsible bool      // Whether or not the pane may be collapsed to zero.
+	Focus       bool      // Whether or not this pane attracts the layout's focus.
+	Size        int       // The current size of the pane, -1 if it was not yet laid out.
+	restore     int       // The size to restore when a collapsed pane is expanded.
+}
+
+// SplitPane arranges its panes horizontally or vertically, similar to [Flex],
+// but with a divider between adjacent panes which the user can move to resize
+// them. Panes may have a minimum and maximum size (see
+// [SplitPane.SetItemLimits]) and they may be collapsed to zero (see
+// [SplitPane.SetItemCollapsible]). The current sizes can be retrieved with
+// [SplitPane.GetSizes] and restored with [SplitPane.SetSizes], e.g. to save a
+// layout across sessions.
+//
+// Dividers can be dragged with the mouse. For keyboard users, pressing the
+// resize key (see [SplitPane.SetResizeKey], Ctrl-\ by default) while a pane has
+// focus selects the divider next to it. The following keys are then available:
+//
+//   - Left/Up arrow: Move the divider to the left or up.
+//   - Right/Down arrow: Move the divider to the right or down.
+//   - Home, End: Move the divider as far as possible.
+//   - Tab, Backtab: Select the next or previous divider.
+//   - Enter, Escape, resize key: Return focus to the pane.
+//
+// Moving a divider beyond a collapsible pane's minimum size collapses it.
+type SplitPane struct {
+	*Box
+
+	// The panes.
+	items []*splitPaneItem
+
+	// FlexRow or FlexColumn.
+	direction int
+
+	// The style of the dividers.
+	dividerStyle tcell.Style
+
+	// The style of the selected divider while it has focus or while it is
+	// being dragged.
+	dividerFocusStyle tcell.Style
+
+	// The key which selects a divider.
+	resizeKey tcell.Key
+
+	// The index of the selected divider. The divider with index i is located
+	// between panes i and i+1.
+	divider int
+
+	// Whether or not the selected divider is being dragged with the mouse.
+	dragging bool
+
+	// Set to true while the split pane itself is being focused in order to
+	// select a divider.
+	selectDivider bool
+
+	// The pane which had focus before a divider was selected using the
+	// keyboard.
+	previousFocus Primitive
+
+	// An optional handler which is called when the user resizes the panes.
+	changed func(sizes []int)
+}
+
+// NewSplitPane returns a new split pane without any panes and its direction
+// set to [FlexColumn], i.e. panes are arranged side by side. To add panes, see
+// [SplitPane.AddItem].
+func NewSplitPane() *SplitPane {
+	s := &SplitPane{
+		Box:               NewBox(),
+		direction:         FlexColumn,
+		dividerStyle:      tcell.StyleDefault.Foreground(Styles.BorderColor).Background(Styles.PrimitiveBackgroundColor),
+		dividerFocusStyle: tcell.StyleDefault.Foreground(Styles.TertiaryTextColor).Background(Styles.PrimitiveBackgroundColor),
+		resizeKey:         tcell.KeyCtrlBackslash,
+	}
+	s.Box.Primitive = s
+	return s
+}
+
+// SetDirection sets the direction in which the panes are distributed. This can
+// be either [FlexColumn] (default, panes side by side with vertical dividers)
+// or [FlexRow] (panes on top of each other with horizontal dividers).
+func (s *SplitPane) SetDirection(direction int) *SplitPane {
+	s.direction = direction
+	return s
+}
+
+// SetDividerStyle sets the style of the dividers as well as the style of the
+// selected divider while it is being moved.
+func (s *SplitPane) SetDividerStyle(normal, focused tcell.Style) *SplitPane {
+	s.dividerStyle = normal
+	s.dividerFocusStyle = focused
+	return s
+}
+
+// SetResizeKey sets the key which, when pressed while a pane has focus, selects
+// the adjacent divider so it can be moved with the arrow keys. The default is
+// Ctrl-\. Set to [tcell.KeyNUL] to disable keyboard resizing via this key.
+func (s *SplitPane) SetResizeKey(key tcell.Key) *SplitPane {
+	s.resizeKey = key
+	return s
+}
+
+// SetChangedFunc sets a handler which is called when the user resizes the
+// panes, either with the mouse or with the keyboard. It receives the new sizes
+// of all panes.
+func (s *SplitPane) SetChangedFunc(handler func(sizes []int)) *SplitPane {
+	s.changed = handler
+	return s
+}
+
+// AddItem adds a new pane to the split pane. The "initialSize" argument is the
+// width or height of the pane when it is first laid out. A value of 0 means
+// that its initial size is determined by the "proportion" argument instead,
+// which defines the pane's size relative to other panes without an initial
+// size (see [Flex.AddItem]). After the first layout, all sizes can be changed
+// by the user.
+//
+// If "focus" is set to true, the pane will receive focus when the split pane
+// receives focus. If multiple panes have the "focus" flag set to true, the
+// first one will receive focus.
+//
+// You can provide a nil value for the primitive. This will still consume screen
+// space but nothing will be drawn.
+func (s *SplitPane) AddItem(item Primitive, initialSize, proportion int, focus bool) *SplitPane {
+	s.items = append(s.items, &splitPaneItem{
+		Item:        item,
+		InitialSize: initialSize,
+		Proportion:  proportion,
+		Focus:       focus,
+		Size:        -1,
+	})
+	return s
+}
+
+// RemoveItem removes all panes for the given primitive, keeping the order of
+// the remaining panes intact. The freed space is distributed among the
+// remaining panes.
+func (s *SplitPane) RemoveItem(p Primitive) *SplitPane {
+	for index := len(s.items) - 1; index >= 0; index-- {
+		if s.items[index].Item == p {
+			s.items = append(s.items[:index], s.items[index+1:]...)
+		}
+	}
+	if s.divider >= len(s.items)-1 {
+		s.divider = 0
+	}
+	return s
+}
+
+// GetItemCount returns the number of panes.
+func (s *SplitPane) GetItemCount() int {
+	return len(s.items)
+}
+
+// GetItem returns the primitive of the pane with the given index, starting
+// with 0 for the first pane.
+//
+// This function will panic for out of range indices.
+func (s *SplitPane) GetItem(index int) Primitive {
+	return s.items[index].Item
+}
+
+// Clear removes all panes.
+func (s *SplitPane) Clear() *SplitPane {
+	s.items = nil
+	s.divider = 0
+	return s
+}
+
+// SetItemLimits sets the minimum and maximum size of the pane with the given
+// index. A value of 0 means that there is no limit. The limits may be violated
+// if there is not enough space or if all panes have reached their maximum
+// size.
+//
+// This function will panic for out of range indices.
+func (s *SplitPane) SetItemLimits(index, minSize, maxSize int) *SplitPane {
+	s.items[index].Min = minSize
+	s.items[index].Max = maxSize
+	return s
+}
+
+// SetItemCollapsible sets whether or not the pane with the given index may be
+// collapsed to zero, either by the user moving a divider beyond the pane's
+// minimum size or by calling [SplitPane.SetItemCollapsed].
+//
+// This function will panic for out of range indices.
+func (s *SplitPane) SetItemCollapsible(index int, collapsible bool) *SplitPane {
+	s.items[index].Collapsible = collapsible
+	return s
+}
+
+// SetItemCollapsed collapses the pane with the given index to zero or restores
+// its previous size. The space is taken from or given to its neighbour. Panes
+// which are not collapsible (see [SplitPane.SetItemCollapsible]) are not
+// collapsed.
+//
+// This function will panic for out of range indices.
+func (s *SplitPane) SetItemCollapsed(index int, collapsed bool) *SplitPane {
+	item := s.items[index]
+	if item.Size < 0 || len(s.items) < 2 || collapsed == (item.Size == 0) {
+		return s
+	}
+	neighbour := s.items[len(s.items)-2]
+	if index < len(s.items)-1 {
+		neighbour = s.items[index+1]
+	}
+	if collapsed {
+		if !item.Collapsible {
+			return s
+		}
+		item.restore = item.Size
+		neighbour.Size += item.Size
+		item.Size = 0
+		return s
+	}
+	size := item.restore
+	if size <= 0 {
+		size = item.Min
+	}
+	if size <= 0 {
+		size = (item.Size + neighbour.Size) / 2
+	}
+	if size > neighbour.Size {
+		size = neighbour.Size
+	}
+	item.Size = size
+	neighbour.Size -= size
+	return s
+}
+
+// IsItemCollapsed returns whether or not the pane with the given index is
+// currently collapsed, i.e. it has a size of zero.
+//
+// This function will panic for out of range indices.
+func (s *SplitPane) IsItemCollapsed(index int) bool {
+	return s.items[index].Size == 0
+}
+
+// GetSizes returns the current sizes of all panes, as determined during the
+// last call to Draw(). Panes which were not laid out yet have a size of -1.
+func (s *SplitPane) GetSizes() []int {
+	sizes := make([]int, len(s.items))
+	for index, item := range s.items {
+		sizes[index] = item.Size
+	}
+	return sizes
+}
+
+// SetSizes sets the sizes of the panes, e.g. to restore sizes previously
+// retrieved with [SplitPane.GetSizes]. Missing or negative values leave the
+// corresponding pane's size unchanged. If the sizes don't add up to the
+// available space when the split pane is drawn, they are adjusted
+// proportionally.
+func (s *SplitPane) SetSizes(sizes []int) *SplitPane {
+	for index, item := range s.items {
+		if index >= len(sizes) || sizes[index] < 0 {
+			continue
+		}
+		if item.Size < 0 {
+			item.InitialSize = sizes[index]
+			if sizes[index] == 0 {
+				item.InitialSize = -1 // Collapsed.
+			}
+			continue
+		}
+		if sizes[index] == 0 && item.Size > 0 {
+			item.restore = item.Size
+		}
+		item.Size = sizes[index]
+	}
+	return s
+}
+
+// layout determines the size of all panes such that they fill the given
+// space.
+func (s *SplitPane) layout(space int) {
+	if len(s.items) == 0 {
+		return
+	}
+
+	// Initial layout.
+	initial := true
+	for _, item := range s.items {
+		if item.Size >= 0 {
+			initial = false
+			break
+		}
+	}
+	if initial {
+		remaining, proportionSum := space, 0
+		for _, item := range s.items {
+			if item.InitialSize != 0 {
+				item.Size = max(item.InitialSize, 0)
+				remaining -= item.Size
+			} else {
+				proportionSum += max(item.Proportion, 1)
+			}
+		}
+		for _, item := range s.items {
+			if item.InitialSize != 0 {
+				continue
+			}
+			item.Size = 0
+			if proportionSum > 0 && remaining > 0 {
+				item.Size = remaining * max(item.Proportion, 1) / proportionSum
+				remaining -= item.Size
+				proportionSum -= max(item.Proportion, 1)
+			}
+		}
+	} else {
+		// Panes added later start with their initial size or an equal share.
+		for _, item := range s.items {
+			if item.Size < 0 {
+				item.Size = max(item.InitialSize, 0)
+				if item.InitialSize == 0 {
+					item.Size = space / len(s.items)
+				}
+			}
+		}
+	}
+
+	// Distribute any difference.
+	var sum, weightSum int
+	for _, item := range s.items {
+		sum += item.Size
+		weightSum += item.Size
+	}
+	delta := space - sum
+	if delta == 0 {
+		return
+	}
+
+	// Proportional pass.
+	if weightSum > 0 {
+		totalDelta := delta
+		for _, item := range s.items {
+			delta -= s.resizeItem(item, totalDelta*item.Size/weightSum)
+		}
+	}
+
+	// Give the remainder to any pane that can take it, starting with the last
+	// one.
+	for index := len(s.items) - 1; index >= 0 && delta != 0; index-- {
+		if s.items[index].Size > 0 {
+			delta -= s.resizeItem(s.items[index], delta)
+		}
+	}
+
+	// If there is still space left over, we have to violate the limits.
+	for index := len(s.items) - 1; index >= 0 && delta != 0; index-- {
+		item := s.items[index]
+		if item.Size == 0 && delta > 0 && index > 0 {
+			continue // Try not to expand collapsed panes.
+		}
+		change := delta
+		if item.Size+change < 0 {
+			change = -item.Size
+		}
+		item.Size += change
+		delta -= change
+	}
+}
+
+// resizeItem changes the size of a pane which is not collapsed by the given
+// amount, as far as its limits allow, and returns the actual change.
+func (s *SplitPane) resizeItem(item *splitPaneItem, change int) int {
+	if item.Size == 0 {
+		return 0
+	}
+	size := item.Size + change
+	if change < 0 && size < item.Min {
+		size = min(item.Min, item.Size)
+	}
+	if change > 0 && item.Max > 0 && size > item.Max {
+		size = max(item.Max, item.Size)
+	}
+	if size < 0 {
+		size = 0
+	}
+	change = size - item.Size
+	item.Size = size
+	return change
+}
+
+// constrain returns the size a pane will have if the user attempts to resize
+// it from its current size to the given size, taking into account its limits
+// and whether or not it may be collapsed.
+func (s *SplitPane) constrain(item *splitPaneItem, size int) int {
+	if size <= 0 && item.Collapsible {
+		return 0
+	}
+	if size < max(item.Min, 1) {
+		if item.Collapsible && item.Size > 0 && (size < (item.Min+1)/2 || size < item.Size && item.Size <= item.Min) {
+			return 0 // Snap shut.
+		}
+		size = max(item.Min, 1)
+	}
+	if item.Max > 0 && size > item.Max {
+		size = item.Max
+	}
+	return size
+}
+
+// fits returns true if the given size is within the limits of the given pane.
+func (s *SplitPane) fits(item *splitPaneItem, size int) bool {
+	if size == 0 {
+		return item.Collapsible
+	}
+	return size >= max(item.Min, 1) && (item.Max <= 0 || size <= item.Max)
+}
+
+// moveDivider moves the divider with the given index by the given amount,
+// respecting the limits of the two adjacent panes. It returns true if the
+// sizes changed. The "changed" handler is not called.
+func (s *SplitPane) moveDivider(index, delta int) bool {
+	if index < 0 || index >= len(s.items)-1 || delta == 0 {
+		return false
+	}
+	first, second := s.items[index], s.items[index+1]
+	if first.Size < 0 || second.Size < 0 {
+		return false
+	}
+	total := first.Size + second.Size
+	firstSize := s.constrain(first, first.Size+delta)
+	secondSize := s.constrain(second, total-firstSize)
+	firstSize = total - secondSize
+	if !s.fits(first, firstSize) {
+		firstSize = s.constrain(first, firstSize)
+		secondSize = total - firstSize
+		if !s.fits(second, secondSize) {
+			return false
+		}
+	}
+	if firstSize < 0 || secondSize < 0 || firstSize == first.Size {
+		return false
+	}
+	if firstSize == 0 {
+		first.restore = first.Size
+	}
+	if secondSize == 0 {
+		second.restore = second.Size
+	}
+	first.Size, second.Size = firstSize, secondSize
+	return true
+}
+
+// dividerPosition returns the screen position (x for FlexColumn, y for
+// FlexRow) of the divider with the given index.
+func (s *SplitPane) dividerPosition(index int) int {
+	x, y, _, _ := s.GetInnerRect()
+	pos := x
+	if s.direction == FlexRow {
+		pos = y
+	}
+	for i := 0; i <= index && i < len(s.items); i++ {
+		pos += max(s.items[i].Size, 0)
+		if i < index {
+			pos++
+		}
+	}
+	return pos
+}
+
+// Draw draws this primitive onto the screen.
+func (s *SplitPane) Draw(screen tcell.Screen) {
+	s.Box.DrawForSubclass(screen, s)
+	x, y, width, height := s.GetInnerRect()
+	space := width
+	if s.direction == FlexRow {
+		space = height
+	}
+	if len(s.items) > 1 {
+		space -= len(s.items) - 1
+	}
+	if space < 0 {
+		space = 0
+	}
+	s.layout(space)
+
+	// Draw the panes and the dividers.
+	pos := x
+	if s.direction == FlexRow {
+		pos = y
+	}
+	for index, item := range s.items {
+		if item.Item != nil {
+			if s.direction == FlexColumn {
+				item.Item.SetRect(pos, y, item.Size, height)
+			} else {
+				item.Item.SetRect(x, pos, width, item.Size)
+			}
+			if item.Size > 0 {
+				if item.Item.HasFocus() {
+					defer item.Item.Draw(screen)
+				} else {
+					item.Item.Draw(screen)
+				}
+			}
+		}
+		pos += item.Size
+		if index == len(s.items)-1 {
+			break
+		}
+
+		// Draw the divider.
+		style, ch := s.dividerStyle, Borders.Vertical
+		if s.direction == FlexRow {
+			ch = Borders.Horizontal
+		}
+		if index == s.divider && (s.dragging || s.Box.hasFocus) {
+			style, ch = s.dividerFocusStyle, Borders.VerticalFocus
+			if s.direction == FlexRow {
+				ch = Borders.HorizontalFocus
+			}
+		}
+		if s.direction == FlexColumn {
+			for line := y; line < y+height; line++ {
+				screen.SetContent(pos, line, ch, nil, style)
+			}
+		} else {
+			for column := x; column < x+width; column++ {
+				screen.SetContent(column, pos, ch, nil, style)
+			}
+		}
+		pos++
+	}
+}
+
+// Focus is called when this primitive receives focus.
+func (s *SplitPane) Focus(delegate func(p Primitive)) {
+	if s.selectDivider {
+		s.selectDivider = false
+		s.Box.Focus(delegate)
+		return
+	}
+	for _, item := range s.items {
+		if item.Item != nil && item.Focus {
+			delegate(item.Item)
+			return
+		}
+	}
+	s.Box.Focus(delegate)
+}
+
+// focusChain implements the [Primitive]'s focusChain method.
+func (s *SplitPane) focusChain(chain *[]Primitive) bool {
+	for _, item := range s.items {
+		if item.Item == nil {
+			continue
+		}
+		if hasFocus := item.Item.focusChain(chain); hasFocus {
+			if chain != nil {
+				*chain = append(*chain, s)
+			}
+			return true
+		}
+	}
+	return s.Box.focusChain(chain)
+}
+
+// InputHandler returns the handler for this primitive.
+func (s *SplitPane) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return s.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		key := event.Key()
+
+		// A divider is selected.
+		if s.Box.hasFocus {
+			delta := 0
+			switch key {
+			case tcell.KeyLeft, tcell.KeyUp:
+				delta = -1
+			case tcell.KeyRight, tcell.KeyDown:
+				delta = 1
+			case tcell.KeyHome:
+				delta = -s.dividerPosition(s.divider)
+			case tcell.KeyEnd:
+				x, y, width, height := s.GetInnerRect()
+				delta = x + width - s.dividerPosition(s.divider)
+				if s.direction == FlexRow {
+					delta = y + height - s.dividerPosition(s.divider)
+				}
+			case tcell.KeyTab:
+				if len(s.items) > 1 {
+					s.divider = (s.divider + 1) % (len(s.items) - 1)
+				}
+			case tcell.KeyBacktab:
+				if len(s.items) > 1 {
+					s.divider = (s.divider + len(s.items) - 2) % (len(s.items) - 1)
+				}
+			case tcell.KeyEnter, tcell.KeyEscape, s.resizeKey:
+				s.returnFocus(setFocus)
+			}
+			// Move step by step so panes can snap to zero.
+			var moved bool
+			for ; delta < 0 && s.moveDivider(s.divider, -1); delta++ {
+				moved = true
+			}
+			for ; delta > 0 && s.moveDivider(s.divider, 1); delta-- {
+				moved = true
+			}
+			if moved && s.changed != nil {
+				s.changed(s.GetSizes())
+			}
+			return
+		}
+
+		// Pass the event on to the pane with focus.
+		for index, item := range s.items {
+			if item.Item == nil || !item.Item.HasFocus() {
+				continue
+			}
+			if key == s.resizeKey && s.resizeKey != tcell.KeyNUL && len(s.items) > 1 {
+				// Let nested split panes handle the key first.
+				if nested, ok := item.Item.(*SplitPane); !ok || nested.resizeKey != key || len(nested.items) < 2 {
+					s.divider = min(index, len(s.items)-2)
+					s.previousFocus = item.Item
+					s.selectDivider = true
+					setFocus(s)
+					return
+				}
+			}
+			if handler := item.Item.InputHandler(); handler != nil {
+				handler(event, setFocus)
+			}
+			return
+		}
+	})
+}
+
+// returnFocus gives focus back to the pane which had focus before a divider
+// was selected using the keyboard.
+func (s *SplitPane) returnFocus(setFocus func(p Primitive)) {
+	target := s.previousFocus
+	s.previousFocus = nil
+	if len(s.items) == 0 {
+		return
+	}
+	s.divider = max(min(s.divider, len(s.items)-2), 0)
+	for _, item := range s.items {
+		if item.Item == target && item.Size == 0 {
+			target = nil // The pane was collapsed.
+		}
+	}
+	for index := s.divider; target == nil && index < len(s.items); index++ {
+		if item := s.items[index]; item.Item != nil && item.Size > 0 {
+			target = item.Item
+		}
+	}
+	for index := s.divider; target == nil && index >= 0; index-- {
+		if item := s.items[index]; item.Item != nil && item.Size > 0 {
+			target = item.Item
+		}
+	}
+	if target != nil {
+		setFocus(target)
+	}
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (s *SplitPane) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return s.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		x, y := event.Position()
+		pos := x
+		if s.direction == FlexRow {
+			pos = y
+		}
+
+		// Drag a divider.
+		if s.dragging {
+			switch action {
+			case MouseMove:
+				if s.moveDivider(s.divider, pos-s.dividerPosition(s.divider)) && s.changed != nil {
+					s.changed(s.GetSizes())
+				}
+				return true, s
+			case MouseLeftUp:
+				s.dragging = false
+				return true, nil
+			}
+		}
+
+		if !s.InRect(x, y) {
+			return false, nil
+		}
+
+		// Start dragging a divider.
+		if action == MouseLeftDown {
+			for index := 0; index < len(s.items)-1; index++ {
+				if pos == s.dividerPosition(index) {
+					s.divider = index
+					s.dragging = true
+					return true, s
+				}
+			}
+		}
+
+		// Pass mouse events along to the first pane that takes it.
+		for _, item := range s.items {
+			if item.Item == nil {
+				continue
+			}
+			consumed, capture = item.Item.MouseHandler()(action, event, setFocus)
+			if consumed {
+				return
+			}
+		}
+
+		return
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (s *SplitPane) PasteHandler() func(pastedText string, setFocus func(p Primitive)) {
+	return s.WrapPasteHandler(func(pastedText string, setFocus func(p Primitive)) {
+		for _, item := range s.items {
+			if item.Item != nil && item.Item.HasFocus() {
+				if handler := item.Item.PasteHandler(); handler != nil {
+					handler(pastedText, setFocus)
+					return
+				}
+			}
+		}
+	})
+}
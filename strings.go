@@ -33,6 +33,7 @@ type stepState struct {
 	boundaries      int         // Information about boundaries, as returned by uniseg.Step.
 	style           tcell.Style // The style of the returned grapheme cluster.
 	region          string      // The region of the returned grapheme cluster.
+	url             string      // The URL of the returned grapheme cluster (also contained in the style).
 	escapedTagState int         // States for parsing escaped tags (defined in [step]).
 	grossLength     int         // The length of the cluster, including any tags not returned.
 
@@ -173,10 +174,11 @@ func step(str string, state *stepState, opts stepOptions) (cluster, rest string,
 		if state.escapedTagState == etNone {
 			if cluster[0] == '[' {
 				// We've already opened a tag. Parse it.
-				length, style, region := parseTag(str, state, opts)
+				length, style, region, url := parseTag(str, state, opts)
 				if length > 0 {
 					state.style = style
 					state.region = region
+					state.url = url
 					cluster, rest, state.boundaries, state.unisegState = uniseg.StepString(str[length:], preState)
 					state.grossLength = len(cluster) + length
 					if rest == "" {
@@ -193,7 +195,7 @@ func step(str string, state *stepState, opts stepOptions) (cluster, rest string,
 			if len(rest) > 0 && rest[0] == '[' {
 				// A tag might follow the cluster. If so, we need to fix the state
 				// for the boundaries to be correct.
-				if length, _, _ := parseTag(rest, state, opts); length > 0 {
+				if length, _, _, _ := parseTag(rest, state, opts); length > 0 {
 					if len(rest) > length {
 						_, l := utf8.DecodeRuneInString(rest[length:])
 						cluster += rest[length : length+l]
@@ -217,8 +219,8 @@ func step(str string, state *stepState, opts stepOptions) (cluster, rest string,
 // parseTag parses str for consecutive style and/or region tags, assuming that
 // str starts with the opening bracket for the first tag. It returns the string
 // length of all valid tags (0 if the first tag is not valid) and the updated
-// style and region for valid tags (based on the provided state).
-func parseTag(str string, state *stepState, opts stepOptions) (length int, style tcell.Style, region string) {
+// style, region, and URL for valid tags (based on the provided state).
+func parseTag(str string, state *stepState, opts stepOptions) (length int, style tcell.Style, region, url string) {
 	if opts == stepOptionsNone {
 		return // No tags to parse.
 	}
@@ -271,6 +273,7 @@ func parseTag(str string, state *stepState, opts stepOptions) (length int, style
 	)
 	tStyle := state.style
 	tRegion := state.region
+	tURL := state.url
 
 	// Process state transitions.
 	for len(str) > 0 {
@@ -441,13 +444,14 @@ func parseTag(str string, state *stepState, opts stepOptions) (length int, style
 			if ch == ']' || ch == ':' {
 				flags := tempStr.String()
 				_, _, a := tStyle.Decompose()
+				underline := -1
 				for index := 0; index < len(flags); index++ {
 					ch := flags[index]
 					switch {
 					case ch == 'u':
-						tStyle = tStyle.Underline(true)
+						underline = 1
 					case ch == 'U':
-						tStyle = tStyle.Underline(false)
+						underline = 0
 					case ch >= 'a' && ch <= 'z':
 						a |= attrs[ch-('a'-'A')]
 					default:
@@ -455,6 +459,9 @@ func parseTag(str string, state *stepState, opts stepOptions) (length int, style
 					}
 				}
 				tStyle = tStyle.Attributes(a)
+				if underline >= 0 {
+					tStyle = tStyle.Underline(underline == 1)
+				}
 			}
 			switch {
 			case ch == ']': // End of tag.
@@ -481,6 +488,7 @@ func parseTag(str string, state *stepState, opts stepOptions) (length int, style
 				tagState = tagStateDoneTag
 			case '-': // Reset URL.
 				tStyle = tStyle.Url("").UrlId("")
+				tURL = ""
 				tagState = tagStateEndURL
 			default: // URL character.
 				tempStr.Reset()
@@ -497,6 +505,7 @@ func parseTag(str string, state *stepState, opts stepOptions) (length int, style
 		case tagStateURL:
 			if ch == ']' { // End of tag.
 				tStyle = tStyle.Url(tempStr.String())
+				tURL = tempStr.String()
 				tagState = tagStateDoneTag
 			} else { // URL character.
 				tempStr.WriteByte(ch)
@@ -531,7 +540,7 @@ func parseTag(str string, state *stepState, opts stepOptions) (length int, style
 
 		// The last transition led to a tag end. Make the tag permanent.
 		if tagState == tagStateDoneTag {
-			length, style, region = tagLength, tStyle, tRegion
+			length, style, region, url = tagLength, tStyle, tRegion, tURL
 			tagState = tagStateNone // Reset state.
 		}
 	}
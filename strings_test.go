@@ -0,0 +1,34 @@
+package tview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// TestParseTagUnderline tests that underline flags combine with other
+// attribute flags in style tags.
+func TestParseTagUnderline(t *testing.T) {
+	for _, test := range []struct {
+		text      string
+		initial   tcell.Style
+		bold      bool
+		underline bool
+	}{
+		{"[::bu]x", tcell.StyleDefault, true, true},
+		{"[::ub]x", tcell.StyleDefault, true, true},
+		{"[::u]x", tcell.StyleDefault.Bold(true), true, true},
+		{"[::Ub]x", tcell.StyleDefault.Underline(true), true, false},
+		{"[::bU]x", tcell.StyleDefault.Underline(true), true, false},
+		{"[::BU]x", tcell.StyleDefault.Bold(true).Underline(true), false, false},
+	} {
+		_, _, state := step(test.text, &stepState{unisegState: -1, style: test.initial}, stepOptionsStyle)
+		_, _, attributes := state.Style().Decompose()
+		if bold := attributes&tcell.AttrBold != 0; bold != test.bold {
+			t.Errorf("%q: expected bold=%t, got %t", test.text, test.bold, bold)
+		}
+		if underline := attributes&tcell.AttrUnderline != 0; underline != test.underline {
+			t.Errorf("%q: expected underline=%t, got %t", test.text, test.underline, underline)
+		}
+	}
+}
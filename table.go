@@ -516,6 +516,14 @@ type Table struct {
 	// drawn.
 	visibleColumnWidths []int
 
+	// The vertical and horizontal scroll bars.
+	scrollBars scrollBars
+
+	// Whether or not the columns didn't fit horizontally the last time the
+	// table was drawn, and whether or not we're currently redrawing the table
+	// because this value changed.
+	columnsOverflow, redrawing bool
+
 	// The style of the selected rows. If this value is the empty struct,
 	// selected rows are simply inverted.
 	selectedStyle tcell.Style
@@ -541,6 +549,7 @@ func NewTable() *Table {
 		Box:          NewBox(),
 		bordersColor: Styles.GraphicsColor,
 		separator:    ' ',
+		scrollBars:   newScrollBars(),
 	}
 	t.SetContent(nil)
 	t.Box.Primitive = t
@@ -672,6 +681,30 @@ func (t *Table) GetOffset() (row, column int) {
 	return t.rowOffset, t.columnOffset
 }
 
+// SetScrollBarVisibility sets when the vertical and the horizontal scroll bars
+// are shown (see [ScrollBarNever], [ScrollBarAuto], and [ScrollBarAlways]).
+// The vertical scroll bar indicates the position of the non-fixed rows within
+// all rows, the horizontal scroll bar does the same for columns.
+//
+// The thumb of a scroll bar can be dragged with the mouse.
+func (t *Table) SetScrollBarVisibility(vertical, horizontal ScrollBarVisibility) *Table {
+	t.scrollBars.setVisibility(vertical, horizontal)
+	return t
+}
+
+// SetScrollBarRunes sets the runes used to draw the scroll bars' tracks and
+// thumbs.
+func (t *Table) SetScrollBarRunes(track, thumb rune) *Table {
+	t.scrollBars.setRunes(track, thumb)
+	return t
+}
+
+// SetScrollBarStyles sets the styles of the scroll bars' tracks and thumbs.
+func (t *Table) SetScrollBarStyles(track, thumb tcell.Style) *Table {
+	t.scrollBars.setStyles(track, thumb)
+	return t
+}
+
 // SetEvaluateAllRows sets a flag which determines the rows to be evaluated when
 // calculating the widths of the table's columns. When false, only visible rows
 // are evaluated. When true, all rows in the table are evaluated.
@@ -878,6 +911,22 @@ func (t *Table) Draw(screen tcell.Screen) {
 	// What's our available screen space?
 	_, totalHeight := screen.Size()
 	x, y, width, height := t.GetInnerRect()
+
+	// Make room for the scroll bars.
+	t.scrollBars.hide()
+	screenRowsPerRow := 1
+	if t.borders {
+		screenRowsPerRow = 2
+	}
+	verticalBar := width > 1 && t.scrollBars.vertical.needed(screenRowsPerRow*t.content.GetRowCount(), height)
+	if verticalBar {
+		width--
+	}
+	horizontalBar := height > 1 && (t.scrollBars.horizontal.visibility == ScrollBarAlways ||
+		t.scrollBars.horizontal.visibility == ScrollBarAuto && t.columnsOverflow)
+	if horizontalBar {
+		height--
+	}
 	netWidth := width
 	if t.borders {
 		t.visibleRows = height / 2
@@ -999,6 +1048,7 @@ func (t *Table) Draw(screen tcell.Screen) {
 	var (
 		tableWidth, expansionTotal  int
 		columns, widths, expansions []int
+		clampedColumn               = -1 // The index of a column which doesn't fit entirely.
 	)
 	includesSelection := !t.clampToSelection || !t.columnsSelectable
 
@@ -1031,6 +1081,7 @@ func (t *Table) Draw(screen tcell.Screen) {
 		clampedMaxWidth := maxWidth
 		if tableWidth+maxWidth > netWidth {
 			clampedMaxWidth = netWidth - tableWidth
+			clampedColumn = column
 		}
 		columns = append(columns, column)
 		widths = append(widths, clampedMaxWidth)
@@ -1089,6 +1140,7 @@ func (t *Table) Draw(screen tcell.Screen) {
 		columns = columns[:t.fixedColumns]
 		widths = widths[:t.fixedColumns]
 		expansions = expansions[:t.fixedColumns]
+		clampedColumn = -1
 	}
 
 	// Add fixed columns.
@@ -1126,6 +1178,23 @@ func (t *Table) Draw(screen tcell.Screen) {
 		}
 	}
 
+	// Do all columns fit? If this changed, we may need to show or hide the
+	// horizontal scroll bar.
+	shownColumns := len(columns) - t.fixedColumns
+	if len(columns) > t.fixedColumns && columns[len(columns)-1] == clampedColumn {
+		shownColumns--
+	}
+	overflow := shownColumns < columnCount-t.fixedColumns
+	if overflow != t.columnsOverflow {
+		t.columnsOverflow = overflow
+		if t.scrollBars.horizontal.visibility == ScrollBarAuto && !t.redrawing {
+			t.redrawing = true
+			t.Draw(screen)
+			t.redrawing = false
+			return
+		}
+	}
+
 	// If we have space left, distribute it.
 	if tableWidth < netWidth {
 		toDistribute := netWidth - tableWidth
@@ -1364,6 +1433,16 @@ func (t *Table) Draw(screen tcell.Screen) {
 		}
 	}
 
+	// Draw the scroll bars.
+	if verticalBar {
+		fixedRows := min(t.fixedRows, rowCount)
+		t.scrollBars.vertical.draw(screen, x+width, y, height, rowCount-fixedRows, t.visibleRows-fixedRows, t.rowOffset)
+	}
+	if horizontalBar {
+		fixedColumns := min(t.fixedColumns, columnCount)
+		t.scrollBars.horizontal.draw(screen, x, y+height, width, columnCount-fixedColumns, max(shownColumns, 1), t.columnOffset)
+	}
+
 	// Remember column infos.
 	t.visibleColumnIndices, t.visibleColumnWidths = columns, widths
 }
@@ -1695,6 +1774,18 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 // MouseHandler returns the mouse handler for this primitive.
 func (t *Table) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		// Scroll bars.
+		if consumed, capture := t.scrollBars.mouseHandler(t, action, event, setFocus, func(vertical bool, offset int) {
+			if vertical {
+				t.rowOffset = offset
+				t.trackEnd = false
+			} else {
+				t.columnOffset = offset
+			}
+		}); consumed {
+			return consumed, capture
+		}
+
 		x, y := event.Position()
 		if !t.InRect(x, y) {
 			return false, nil
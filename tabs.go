@@ -0,0 +1,635 @@
+package tview
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// tab holds the tab strip information of one page of a [Tabs] primitive.
+type tab struct {
+	Name  string // The page's name.
+	Title string // The text shown in the tab.
+	Badge string // An optional text shown after the title, e.g. a counter.
+	Dirty bool   // Whether or not the dirty marker is shown.
+}
+
+// tabPosition is the position of a tab in the tab strip, as determined during
+// the last call to Draw().
+type tabPosition struct {
+	index  int // The index of the tab.
+	x      int // The left-most position of the tab.
+	width  int // The width of the tab, including the close button.
+	closeX int // The position of the close button, -1 if there is none.
+}
+
+// Tabs is a container of pages (see [Pages]) with a tab strip at the top which
+// shows one tab per page. Exactly one page (the current page) is visible at a
+// time. Clicking on a tab switches to its page.
+//
+// Tabs may show a close button (see [Tabs.SetCloseButtons]), a badge (see
+// [Tabs.SetPageBadge]), and a dirty marker (see [Tabs.SetPageDirty]). If the
+// tabs don't fit into the available width, arrows are shown which can be
+// clicked to scroll the tab strip.
+//
+// The following key binds are available as long as focus is within the
+// primitive:
+//
+//   - Ctrl-PgDn: Switch to the next page.
+//   - Ctrl-PgUp: Switch to the previous page.
+//   - Ctrl-Shift-PgDn: Move the current tab to the right.
+//   - Ctrl-Shift-PgUp: Move the current tab to the left.
+//
+// Tabs can also be reordered by dragging them with the mouse.
+type Tabs struct {
+	*Box
+
+	// The pages which hold the tab contents.
+	pages *Pages
+
+	// The tabs in the order in which they are shown.
+	tabs []*tab
+
+	// The name of the current page.
+	current string
+
+	// The index of the first visible tab.
+	tabOffset int
+
+	// The name of the page whose tab was last scrolled into view.
+	scrolledTo string
+
+	// Whether or not tabs show a close button.
+	closeButtons bool
+
+	// The style of the tab strip's background.
+	stripStyle tcell.Style
+
+	// The style of tabs which are not current.
+	tabStyle tcell.Style
+
+	// The style of the current tab.
+	currentTabStyle tcell.Style
+
+	// The style of badges (the background color is taken from the tab).
+	badgeStyle tcell.Style
+
+	// The string shown after the title of dirty pages.
+	dirtyMarker string
+
+	// The string used for close buttons.
+	closeString string
+
+	// The positions of the visible tabs and whether or not scroll arrows are
+	// shown, as determined during the last call to Draw().
+	tabPositions   []tabPosition
+	arrows         bool
+	canScrollRight bool
+
+	// The name of the tab which is being dragged with the mouse, empty if no
+	// tab is being dragged.
+	dragging string
+
+	// An optional handler which is called whenever the current page or the
+	// set or order of pages changes.
+	changed func()
+
+	// An optional handler which is called when the user clicks a close button.
+	// The page is only removed if it returns true.
+	closing func(name string) bool
+}
+
+// NewTabs returns a new [Tabs] object without any pages.
+func NewTabs() *Tabs {
+	t := &Tabs{
+		Box:             NewBox(),
+		pages:           NewPages(),
+		stripStyle:      tcell.StyleDefault.Background(Styles.PrimitiveBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		tabStyle:        tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		currentTabStyle: tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
+		badgeStyle:      tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		dirtyMarker:     "*",
+		closeString:     "×",
+	}
+	t.pages.SetChangedFunc(t.fireChanged)
+	t.Box.Primitive = t
+	return t
+}
+
+// fireChanged invokes the "changed" callback, if there is one.
+func (t *Tabs) fireChanged() {
+	if t.changed != nil {
+		t.changed()
+	}
+}
+
+// SetChangedFunc sets a handler which is called whenever the current page or
+// the set or order of pages changes.
+func (t *Tabs) SetChangedFunc(handler func()) *Tabs {
+	t.changed = handler
+	return t
+}
+
+// SetCloseFunc sets a handler which is called when the user clicks a tab's
+// close button. The page is removed only if the handler returns true. If no
+// handler is set, pages are always removed.
+func (t *Tabs) SetCloseFunc(handler func(name string) bool) *Tabs {
+	t.closing = handler
+	return t
+}
+
+// SetCloseButtons sets whether or not tabs show a close button.
+func (t *Tabs) SetCloseButtons(show bool) *Tabs {
+	t.closeButtons = show
+	return t
+}
+
+// SetStripStyle sets the style of the tab strip's background.
+func (t *Tabs) SetStripStyle(style tcell.Style) *Tabs {
+	t.stripStyle = style
+	return t
+}
+
+// SetTabStyles sets the style of tabs which are not current as well as the
+// style of the current tab.
+func (t *Tabs) SetTabStyles(normal, current tcell.Style) *Tabs {
+	t.tabStyle = normal
+	t.currentTabStyle = current
+	return t
+}
+
+// SetBadgeStyle sets the style of the tab badges. The background color is
+// ignored, the tab's background color is used instead.
+func (t *Tabs) SetBadgeStyle(style tcell.Style) *Tabs {
+	t.badgeStyle = style
+	return t
+}
+
+// SetDirtyMarker sets the string shown after the title of pages which were
+// marked as dirty with [Tabs.SetPageDirty]. The default is "*".
+func (t *Tabs) SetDirtyMarker(marker string) *Tabs {
+	t.dirtyMarker = marker
+	return t
+}
+
+// GetPages returns the [Pages] object holding the tab contents. Do not add or
+// remove pages directly, use the functions of this primitive instead.
+func (t *Tabs) GetPages() *Pages {
+	return t.pages
+}
+
+// GetPageCount returns the number of pages.
+func (t *Tabs) GetPageCount() int {
+	return len(t.tabs)
+}
+
+// GetPageNames returns the names of all pages in the order of their tabs.
+func (t *Tabs) GetPageNames() []string {
+	names := make([]string, 0, len(t.tabs))
+	for _, tab := range t.tabs {
+		names = append(names, tab.Name)
+	}
+	return names
+}
+
+// tabIndex returns the index of the tab with the given name or -1 if there is
+// no such tab.
+func (t *Tabs) tabIndex(name string) int {
+	for index, tab := range t.tabs {
+		if tab.Name == name {
+			return index
+		}
+	}
+	return -1
+}
+
+// AddPage adds a new page with the given name, tab title, and primitive. If
+// there was previously a page with the same name, it is replaced (keeping the
+// position of its tab). The primitive is resized to fill the space below the
+// tab strip.
+//
+// If "switchTo" is true or if this is the first page, the new page becomes the
+// current page.
+func (t *Tabs) AddPage(name, title string, item Primitive, switchTo bool) *Tabs {
+	if index := t.tabIndex(name); index >= 0 {
+		t.tabs[index].Title = title
+	} else {
+		t.tabs = append(t.tabs, &tab{Name: name, Title: title})
+	}
+	t.pages.AddPage(name, item, true, name == t.current)
+	if switchTo || t.current == "" {
+		t.SwitchToPage(name)
+	}
+	return t
+}
+
+// AddAndSwitchToPage calls [Tabs.AddPage] and switches to the new page.
+func (t *Tabs) AddAndSwitchToPage(name, title string, item Primitive) *Tabs {
+	return t.AddPage(name, title, item, true)
+}
+
+// RemovePage removes the page with the given name. If it was the current page,
+// the page to its right (or to its left if there is none) becomes the current
+// page.
+func (t *Tabs) RemovePage(name string) *Tabs {
+	index := t.tabIndex(name)
+	if index < 0 {
+		return t
+	}
+	t.tabs = append(t.tabs[:index], t.tabs[index+1:]...)
+	t.pages.RemovePage(name)
+	if name == t.current {
+		t.current = ""
+		if index >= len(t.tabs) {
+			index = len(t.tabs) - 1
+		}
+		if index >= 0 {
+			t.SwitchToPage(t.tabs[index].Name)
+		}
+	}
+	return t
+}
+
+// HasPage returns true if a page with the given name exists.
+func (t *Tabs) HasPage(name string) bool {
+	return t.tabIndex(name) >= 0
+}
+
+// GetPage returns the primitive of the page with the given name. If no such
+// page exists, nil is returned.
+func (t *Tabs) GetPage(name string) Primitive {
+	return t.pages.GetPage(name)
+}
+
+// SwitchToPage makes the page with the given name the current page.
+func (t *Tabs) SwitchToPage(name string) *Tabs {
+	if t.tabIndex(name) < 0 {
+		return t
+	}
+	t.current = name
+	t.pages.SwitchToPage(name)
+	return t
+}
+
+// GetFrontPage returns the name and primitive of the current page. If there
+// are no pages, ("", nil) is returned.
+func (t *Tabs) GetFrontPage() (name string, item Primitive) {
+	return t.pages.GetFrontPage()
+}
+
+// GetCurrentIndex returns the index of the current page's tab, starting with 0
+// for the left-most tab, or -1 if there are no pages.
+func (t *Tabs) GetCurrentIndex() int {
+	return t.tabIndex(t.current)
+}
+
+// MovePage moves the tab of the page with the given name to the given index.
+// Indices are clamped to the available range.
+func (t *Tabs) MovePage(name string, index int) *Tabs {
+	from := t.tabIndex(name)
+	if from < 0 {
+		return t
+	}
+	if index < 0 {
+		index = 0
+	} else if index >= len(t.tabs) {
+		index = len(t.tabs) - 1
+	}
+	if index == from {
+		return t
+	}
+	moved := t.tabs[from]
+	t.tabs = append(t.tabs[:from], t.tabs[from+1:]...)
+	t.tabs = append(t.tabs[:index], append([]*tab{moved}, t.tabs[index:]...)...)
+	t.fireChanged()
+	return t
+}
+
+// SetPageTitle sets the text shown in the tab of the page with the given name.
+func (t *Tabs) SetPageTitle(name, title string) *Tabs {
+	if index := t.tabIndex(name); index >= 0 {
+		t.tabs[index].Title = title
+	}
+	return t
+}
+
+// GetPageTitle returns the text shown in the tab of the page with the given
+// name.
+func (t *Tabs) GetPageTitle(name string) string {
+	if index := t.tabIndex(name); index >= 0 {
+		return t.tabs[index].Title
+	}
+	return ""
+}
+
+// SetPageBadge sets a text shown after the title of the page with the given
+// name, for example a number of unread items. Set to an empty string to remove
+// the badge.
+func (t *Tabs) SetPageBadge(name, badge string) *Tabs {
+	if index := t.tabIndex(name); index >= 0 {
+		t.tabs[index].Badge = badge
+	}
+	return t
+}
+
+// SetPageDirty sets whether or not the tab of the page with the given name
+// shows the dirty marker (see [Tabs.SetDirtyMarker]), e.g. to indicate unsaved
+// changes.
+func (t *Tabs) SetPageDirty(name string, dirty bool) *Tabs {
+	if index := t.tabIndex(name); index >= 0 {
+		t.tabs[index].Dirty = dirty
+	}
+	return t
+}
+
+// IsPageDirty returns whether or not the page with the given name was marked
+// as dirty.
+func (t *Tabs) IsPageDirty(name string) bool {
+	if index := t.tabIndex(name); index >= 0 {
+		return t.tabs[index].Dirty
+	}
+	return false
+}
+
+// tabWidth returns the screen width of the given tab.
+func (t *Tabs) tabWidth(tab *tab) int {
+	width := TaggedStringWidth(tab.Title) + 2
+	if tab.Dirty {
+		width += TaggedStringWidth(t.dirtyMarker)
+	}
+	if tab.Badge != "" {
+		width += TaggedStringWidth(tab.Badge) + 1
+	}
+	if t.closeButtons {
+		width += TaggedStringWidth(t.closeString) + 1
+	}
+	return width
+}
+
+// Draw draws this primitive onto the screen.
+func (t *Tabs) Draw(screen tcell.Screen) {
+	t.Box.DrawForSubclass(screen, t)
+	x, y, width, height := t.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Draw the pages.
+	if height > 1 {
+		t.pages.SetRect(x, y+1, width, height-1)
+		t.pages.Draw(screen)
+	}
+
+	// Draw the strip background.
+	for index := 0; index < width; index++ {
+		screen.SetContent(x+index, y, ' ', nil, t.stripStyle)
+	}
+
+	// Do we need scroll arrows?
+	widths := make([]int, len(t.tabs))
+	totalWidth := -1
+	for index, tab := range t.tabs {
+		widths[index] = t.tabWidth(tab)
+		totalWidth += widths[index] + 1
+	}
+	left, right := x, x+width
+	t.arrows = totalWidth > width && width > 4
+	if t.arrows {
+		left, right = x+2, x+width-2
+	} else {
+		t.tabOffset = 0
+	}
+
+	// Scroll the current tab into view if it changed.
+	current := t.tabIndex(t.current)
+	if t.tabOffset >= len(t.tabs) {
+		t.tabOffset = len(t.tabs) - 1
+	}
+	if current >= 0 && current < t.tabOffset && t.scrolledTo != t.current {
+		t.tabOffset = current
+	}
+	for current > t.tabOffset && t.scrolledTo != t.current {
+		used := -1
+		for index := t.tabOffset; index <= current; index++ {
+			used += widths[index] + 1
+		}
+		if used <= right-left {
+			break
+		}
+		t.tabOffset++
+	}
+	if t.tabOffset < 0 {
+		t.tabOffset = 0
+	}
+	t.scrolledTo = t.current
+	t.canScrollRight = t.arrows && left+totalWidth-t.offsetWidth(widths) > right
+
+	// Draw the arrows.
+	if t.arrows {
+		style := t.stripStyle
+		if t.tabOffset == 0 {
+			style = style.Dim(true)
+		}
+		printWithStyle(screen, "◀", x, y, 0, 1, AlignLeft, style, false)
+		style = t.stripStyle
+		if !t.canScrollRight {
+			style = style.Dim(true)
+		}
+		printWithStyle(screen, "▶", x+width-1, y, 0, 1, AlignLeft, style, false)
+	}
+
+	// Draw the tabs.
+	t.tabPositions = t.tabPositions[:0]
+	pos := left
+	for index := t.tabOffset; index < len(t.tabs) && pos < right; index++ {
+		tab := t.tabs[index]
+		style := t.tabStyle
+		if tab.Name == t.current {
+			style = t.currentTabStyle
+		}
+		_, background, _ := style.Decompose()
+		position := tabPosition{index: index, x: pos, width: widths[index], closeX: -1}
+		if pos+position.width > right {
+			position.width = right - pos
+		}
+		end := pos + position.width
+		for cx := pos; cx < end; cx++ {
+			screen.SetContent(cx, y, ' ', nil, style)
+		}
+		pos++
+		_, _, printed := printWithStyle(screen, tab.Title, pos, y, 0, end-pos, AlignLeft, style, false)
+		pos += printed
+		if tab.Dirty && pos < end {
+			_, _, printed = printWithStyle(screen, t.dirtyMarker, pos, y, 0, end-pos, AlignLeft, style, false)
+			pos += printed
+		}
+		if tab.Badge != "" && pos+1 < end {
+			_, _, printed = printWithStyle(screen, tab.Badge, pos+1, y, 0, end-pos-1, AlignLeft, t.badgeStyle.Background(background), false)
+			pos += printed + 1
+		}
+		if t.closeButtons && pos+1 < end {
+			_, _, printed = printWithStyle(screen, t.closeString, pos+1, y, 0, end-pos-1, AlignLeft, style, false)
+			if printed > 0 {
+				position.closeX = pos + 1
+			}
+		}
+		t.tabPositions = append(t.tabPositions, position)
+		pos = end + 1
+	}
+}
+
+// offsetWidth returns the width of the tabs (including the space between
+// them) which are scrolled out of view on the left, given the widths of all
+// tabs.
+func (t *Tabs) offsetWidth(widths []int) (width int) {
+	for index := 0; index < t.tabOffset && index < len(widths); index++ {
+		width += widths[index] + 1
+	}
+	return
+}
+
+// Focus is called when this primitive receives focus.
+func (t *Tabs) Focus(delegate func(p Primitive)) {
+	if t.current != "" && delegate != nil {
+		delegate(t.pages)
+		return
+	}
+	t.Box.Focus(delegate)
+}
+
+// focusChain implements the [Primitive]'s focusChain method.
+func (t *Tabs) focusChain(chain *[]Primitive) bool {
+	if hasFocus := t.pages.focusChain(chain); hasFocus {
+		if chain != nil {
+			*chain = append(*chain, t)
+		}
+		return true
+	}
+	return t.Box.focusChain(chain)
+}
+
+// switchBy switches to the page whose tab is "offset" tabs away from the
+// current tab, wrapping around.
+func (t *Tabs) switchBy(offset int) {
+	if len(t.tabs) == 0 {
+		return
+	}
+	index := t.tabIndex(t.current)
+	index = ((index+offset)%len(t.tabs) + len(t.tabs)) % len(t.tabs)
+	t.SwitchToPage(t.tabs[index].Name)
+}
+
+// InputHandler returns the handler for this primitive.
+func (t *Tabs) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if event.Modifiers()&tcell.ModCtrl != 0 {
+			key := event.Key()
+			if key == tcell.KeyPgDn || key == tcell.KeyPgUp {
+				direction := 1
+				if key == tcell.KeyPgUp {
+					direction = -1
+				}
+				if event.Modifiers()&tcell.ModShift != 0 {
+					t.MovePage(t.current, t.tabIndex(t.current)+direction)
+				} else {
+					t.switchBy(direction)
+				}
+				if !t.pages.HasFocus() {
+					setFocus(t)
+				}
+				return
+			}
+		}
+
+		// Pass other key events to the current page.
+		if t.pages.HasFocus() {
+			if handler := t.pages.InputHandler(); handler != nil {
+				handler(event, setFocus)
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (t *Tabs) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		x, y := event.Position()
+		if !t.InRect(x, y) && t.dragging == "" {
+			return false, nil
+		}
+
+		// Determine the tab at the mouse position.
+		rectX, rectY, width, _ := t.GetInnerRect()
+		var position *tabPosition
+		if y == rectY {
+			for index := range t.tabPositions {
+				p := &t.tabPositions[index]
+				if x >= p.x && x < p.x+p.width {
+					position = p
+					break
+				}
+			}
+		}
+
+		// Reorder tabs while dragging.
+		if t.dragging != "" {
+			switch action {
+			case MouseMove:
+				if position != nil {
+					t.MovePage(t.dragging, position.index)
+				}
+				return true, t
+			case MouseLeftUp:
+				t.dragging = ""
+				return true, nil
+			}
+		}
+
+		// Events on the pages.
+		if y != rectY {
+			return t.pages.MouseHandler()(action, event, setFocus)
+		}
+
+		// Events on the tab strip.
+		consumed = action != MouseMove
+		switch action {
+		case MouseLeftDown:
+			if position != nil && x != position.closeX {
+				t.SwitchToPage(t.tabs[position.index].Name)
+				setFocus(t)
+				t.dragging = t.tabs[position.index].Name
+				capture = t
+			}
+		case MouseLeftClick, MouseLeftDoubleClick:
+			if t.arrows && x == rectX {
+				t.tabOffset--
+				if t.tabOffset < 0 {
+					t.tabOffset = 0
+				}
+			} else if t.arrows && x == rectX+width-1 {
+				if t.canScrollRight {
+					t.tabOffset++
+				}
+			} else if position != nil && x == position.closeX && action == MouseLeftClick {
+				name := t.tabs[position.index].Name
+				if t.closing == nil || t.closing(name) {
+					t.RemovePage(name)
+				}
+			}
+		case MouseScrollUp, MouseScrollLeft:
+			t.switchBy(-1)
+		case MouseScrollDown, MouseScrollRight:
+			t.switchBy(1)
+		}
+		return
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (t *Tabs) PasteHandler() func(pastedText string, setFocus func(p Primitive)) {
+	return t.WrapPasteHandler(func(pastedText string, setFocus func(p Primitive)) {
+		if t.pages.HasFocus() {
+			if handler := t.pages.PasteHandler(); handler != nil {
+				handler(pastedText, setFocus)
+			}
+		}
+	})
+}
@@ -0,0 +1,345 @@
+package tview
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Terminal is a terminal emulator which runs a command (e.g. a shell, an editor,
+// or a system monitor) in a pseudo-terminal and shows its output. It
+// understands the VT100/xterm escape sequences used by most terminal
+// applications, including cursor movement, scroll regions, the alternate
+// screen, colors and text attributes, and mouse reporting.
+//
+// Start a command with [Terminal.Start]. The size of the pseudo-terminal
+// follows the size of the primitive. Keys, pasted text, and (if requested by
+// the command) mouse events are forwarded to the command while the terminal
+// has focus. Note that this includes keys such as Tab or Escape which are
+// usually used to navigate between primitives. Use
+// [Application.SetInputCapture] or [Box.SetInputCapture] to intercept keys
+// which switch the focus. Ctrl-C stops the application unless it is
+// intercepted in the same way.
+//
+// The terminal's output is processed in a separate goroutine. Use
+// [Terminal.SetChangedFunc] to redraw the application when the output changes.
+// The command's environment variable TERM is set to "xterm-256color" unless
+// the command's environment is set explicitly.
+//
+// Pseudo-terminals are currently only supported on Linux. On other platforms,
+// [Terminal.Start] returns an error.
+type Terminal struct {
+	sync.Mutex
+	*Box
+
+	// The terminal emulator.
+	emulator *vtEmulator
+
+	// The running command, or nil if no command was started.
+	cmd *exec.Cmd
+
+	// The master side of the pseudo-terminal, or nil if the command is not
+	// running.
+	pty *os.File
+
+	// The mouse button which is currently pressed, or -1 if none is pressed.
+	mouseButton int
+
+	// An optional function which is called when the terminal's output changes.
+	changed func()
+
+	// An optional function which is called when the command changes the
+	// terminal's title.
+	titleChanged func(title string)
+
+	// An optional function which is called when the command exits.
+	done func(err error)
+}
+
+// NewTerminal returns a new terminal. Call [Terminal.Start] to run a command
+// in it.
+func NewTerminal() *Terminal {
+	t := &Terminal{
+		Box:         NewBox(),
+		emulator:    newVTEmulator(80, 24),
+		mouseButton: -1,
+	}
+	t.Box.Primitive = t
+	return t
+}
+
+// Start runs the given command in the terminal. The command's standard input,
+// output, and error are connected to the terminal, any values set in the
+// command are replaced. The terminal is reset before the command is started.
+// An error is returned if the command could not be started or if another
+// command is still running in the terminal.
+func (t *Terminal) Start(cmd *exec.Cmd) error {
+	t.Lock()
+	defer t.Unlock()
+	if t.pty != nil {
+		return errors.New("a command is already running in this terminal")
+	}
+	if cmd.Env == nil {
+		cmd.Env = append(os.Environ(), "TERM=xterm-256color") // The last value takes precedence.
+	}
+	pty, err := startPty(cmd, t.emulator.cols, t.emulator.rows)
+	if err != nil {
+		return err
+	}
+	t.emulator.reset()
+	t.cmd, t.pty = cmd, pty
+	go t.read(cmd, pty)
+	return nil
+}
+
+// read processes the output of the command until the pseudo-terminal is
+// closed and then waits for the command to exit.
+func (t *Terminal) read(cmd *exec.Cmd, pty *os.File) {
+	buffer := make([]byte, 32*1024)
+	for {
+		n, err := pty.Read(buffer)
+		if n > 0 {
+			t.Lock()
+			title := t.emulator.title
+			t.emulator.Write(buffer[:n])
+			response := t.emulator.response
+			t.emulator.response = nil
+			titleChanged := t.titleChanged
+			if title == t.emulator.title {
+				titleChanged = nil
+			}
+			title = t.emulator.title
+			changed := t.changed
+			t.Unlock()
+
+			if len(response) > 0 {
+				pty.Write(response)
+			}
+			if titleChanged != nil {
+				titleChanged(title)
+			}
+			if changed != nil {
+				changed()
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	err := cmd.Wait()
+	t.Lock()
+	pty.Close()
+	if t.pty == pty {
+		t.pty = nil
+	}
+	changed, done := t.changed, t.done
+	t.Unlock()
+	if changed != nil {
+		changed()
+	}
+	if done != nil {
+		done(err)
+	}
+}
+
+// Close closes the terminal's pseudo-terminal. This usually causes the command
+// to exit (by sending it a SIGHUP signal). It has no effect if no command is
+// running.
+func (t *Terminal) Close() error {
+	t.Lock()
+	defer t.Unlock()
+	if t.pty == nil {
+		return nil
+	}
+	err := t.pty.Close()
+	t.pty = nil
+	return err
+}
+
+// IsRunning returns whether a command is running in the terminal.
+func (t *Terminal) IsRunning() bool {
+	t.Lock()
+	defer t.Unlock()
+	return t.pty != nil
+}
+
+// GetTerminalTitle returns the title most recently set by the command with an
+// escape sequence, or an empty string if no title was set.
+func (t *Terminal) GetTerminalTitle() string {
+	t.Lock()
+	defer t.Unlock()
+	return t.emulator.title
+}
+
+// SetChangedFunc sets a handler which is called when the terminal's output
+// changes. The handler is called from a separate goroutine. It is typically
+// used to redraw the application:
+//
+//	terminal.SetChangedFunc(func() {
+//		app.Draw()
+//	})
+func (t *Terminal) SetChangedFunc(handler func()) *Terminal {
+	t.Lock()
+	defer t.Unlock()
+	t.changed = handler
+	return t
+}
+
+// SetTitleChangedFunc sets a handler which is called when the command changes
+// the terminal's title, e.g. to show it as the title of the terminal's box.
+// The handler is called from a separate goroutine so it must use
+// [Application.QueueUpdateDraw] to make changes to primitives.
+func (t *Terminal) SetTitleChangedFunc(handler func(title string)) *Terminal {
+	t.Lock()
+	defer t.Unlock()
+	t.titleChanged = handler
+	return t
+}
+
+// SetDoneFunc sets a handler which is called when the command has exited. The
+// error is the value returned by [exec.Cmd.Wait]. The handler is called from a
+// separate goroutine so it must use [Application.QueueUpdateDraw] to make
+// changes to primitives.
+func (t *Terminal) SetDoneFunc(handler func(err error)) *Terminal {
+	t.Lock()
+	defer t.Unlock()
+	t.done = handler
+	return t
+}
+
+// send sends the given bytes to the command, if it is running.
+func (t *Terminal) send(p []byte) {
+	t.Lock()
+	pty := t.pty
+	t.Unlock()
+	if pty != nil && len(p) > 0 {
+		pty.Write(p)
+	}
+}
+
+// Draw draws this primitive onto the screen.
+func (t *Terminal) Draw(screen tcell.Screen) {
+	t.Box.DrawForSubclass(screen, t)
+
+	t.Lock()
+	defer t.Unlock()
+
+	// Adjust the size of the terminal.
+	x, y, width, height := t.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	e := t.emulator
+	if width != e.cols || height != e.rows {
+		e.resize(width, height)
+		if t.pty != nil {
+			resizePty(t.pty, width, height)
+		}
+	}
+
+	// Draw the cells.
+	for row, line := range e.cells {
+		for column, cell := range line {
+			if cell.width == 0 {
+				continue
+			}
+			style := cell.style
+			if _, background, _ := style.Decompose(); background == tcell.ColorDefault {
+				style = style.Background(t.backgroundColor)
+			}
+			main, combining := ' ', []rune(nil)
+			if cell.text != "" {
+				runes := []rune(cell.text)
+				main, combining = runes[0], runes[1:]
+			}
+			screen.SetContent(x+column, y+row, main, combining, style)
+		}
+	}
+
+	// Show the cursor.
+	if t.HasFocus() && e.cursorVisible && t.pty != nil {
+		screen.ShowCursor(x+e.x, y+e.y)
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (t *Terminal) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		t.Lock()
+		p := t.emulator.key(event)
+		t.Unlock()
+		t.send(p)
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (t *Terminal) PasteHandler() func(pastedText string, setFocus func(p Primitive)) {
+	return t.WrapPasteHandler(func(pastedText string, setFocus func(p Primitive)) {
+		text := strings.ReplaceAll(strings.ReplaceAll(pastedText, "\r\n", "\r"), "\n", "\r")
+		t.Lock()
+		if t.emulator.bracketedPaste {
+			text = "\x1b[200~" + text + "\x1b[201~"
+		}
+		t.Unlock()
+		t.send([]byte(text))
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (t *Terminal) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		x, y := event.Position()
+		if !t.InRect(x, y) && t.mouseButton < 0 {
+			return false, nil
+		}
+		if action == MouseLeftDown || action == MouseMiddleDown || action == MouseRightDown {
+			setFocus(t)
+			consumed = true
+		}
+
+		t.Lock()
+		e := t.emulator
+		if e.mouseMode == vtMouseNone || t.pty == nil {
+			t.Unlock()
+			t.mouseButton = -1
+			return
+		}
+
+		// Translate the action into an xterm mouse report.
+		rectX, rectY, width, height := t.GetInnerRect()
+		x = min(max(x-rectX, 0), width-1)
+		y = min(max(y-rectY, 0), height-1)
+		var report []byte
+		switch action {
+		case MouseLeftDown, MouseMiddleDown, MouseRightDown:
+			t.mouseButton = map[MouseAction]int{MouseLeftDown: 0, MouseMiddleDown: 1, MouseRightDown: 2}[action]
+			report = e.mouse(t.mouseButton, false, false, event.Modifiers(), x, y)
+			capture = t
+		case MouseLeftUp, MouseMiddleUp, MouseRightUp:
+			button := map[MouseAction]int{MouseLeftUp: 0, MouseMiddleUp: 1, MouseRightUp: 2}[action]
+			report = e.mouse(button, true, false, event.Modifiers(), x, y)
+			t.mouseButton = -1
+		case MouseMove:
+			if t.mouseButton >= 0 && e.mouseMode != vtMouseClick {
+				report = e.mouse(t.mouseButton, false, true, event.Modifiers(), x, y)
+				capture = t
+			} else if e.mouseMode == vtMouseMotion {
+				report = e.mouse(3, false, true, event.Modifiers(), x, y)
+			}
+		case MouseScrollUp, MouseScrollDown, MouseScrollLeft, MouseScrollRight:
+			button := map[MouseAction]int{MouseScrollUp: 64, MouseScrollDown: 65, MouseScrollLeft: 66, MouseScrollRight: 67}[action]
+			report = e.mouse(button, false, false, event.Modifiers(), x, y)
+		default:
+			t.Unlock()
+			return
+		}
+		t.Unlock()
+		t.send(report)
+		return true, capture
+	})
+}
@@ -0,0 +1,86 @@
+package tview
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// startPty starts the given command in a new pseudo-terminal of the given size
+// and returns the master side of the pseudo-terminal. The command's standard
+// input, output, and error are connected to the pseudo-terminal which also
+// becomes its controlling terminal.
+func startPty(cmd *exec.Cmd, cols, rows int) (*os.File, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := master.SyscallConn()
+	if err != nil {
+		master.Close()
+		return nil, err
+	}
+
+	// Unlock the pseudo-terminal and determine the name of its slave side.
+	var number uint32
+	controlErr := conn.Control(func(fd uintptr) {
+		if err = unix.IoctlSetPointerInt(int(fd), unix.TIOCSPTLCK, 0); err != nil {
+			return
+		}
+		number, err = unix.IoctlGetUint32(int(fd), unix.TIOCGPTN)
+	})
+	if err == nil {
+		err = controlErr
+	}
+	if err != nil {
+		master.Close()
+		return nil, err
+	}
+	slave, err := os.OpenFile("/dev/pts/"+strconv.FormatUint(uint64(number), 10), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		return nil, err
+	}
+	defer slave.Close()
+	if err := resizePty(master, cols, rows); err != nil {
+		master.Close()
+		return nil, err
+	}
+
+	// Start the command in a new session.
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = slave, slave, slave
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setsid = true
+	cmd.SysProcAttr.Setctty = true
+	cmd.SysProcAttr.Ctty = 0 // Standard input in the child process.
+	if err := cmd.Start(); err != nil {
+		master.Close()
+		return nil, err
+	}
+	return master, nil
+}
+
+// resizePty sets the size of the pseudo-terminal with the given master side.
+// The kernel notifies the processes running in the pseudo-terminal with a
+// SIGWINCH signal.
+func resizePty(master *os.File, cols, rows int) error {
+	conn, err := master.SyscallConn()
+	if err != nil {
+		return err
+	}
+	controlErr := conn.Control(func(fd uintptr) {
+		err = unix.IoctlSetWinsize(int(fd), unix.TIOCSWINSZ, &unix.Winsize{
+			Row: uint16(rows),
+			Col: uint16(cols),
+		})
+	})
+	if err == nil {
+		err = controlErr
+	}
+	return err
+}
@@ -0,0 +1,23 @@
+//go:build !linux
+
+package tview
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// errPtyUnsupported is returned when pseudo-terminals are not supported on the
+// current platform.
+var errPtyUnsupported = errors.New("terminals are only supported on Linux")
+
+// startPty is not supported on this platform.
+func startPty(cmd *exec.Cmd, cols, rows int) (*os.File, error) {
+	return nil, errPtyUnsupported
+}
+
+// resizePty is not supported on this platform.
+func resizePty(master *os.File, cols, rows int) error {
+	return errPtyUnsupported
+}
@@ -253,6 +253,16 @@ type TextArea struct {
 	// to hide characters from the screen while preserving the original text.
 	transform func(cluster, rest string, boundaries int) (newCluster string, newBoundaries int)
 
+	// An optional highlighter which determines the styles of the text.
+	highlighter Highlighter
+
+	// The lines of the text as highlighted by the highlighter.
+	highlightedLines []highlightedLine
+
+	// Set to true when the whole text needs to be highlighted again. Edits
+	// made with [TextArea.replace] only update the affected lines.
+	rehighlight bool
+
 	// Display, navigation, and cursor related fields:
 
 	// If set to true, lines that are longer than the available width are
@@ -273,6 +283,9 @@ type TextArea struct {
 	// The inner height and width of the text area the last time it was drawn.
 	lastHeight, lastWidth int
 
+	// The vertical and horizontal scroll bars.
+	scrollBars scrollBars
+
 	// The width of the currently known widest line, as determined by
 	// [TextArea.extendLines].
 	widestLine int
@@ -363,6 +376,7 @@ func NewTextArea() *TextArea {
 		minCursorSuffix:  minCursorSuffixDefault,
 		lastWidth:        math.MaxInt / 2, // We need this so some functions work before the first draw.
 		lastHeight:       1,
+		scrollBars:       newScrollBars(),
 	}
 	t.editText.Grow(editBufferMinCap)
 	t.spans[0] = textAreaSpan{previous: -1, next: 1}
@@ -423,6 +437,7 @@ func (t *TextArea) SetText(text string, cursorAtTheEnd bool) *TextArea {
 		t.spans[1].previous = 0
 	}
 	t.selectionStart = t.cursor
+	t.rehighlight = true
 
 	if t.changed != nil {
 		t.changed()
@@ -756,6 +771,31 @@ RowLoop:
 	return t
 }
 
+// SetScrollBarVisibility sets when the vertical and the horizontal scroll bars
+// are shown (see [ScrollBarNever], [ScrollBarAuto], and [ScrollBarAlways]).
+// The horizontal scroll bar is only shown if wrapping is turned off. Note that
+// in order to show the vertical scroll bar, the text area needs to lay out the
+// entire text which is an expensive operation for large texts.
+//
+// The thumb of a scroll bar can be dragged with the mouse.
+func (t *TextArea) SetScrollBarVisibility(vertical, horizontal ScrollBarVisibility) *TextArea {
+	t.scrollBars.setVisibility(vertical, horizontal)
+	return t
+}
+
+// SetScrollBarRunes sets the runes used to draw the scroll bars' tracks and
+// thumbs.
+func (t *TextArea) SetScrollBarRunes(track, thumb rune) *TextArea {
+	t.scrollBars.setRunes(track, thumb)
+	return t
+}
+
+// SetScrollBarStyles sets the styles of the scroll bars' tracks and thumbs.
+func (t *TextArea) SetScrollBarStyles(track, thumb tcell.Style) *TextArea {
+	t.scrollBars.setStyles(track, thumb)
+	return t
+}
+
 // SetWrap sets the flag that, if true, leads to lines that are longer than the
 // available width being wrapped onto the next line. If false, any characters
 // beyond the available width are not displayed.
@@ -842,6 +882,21 @@ func (t *TextArea) GetDisabled() bool {
 	return t.disabled
 }
 
+// SetHighlighter sets a highlighter which determines the styles of the text,
+// e.g. for syntax highlighting (see [SyntaxHighlighter]). The highlighter's
+// styles are applied on top of the text style (see [TextArea.SetTextStyle]).
+// Selected text is always shown in the selected style. Set to nil to remove
+// the highlighter.
+//
+// When the text is edited, only the lines affected by the change are
+// highlighted again.
+func (t *TextArea) SetHighlighter(highlighter Highlighter) *TextArea {
+	t.highlighter = highlighter
+	t.highlightedLines = nil
+	t.rehighlight = true
+	return t
+}
+
 // SetMaxLength sets the maximum number of bytes allowed in the text area. A
 // value of 0 means there is no limit. If the text area currently contains more
 // bytes than this, it may violate this constraint.
@@ -904,6 +959,18 @@ func (t *TextArea) GetOffset() (row, column int) {
 	return t.rowOffset, t.columnOffset
 }
 
+// GetWrappedLineCount returns the number of lines in the text area, taking
+// wrapping into account (if activated). This is an expensive call as it needs
+// to lay out the text until the end. Calling this method before the text area
+// was drawn for the first time will assume no wrapping.
+func (t *TextArea) GetWrappedLineCount() int {
+	if t.length == 0 {
+		return 0
+	}
+	t.extendLines(t.lastWidth, math.MaxInt32)
+	return len(t.lineStarts)
+}
+
 // SetOffset sets the text's offset, that is, the number of rows and columns
 // skipped during drawing at the top or on the left, respectively. If wrapping
 // is enabled, the column offset is ignored. These values may get adjusted
@@ -1005,6 +1072,7 @@ func (t *TextArea) replace(deleteStart, deleteEnd [3]int, insert string, continu
 	}
 
 	// Notify at the end.
+	t.updateHighlight(deleteStart, deleteEnd, insert)
 	if t.changed != nil {
 		defer t.changed()
 	}
@@ -1193,6 +1261,39 @@ func (t *TextArea) Draw(screen tcell.Screen) {
 		return // No space left for the text area.
 	}
 
+	// Make room for the scroll bars.
+	t.scrollBars.hide()
+	var verticalBar, horizontalBar bool
+	if width > 1 {
+		switch t.scrollBars.vertical.visibility {
+		case ScrollBarAlways:
+			verticalBar = true
+		case ScrollBarAuto:
+			// To avoid unnecessary layouts, we use the width of the last draw
+			// if the scroll bar was visible then.
+			probeWidth := width
+			if t.lastWidth == width-1 {
+				probeWidth = width - 1
+			}
+			verticalBar = t.length > 0 && t.overflows(probeWidth, height)
+		}
+		if verticalBar {
+			width--
+		}
+	}
+	if !t.wrap && height > 1 && t.scrollBars.horizontal.needed(t.widestLine, width) {
+		horizontalBar = true
+		height--
+	}
+	defer func() {
+		if verticalBar {
+			t.scrollBars.vertical.draw(screen, x+width, y, height, max(t.GetWrappedLineCount(), 1), height, t.rowOffset)
+		}
+		if horizontalBar {
+			t.scrollBars.horizontal.draw(screen, x, y+height, width, t.widestLine, width, t.columnOffset)
+		}
+	}()
+
 	// Draw the input element if necessary.
 	_, bg, _ := t.textStyle.Decompose()
 	if t.disabled {
@@ -1258,6 +1359,17 @@ func (t *TextArea) Draw(screen tcell.Screen) {
 		}
 	}
 
+	// Update the highlighted text.
+	highlight := t.highlighter != nil && t.transform == nil
+	var offset int
+	if highlight {
+		if t.rehighlight {
+			t.highlightedLines = highlightLines(t.highlighter, t.GetText(), t.highlightedLines)
+			t.rehighlight = false
+		}
+		offset = t.textOffset(t.lineStarts[t.rowOffset])
+	}
+
 	// Print the text.
 	var cluster, text string
 	line := t.rowOffset
@@ -1266,7 +1378,9 @@ func (t *TextArea) Draw(screen tcell.Screen) {
 	posX, posY := 0, 0
 	for pos[0] != 1 {
 		var clusterWidth int
+		clusterOffset := offset
 		cluster, text, _, clusterWidth, pos, endPos = t.step(text, pos, endPos)
+		offset += len(cluster)
 
 		// Prepare drawing.
 		runes := []rune(cluster)
@@ -1284,6 +1398,9 @@ func (t *TextArea) Draw(screen tcell.Screen) {
 			if t.disabled {
 				style = style.Background(t.backgroundColor)
 			}
+			if highlight {
+				style = highlightStyle(t.highlightedLines, clusterOffset, style)
+			}
 		}
 
 		// Selected tabs are a bit special.
@@ -1312,6 +1429,33 @@ func (t *TextArea) Draw(screen tcell.Screen) {
 	}
 }
 
+// textOffset returns the byte position of the given span position within the
+// entire text.
+func (t *TextArea) textOffset(pos [3]int) int {
+	if pos[0] == 1 {
+		return t.length
+	}
+	var offset int
+	for span := t.spans[0].next; span != 1 && span != pos[0]; span = t.spans[span].next {
+		offset += abs(t.spans[span].length)
+	}
+	return offset + pos[1]
+}
+
+// updateHighlight updates the highlighted lines before the text between the
+// given positions is replaced with the given text.
+func (t *TextArea) updateHighlight(deleteStart, deleteEnd [3]int, insert string) {
+	if t.highlighter == nil || t.rehighlight || t.highlightedLines == nil {
+		t.rehighlight = true
+		return
+	}
+	start, end := t.textOffset(deleteStart), t.textOffset(deleteEnd)
+	t.highlightedLines = updateHighlightedLines(t.highlighter, t.highlightedLines, start, end, insert)
+	if t.highlightedLines == nil {
+		t.rehighlight = true
+	}
+}
+
 // drawPlaceholder draws the placeholder text into the given rectangle. It does
 // not do anything if the text area already contains text or if there is no
 // placeholder text.
@@ -1325,6 +1469,17 @@ func (t *TextArea) drawPlaceholder(screen tcell.Screen, x, y, width, height int)
 	textView.Draw(screen)
 }
 
+// overflows returns true if the text, laid out for the given width, has more
+// than the given number of lines. The layout is reset if the width changed.
+func (t *TextArea) overflows(width, height int) bool {
+	if t.lastWidth != width && t.lineStarts != nil {
+		t.reset()
+	}
+	t.lastWidth = width
+	t.extendLines(width, height)
+	return len(t.lineStarts) > height
+}
+
 // reset resets many of the local variables of the text area because they cannot
 // be used anymore and must be recalculated, typically after the text area's
 // size has changed.
@@ -2293,6 +2448,7 @@ func (t *TextArea) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 			t.truncateLines(0) // This is why Undo is expensive for large texts. (t.lineStarts can get largely unusable after an undo.)
 			t.findCursor(true, 0)
 			t.selectionStart = t.cursor
+			t.rehighlight = true
 			if t.changed != nil {
 				defer t.changed()
 			}
@@ -2315,6 +2471,7 @@ func (t *TextArea) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 			t.truncateLines(0) // This is why Redo is expensive for large texts. (t.lineStarts can get largely unusable after an undo.)
 			t.findCursor(true, 0)
 			t.selectionStart = t.cursor
+			t.rehighlight = true
 			if t.changed != nil {
 				defer t.changed()
 			}
@@ -2329,6 +2486,17 @@ func (t *TextArea) MouseHandler() func(action MouseAction, event *tcell.EventMou
 			return false, nil
 		}
 
+		// Scroll bars.
+		if consumed, capture := t.scrollBars.mouseHandler(t, action, event, setFocus, func(vertical bool, offset int) {
+			if vertical {
+				t.rowOffset = offset
+			} else {
+				t.columnOffset = offset
+			}
+		}); consumed {
+			return consumed, capture
+		}
+
 		x, y := event.Position()
 		rectX, rectY, _, _ := t.GetInnerRect()
 		if !t.InRect(x, y) {
@@ -2,6 +2,7 @@ package tview
 
 import (
 	"math"
+	"slices"
 	"strings"
 	"sync"
 
@@ -38,6 +39,20 @@ type Region struct {
 	StartRow, StartColumn, EndRow, EndColumn int
 }
 
+// textViewLink is a link (a range of text with a URL) visible in a text view.
+type textViewLink struct {
+	// The link's URL.
+	url string
+
+	// The string position in the buffer of the link's first visible
+	// character.
+	start int
+
+	// The parts of the link on screen, one per screen row: the row, the first
+	// column, and the column after the last character, in screen coordinates.
+	parts [][3]int
+}
+
 // batchWriter is a writer that can be used to write to and clear a TextView
 // in batches, i.e. multiple writes with the lock only being acquired once.
 // Don't instantiated this class directly but use the [TextView.BatchWriter]
@@ -134,6 +149,13 @@ func (w *batchWriter) focusChain(chain *[]Primitive) bool {
 // The [TextView.ScrollToHighlight] function can be used to jump to the
 // currently highlighted region once when the text view is drawn the next time.
 //
+// # Links
+//
+// Text with a URL (see the package documentation) is a link. Use
+// [TextView.SetLinkClickedFunc] to react to links being clicked. This also
+// allows the user to select the visible links with the Tab and Backtab keys
+// and to activate the selected link with the Enter key.
+//
 // # Large Texts
 //
 // The text view can handle reasonably large texts. It will parse the text as
@@ -212,6 +234,9 @@ type TextView struct {
 	// navigated when the text is longer than what fits into the box.
 	scrollable bool
 
+	// The vertical and horizontal scroll bars.
+	scrollBars scrollBars
+
 	// If set to true, lines that are longer than the available width are
 	// wrapped onto the next line. If set to false, any characters beyond the
 	// available width are discarded.
@@ -231,6 +256,16 @@ type TextView struct {
 	// Whether or not region tags are used.
 	regionTags bool
 
+	// An optional highlighter which determines the styles of the text.
+	highlighter Highlighter
+
+	// The lines of the text as highlighted by the highlighter.
+	highlightedLines []highlightedLine
+
+	// Set to true when the text has changed and the highlighted lines need
+	// to be updated.
+	rehighlight bool
+
 	// A temporary flag which, when true, will automatically bring the current
 	// highlight(s) into the visible screen the next time the text view is
 	// drawn.
@@ -251,22 +286,36 @@ type TextView struct {
 	// An optional function which is called when one or more regions were
 	// highlighted.
 	highlighted func(added, removed, remaining []string)
+
+	// The links visible the last time the text view was drawn, in the order
+	// of their appearance.
+	links []*textViewLink
+
+	// The string position of the first visible character of the selected
+	// link, or -1 if no link is selected.
+	selectedLink int
+
+	// An optional function which is called when a link is clicked or
+	// activated with the keyboard.
+	linkClicked func(url string)
 }
 
 // NewTextView returns a new [TextView].
 func NewTextView() *TextView {
 	t := &TextView{
-		Box:        NewBox(),
-		labelStyle: tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
-		highlights: make(map[string]struct{}),
-		lineOffset: -1,
-		scrollable: true,
-		align:      AlignLeft,
-		wrap:       true,
-		wordWrap:   true,
-		textStyle:  tcell.StyleDefault.Background(Styles.PrimitiveBackgroundColor).Foreground(Styles.PrimaryTextColor),
-		regionTags: false,
-		styleTags:  false,
+		Box:          NewBox(),
+		labelStyle:   tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		highlights:   make(map[string]struct{}),
+		lineOffset:   -1,
+		selectedLink: -1,
+		scrollable:   true,
+		align:        AlignLeft,
+		wrap:         true,
+		wordWrap:     true,
+		textStyle:    tcell.StyleDefault.Background(Styles.PrimitiveBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		regionTags:   false,
+		styleTags:    false,
+		scrollBars:   newScrollBars(),
 	}
 	t.Box.Primitive = t
 	return t
@@ -331,6 +380,32 @@ func (t *TextView) SetScrollable(scrollable bool) *TextView {
 	return t
 }
 
+// SetScrollBarVisibility sets when the vertical and the horizontal scroll bars
+// are shown (see [ScrollBarNever], [ScrollBarAuto], and [ScrollBarAlways]).
+// Scroll bars are only shown if the text view is scrollable. The horizontal
+// scroll bar is only shown if wrapping is turned off. Note that in order to
+// show the vertical scroll bar, the text view needs to determine the total
+// number of lines which is an expensive operation for large texts.
+//
+// The thumb of a scroll bar can be dragged with the mouse.
+func (t *TextView) SetScrollBarVisibility(vertical, horizontal ScrollBarVisibility) *TextView {
+	t.scrollBars.setVisibility(vertical, horizontal)
+	return t
+}
+
+// SetScrollBarRunes sets the runes used to draw the scroll bars' tracks and
+// thumbs.
+func (t *TextView) SetScrollBarRunes(track, thumb rune) *TextView {
+	t.scrollBars.setRunes(track, thumb)
+	return t
+}
+
+// SetScrollBarStyles sets the styles of the scroll bars' tracks and thumbs.
+func (t *TextView) SetScrollBarStyles(track, thumb tcell.Style) *TextView {
+	t.scrollBars.setStyles(track, thumb)
+	return t
+}
+
 // SetWrap sets the flag that, if true, leads to lines that are longer than the
 // available width being wrapped onto the next line. If false, any characters
 // beyond the available width are not displayed.
@@ -400,6 +475,22 @@ func (t *TextView) SetTextStyle(style tcell.Style) *TextView {
 	return t
 }
 
+// SetHighlighter sets a highlighter which determines the styles of the text,
+// e.g. for syntax highlighting (see [SyntaxHighlighter]). The highlighter's
+// styles are applied on top of the text style (see [TextView.SetTextStyle])
+// and any style tags. Set to nil to remove the highlighter.
+//
+// The highlighter processes the text as it is stored, i.e. including any style
+// or region tags. It is therefore best used with dynamic colors and regions
+// switched off. When text is added to the text view, only new lines and lines
+// affected by the change are highlighted.
+func (t *TextView) SetHighlighter(highlighter Highlighter) *TextView {
+	t.highlighter = highlighter
+	t.highlightedLines = nil
+	t.rehighlight = true
+	return t
+}
+
 // SetText sets the text of this text view to the provided string. Previously
 // contained text will be removed. As with writing to the text view io.Writer
 // interface directly, this does not trigger an automatic redraw but it will
@@ -534,6 +625,29 @@ func (t *TextView) SetDoneFunc(handler func(key tcell.Key)) *TextView {
 	return t
 }
 
+// SetLinkClickedFunc sets a handler which is called when the user clicks on a
+// link or activates it with the keyboard. Links are created with the URL field
+// of style tags (see the package documentation), e.g. "[:::help:intro]an
+// introduction[:::-]", so dynamic colors must be switched on (see
+// [TextView.SetDynamicColors]). The URL does not have to be a valid URL, the
+// handler may interpret it as it likes, e.g. to implement a simple help
+// browser.
+//
+// When a handler is set, the following keys can be used to navigate between
+// the links visible on screen:
+//
+//   - Tab: Select the next link.
+//   - Backtab: Select the previous link.
+//   - Enter: Activate the selected link.
+//
+// The selected link is highlighted. These keys are only passed on to the
+// "done" handler (see [TextView.SetDoneFunc]) if there are no visible links
+// (or, for Enter, if no visible link is selected).
+func (t *TextView) SetLinkClickedFunc(handler func(url string)) *TextView {
+	t.linkClicked = handler
+	return t
+}
+
 // SetHighlightedFunc sets a handler which is called when the list of currently
 // highlighted regions change. It receives a list of region IDs which were newly
 // highlighted, those that are not highlighted anymore, and those that remain
@@ -893,6 +1007,7 @@ func (t *TextView) write(p []byte) (n int, err error) {
 		}()
 	}
 
+	t.rehighlight = true
 	return t.text.Write(p)
 }
 
@@ -922,10 +1037,26 @@ func (t *TextView) BatchWriter() *batchWriter {
 // resetIndex resets all indexed data, including the line index.
 func (t *TextView) resetIndex() {
 	t.lineIndex = nil
+	t.selectedLink = -1
+	t.rehighlight = true
 	t.regions = make(map[string]int)
 	t.longestLine = 0
 }
 
+// overflows returns true if the text, wrapped at the given width, has more
+// than the given number of lines. The line index is rebuilt if the width
+// changed.
+func (t *TextView) overflows(width, height int) bool {
+	if width != t.lastWidth && t.wrap {
+		t.resetIndex()
+	}
+	t.lastWidth = width
+	t.parseAhead(width, func(lineNumber int, line *textViewLine) bool {
+		return lineNumber >= height
+	})
+	return len(t.lineIndex) > height
+}
+
 // parseAhead parses the text buffer starting at the last line in
 // [TextView.lineIndex] until either the end of the buffer or until stop returns
 // true for the last complete line that was parsed. If wrapping is enabled,
@@ -1160,6 +1291,37 @@ func (t *TextView) Draw(screen tcell.Screen) {
 		return // No space left for the text area.
 	}
 
+	// Make room for the scroll bars.
+	t.scrollBars.hide()
+	var verticalBar, horizontalBar bool
+	if t.scrollable && width > 1 {
+		switch t.scrollBars.vertical.visibility {
+		case ScrollBarAlways:
+			verticalBar = true
+		case ScrollBarAuto:
+			// To avoid unnecessary reindexing, we use the width of the last
+			// draw if the scroll bar was visible then.
+			probeWidth := width
+			if t.lastWidth == width-1 {
+				probeWidth = width - 1
+			}
+			verticalBar = t.overflows(probeWidth, height)
+		}
+		if verticalBar {
+			width--
+		}
+	}
+	if t.scrollable && !t.wrap && height > 1 && t.scrollBars.horizontal.visibility != ScrollBarNever {
+		t.parseAhead(width, func(lineNumber int, line *textViewLine) bool {
+			return lineNumber >= t.lineOffset+height
+		})
+		if t.scrollBars.horizontal.needed(t.longestLine, width) {
+			horizontalBar = true
+			height--
+		}
+	}
+	t.pageSize = height
+
 	// Draw the text element if necessary.
 	_, bg, _ := t.textStyle.Decompose()
 	if bg != t.backgroundColor {
@@ -1291,7 +1453,15 @@ func (t *TextView) Draw(screen tcell.Screen) {
 		}
 	}
 
+	// Update the highlighted text.
+	if t.highlighter != nil && t.rehighlight {
+		t.highlightedLines = highlightLines(t.highlighter, t.text.String(), t.highlightedLines)
+		t.rehighlight = false
+	}
+
 	// Draw visible lines.
+	t.links = nil
+	var link *textViewLink
 	for line := t.lineOffset; line < len(t.lineIndex); line++ {
 		// Are we done?
 		if line-t.lineOffset >= height {
@@ -1338,6 +1508,7 @@ func (t *TextView) Draw(screen tcell.Screen) {
 					w = TabSize
 				}
 			}
+			offset := info.offset + processed + max(state.GrossLength()-len(ch), 0) // Skip preceding tags.
 			processed += state.GrossLength()
 
 			// Don't draw anything while we skip characters.
@@ -1349,6 +1520,25 @@ func (t *TextView) Draw(screen tcell.Screen) {
 			// Draw this character.
 			if w > 0 {
 				style := state.Style()
+				if t.highlighter != nil {
+					style = highlightStyle(t.highlightedLines, offset, style)
+				}
+
+				// Remember links.
+				if state.url == "" {
+					link = nil
+				} else {
+					if link == nil || link.url != state.url {
+						link = &textViewLink{url: state.url, start: offset}
+						t.links = append(t.links, link)
+					}
+					row, last := y+line-t.lineOffset, len(link.parts)-1
+					if last >= 0 && link.parts[last][0] == row && link.parts[last][2] == x+xPos {
+						link.parts[last][2] += w
+					} else {
+						link.parts = append(link.parts, [3]int{row, x + xPos, x + xPos + w})
+					}
+				}
 
 				// Do we highlight this character?
 				var highlighted bool
@@ -1357,6 +1547,9 @@ func (t *TextView) Draw(screen tcell.Screen) {
 						highlighted = true
 					}
 				}
+				if link != nil && link.start == t.selectedLink {
+					highlighted = true
+				}
 				if highlighted {
 					fg, bg, _ := style.Decompose()
 					if bg == t.backgroundColor {
@@ -1387,6 +1580,17 @@ func (t *TextView) Draw(screen tcell.Screen) {
 		}
 	}
 
+	// Draw the scroll bars.
+	if verticalBar {
+		t.parseAhead(width, func(lineNumber int, line *textViewLine) bool {
+			return false
+		})
+		t.scrollBars.vertical.draw(screen, x+width, y, height, len(t.lineIndex), height, t.lineOffset)
+	}
+	if horizontalBar {
+		t.scrollBars.horizontal.draw(screen, x, y+height, width, t.longestLine, width, t.columnOffset)
+	}
+
 	// If this view is not scrollable, we'll purge the buffer of lines that have
 	// scrolled out of view.
 	var purgeStart int
@@ -1416,6 +1620,9 @@ func (t *TextView) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 		key := event.Key()
 
 		if key == tcell.KeyEscape || key == tcell.KeyEnter || key == tcell.KeyTab || key == tcell.KeyBacktab {
+			if t.linkClicked != nil && t.navigateLinks(key) {
+				return
+			}
 			if t.done != nil {
 				t.done(key)
 			}
@@ -1471,9 +1678,64 @@ func (t *TextView) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 	})
 }
 
+// navigateLinks selects or activates the visible links according to the
+// given key. It returns whether the key was handled.
+func (t *TextView) navigateLinks(key tcell.Key) bool {
+	if len(t.links) == 0 {
+		return false
+	}
+	selected := slices.IndexFunc(t.links, func(link *textViewLink) bool {
+		return link.start == t.selectedLink
+	})
+	switch key {
+	case tcell.KeyEnter:
+		if selected < 0 {
+			return false
+		}
+		t.linkClicked(t.links[selected].url)
+	case tcell.KeyTab:
+		selected = (selected + 1) % len(t.links)
+		t.selectedLink = t.links[selected].start
+	case tcell.KeyBacktab:
+		if selected < 0 {
+			selected = len(t.links)
+		}
+		selected = (selected - 1 + len(t.links)) % len(t.links)
+		t.selectedLink = t.links[selected].start
+	default:
+		return false
+	}
+	return true
+}
+
+// linkAt returns the visible link at the given screen position, or nil if
+// there is no link at that position.
+func (t *TextView) linkAt(x, y int) *textViewLink {
+	for _, link := range t.links {
+		for _, part := range link.parts {
+			if part[0] == y && x >= part[1] && x < part[2] {
+				return link
+			}
+		}
+	}
+	return nil
+}
+
 // MouseHandler returns the mouse handler for this primitive.
 func (t *TextView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		// Scroll bars.
+		if consumed, capture := t.scrollBars.mouseHandler(t, action, event, setFocus, func(vertical bool, offset int) {
+			if vertical {
+				t.lineOffset = offset
+				t.trackEnd = offset >= t.scrollBars.vertical.total-t.scrollBars.vertical.size
+			} else {
+				t.columnOffset = offset
+			}
+		}); consumed {
+			return consumed, capture
+		}
+
 		x, y := event.Position()
 		if !t.InRect(x, y) {
 			return false, nil
@@ -1485,6 +1747,12 @@ func (t *TextView) MouseHandler() func(action MouseAction, event *tcell.EventMou
 			setFocus(t)
 			consumed = true
 		case MouseLeftClick:
+			if link := t.linkAt(x, y); link != nil && t.linkClicked != nil {
+				t.selectedLink = link.start
+				t.linkClicked(link.url)
+				consumed = true
+				break
+			}
 			if t.regionTags && t.InInnerRect(x, y) {
 				// Find a region to highlight.
 				column := x - rectX
@@ -0,0 +1,518 @@
+package tview
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// TimePicker is a form item which lets the user enter a time of day (hours,
+// minutes, and optionally seconds) or, in range mode (see
+// [TimePicker.SetRangeMode]), a start and an end time. Each component of the
+// time is a spinner which can be changed individually.
+//
+// The following keys are available:
+//
+//   - Left arrow, Right arrow: Select the previous or next component.
+//   - Up arrow, Down arrow, +, -: Increase or decrease the selected component.
+//   - 0-9: Enter the selected component. After two digits, the next component
+//     is selected.
+//   - Tab, Backtab, Escape: Done (see [TimePicker.SetDoneFunc]).
+//
+// With the mouse, click on a component to select it and use the scroll wheel
+// to change it.
+type TimePicker struct {
+	*Box
+
+	// Whether or not this time picker is disabled/read-only.
+	disabled bool
+
+	// The times whose dates and locations are used for the times returned by
+	// the time picker.
+	start, end time.Time
+
+	// The hours, minutes, and seconds of the start and the end time.
+	values [2][3]int
+
+	// The index of the selected component.
+	selected int
+
+	// The digits the user has typed into the selected component so far.
+	typed string
+
+	// Whether or not seconds are shown.
+	showSeconds bool
+
+	// Whether or not a start and an end time are entered.
+	rangeMode bool
+
+	// The step by which minutes are increased or decreased.
+	minuteStep int
+
+	// The text to be displayed before the input area.
+	label string
+
+	// The screen width of the label area. A value of 0 means use the width of
+	// the label text.
+	labelWidth int
+
+	// Styles.
+	labelStyle, fieldStyle, focusedStyle, disabledStyle tcell.Style
+
+	// An optional function which is called when the time changes.
+	changed func(t time.Time)
+
+	// An optional function which is called when the time range changes.
+	rangeChanged func(start, end time.Time)
+
+	// An optional function which is called when the user leaves the time
+	// picker.
+	done func(key tcell.Key)
+}
+
+// NewTimePicker returns a new time picker set to midnight.
+func NewTimePicker() *TimePicker {
+	box := NewBox()
+	t := &TimePicker{
+		Box:           box,
+		minuteStep:    1,
+		labelStyle:    tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		fieldStyle:    tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		focusedStyle:  tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
+		disabledStyle: tcell.StyleDefault.Background(box.backgroundColor).Foreground(Styles.SecondaryTextColor),
+	}
+	t.Box.Primitive = t
+	return t
+}
+
+// components returns the number of components per time.
+func (t *TimePicker) components() int {
+	if t.showSeconds {
+		return 3
+	}
+	return 2
+}
+
+// count returns the total number of components.
+func (t *TimePicker) count() int {
+	if t.rangeMode {
+		return 2 * t.components()
+	}
+	return t.components()
+}
+
+// component returns a pointer to the value of the component with the given
+// index.
+func (t *TimePicker) component(index int) *int {
+	n := t.components()
+	return &t.values[index/n][index%n]
+}
+
+// SetShowSeconds sets whether or not seconds are shown and can be entered.
+func (t *TimePicker) SetShowSeconds(show bool) *TimePicker {
+	t.showSeconds = show
+	if !show {
+		t.values[0][2], t.values[1][2] = 0, 0
+	}
+	t.selected, t.typed = min(t.selected, t.count()-1), ""
+	return t
+}
+
+// SetRangeMode sets whether or not the user enters a start and an end time
+// instead of a single time.
+func (t *TimePicker) SetRangeMode(rangeMode bool) *TimePicker {
+	t.rangeMode = rangeMode
+	t.selected, t.typed = min(t.selected, t.count()-1), ""
+	return t
+}
+
+// SetMinuteStep sets the step by which minutes are increased or decreased
+// with the arrow keys or the mouse wheel. The default is 1.
+func (t *TimePicker) SetMinuteStep(step int) *TimePicker {
+	t.minuteStep = min(max(step, 1), 59)
+	return t
+}
+
+// setValues sets the components of the time with the given index (0 = start,
+// 1 = end) to the clock of the given time.
+func (t *TimePicker) setValues(group int, clock time.Time) {
+	hour, minute, second := clock.Clock()
+	if !t.showSeconds {
+		second = 0
+	}
+	t.values[group] = [3]int{hour, minute, second}
+}
+
+// SetTime sets the time shown by the time picker. Only its clock (hours,
+// minutes, and seconds) is shown but the times returned by
+// [TimePicker.GetTime] will have the same date and location. In range mode,
+// this sets the start time. This does not trigger the "changed" callbacks.
+func (t *TimePicker) SetTime(clock time.Time) *TimePicker {
+	t.start = clock
+	t.setValues(0, clock)
+	return t
+}
+
+// GetTime returns the entered time. Its date and location are those of the
+// time passed to [TimePicker.SetTime] (January 1, year 1, UTC if it was never
+// called). In range mode, this is the start time.
+func (t *TimePicker) GetTime() time.Time {
+	return t.clock(0)
+}
+
+// SetTimeRange sets the start and the end time in range mode. See
+// [TimePicker.SetTime] for details. This does not trigger the "changed"
+// callbacks.
+func (t *TimePicker) SetTimeRange(start, end time.Time) *TimePicker {
+	t.SetRangeMode(true)
+	t.start, t.end = start, end
+	t.setValues(0, start)
+	t.setValues(1, end)
+	return t
+}
+
+// GetTimeRange returns the start and the end time entered in range mode. See
+// [TimePicker.GetTime] for details. If no end time was set, the end time has
+// the date and location of the start time.
+func (t *TimePicker) GetTimeRange() (start, end time.Time) {
+	if !t.rangeMode {
+		return t.clock(0), t.clock(0)
+	}
+	return t.clock(0), t.clock(1)
+}
+
+// clock returns the time with the given index (0 = start, 1 = end).
+func (t *TimePicker) clock(group int) time.Time {
+	base := t.start
+	if group == 1 && !t.end.IsZero() {
+		base = t.end
+	}
+	year, month, day := base.Date()
+	values := t.values[group]
+	return time.Date(year, month, day, values[0], values[1], values[2], 0, base.Location())
+}
+
+// SetLabel sets the text to be displayed before the input area.
+func (t *TimePicker) SetLabel(label string) *TimePicker {
+	t.label = label
+	return t
+}
+
+// GetLabel returns the text to be displayed before the input area.
+func (t *TimePicker) GetLabel() string {
+	return t.label
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause the
+// primitive to use the width of the label string.
+func (t *TimePicker) SetLabelWidth(width int) *TimePicker {
+	t.labelWidth = width
+	return t
+}
+
+// SetLabelStyle sets the style of the label.
+func (t *TimePicker) SetLabelStyle(style tcell.Style) *TimePicker {
+	t.labelStyle = style
+	return t
+}
+
+// SetFieldStyle sets the style of the input area.
+func (t *TimePicker) SetFieldStyle(style tcell.Style) *TimePicker {
+	t.fieldStyle = style
+	return t
+}
+
+// SetFocusedStyle sets the style of the selected component when the time
+// picker has focus.
+func (t *TimePicker) SetFocusedStyle(style tcell.Style) *TimePicker {
+	t.focusedStyle = style
+	return t
+}
+
+// SetDisabledStyle sets the style of the input area when the time picker is
+// disabled.
+func (t *TimePicker) SetDisabledStyle(style tcell.Style) *TimePicker {
+	t.disabledStyle = style
+	return t
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (t *TimePicker) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
+	t.labelWidth = labelWidth
+	t.labelStyle = t.labelStyle.Foreground(labelColor)
+	t.SetBackgroundColor(bgColor)
+	t.fieldStyle = tcell.StyleDefault.Foreground(fieldTextColor).Background(fieldBgColor)
+	return t
+}
+
+// GetFieldWidth returns this primitive's field screen width.
+func (t *TimePicker) GetFieldWidth() int {
+	width := t.components()*3 - 1
+	if t.rangeMode {
+		width = 2*width + 3
+	}
+	return width
+}
+
+// GetFieldHeight returns this primitive's field height.
+func (t *TimePicker) GetFieldHeight() int {
+	return 1
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (t *TimePicker) SetDisabled(disabled bool) FormItem {
+	t.disabled = disabled
+	return t
+}
+
+// GetDisabled returns whether or not the item is disabled / read-only.
+func (t *TimePicker) GetDisabled() bool {
+	return t.disabled
+}
+
+// SetChangedFunc sets a handler which is called when the user changes the
+// time. See [TimePicker.GetTime] for the time it receives. In range mode, use
+// [TimePicker.SetRangeChangedFunc] instead.
+func (t *TimePicker) SetChangedFunc(handler func(clock time.Time)) *TimePicker {
+	t.changed = handler
+	return t
+}
+
+// SetRangeChangedFunc sets a handler which is called in range mode when the
+// user changes the start or the end time.
+func (t *TimePicker) SetRangeChangedFunc(handler func(start, end time.Time)) *TimePicker {
+	t.rangeChanged = handler
+	return t
+}
+
+// SetDoneFunc sets a handler which is called when the user is done entering
+// the time. The callback function is provided with the key that was pressed,
+// which is one of the following:
+//
+//   - KeyEscape: Abort entry.
+//   - KeyTab: Move to the next field.
+//   - KeyBacktab: Move to the previous field.
+func (t *TimePicker) SetDoneFunc(handler func(key tcell.Key)) *TimePicker {
+	t.done = handler
+	return t
+}
+
+// AllowExit returns whether or not this primitive will allow a containing form
+// to move focus away from this primitive.
+func (t *TimePicker) AllowExit(event *tcell.EventKey) bool {
+	return true
+}
+
+// limit returns the number of possible values of the component with the
+// given index.
+func (t *TimePicker) limit(index int) int {
+	if index%t.components() == 0 {
+		return 24
+	}
+	return 60
+}
+
+// setValue sets the value of the component with the given index and triggers
+// the "changed" callbacks.
+func (t *TimePicker) setValue(index, value int) {
+	limit := t.limit(index)
+	value = (value%limit + limit) % limit
+	if *t.component(index) == value {
+		return
+	}
+	*t.component(index) = value
+	if t.rangeMode {
+		if t.rangeChanged != nil {
+			t.rangeChanged(t.clock(0), t.clock(1))
+		}
+	} else if t.changed != nil {
+		t.changed(t.clock(0))
+	}
+}
+
+// spin increases (or decreases, if "up" is false) the component with the
+// given index.
+func (t *TimePicker) spin(index int, up bool) {
+	step := 1
+	if index%t.components() == 1 {
+		step = t.minuteStep
+	}
+	value := *t.component(index)
+	if up {
+		value = (value/step + 1) * step
+	} else {
+		value = ((value+step-1)/step - 1) * step
+	}
+	if value >= 60 && t.limit(index) == 60 {
+		value = 0 // Don't wrap to a value which isn't a multiple of the step.
+	}
+	t.typed = ""
+	t.setValue(index, value)
+}
+
+// componentOffset returns the horizontal offset of the component with the
+// given index within the input area.
+func (t *TimePicker) componentOffset(index int) int {
+	n := t.components()
+	return (index/n)*(n*3-1+3) + (index%n)*3
+}
+
+// Draw draws this primitive onto the screen.
+func (t *TimePicker) Draw(screen tcell.Screen) {
+	t.Box.DrawForSubclass(screen, t)
+
+	// Prepare.
+	x, y, width, height := t.GetInnerRect()
+	rightLimit := x + width
+	if height < 1 || rightLimit <= x {
+		return
+	}
+
+	// Draw label.
+	if t.labelWidth > 0 {
+		labelWidth := min(t.labelWidth, rightLimit-x)
+		printWithStyle(screen, t.label, x, y, 0, labelWidth, AlignLeft, t.labelStyle, true)
+		x += labelWidth
+	} else {
+		_, _, drawnWidth := printWithStyle(screen, t.label, x, y, 0, rightLimit-x, AlignLeft, t.labelStyle, true)
+		x += drawnWidth
+	}
+
+	// Draw the input area.
+	fieldStyle := t.fieldStyle
+	if t.disabled {
+		fieldStyle = t.disabledStyle
+	}
+	var text strings.Builder
+	for index := range t.count() {
+		if index > 0 {
+			if index%t.components() == 0 {
+				text.WriteString(" - ")
+			} else {
+				text.WriteString(":")
+			}
+		}
+		fmt.Fprintf(&text, "%02d", *t.component(index))
+	}
+	fieldWidth := min(t.GetFieldWidth(), rightLimit-x)
+	printWithStyle(screen, text.String(), x, y, 0, fieldWidth, AlignLeft, fieldStyle, false)
+
+	// Highlight the selected component.
+	if t.HasFocus() && !t.disabled {
+		offset := t.componentOffset(t.selected)
+		if offset < fieldWidth {
+			printWithStyle(screen, fmt.Sprintf("%02d", *t.component(t.selected)), x+offset, y, 0, min(2, fieldWidth-offset), AlignLeft, t.focusedStyle, false)
+		}
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (t *TimePicker) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if t.disabled {
+			return
+		}
+
+		switch key := event.Key(); key {
+		case tcell.KeyLeft:
+			if t.selected > 0 {
+				t.selected--
+			}
+			t.typed = ""
+		case tcell.KeyRight:
+			if t.selected < t.count()-1 {
+				t.selected++
+			}
+			t.typed = ""
+		case tcell.KeyUp:
+			t.spin(t.selected, true)
+		case tcell.KeyDown:
+			t.spin(t.selected, false)
+		case tcell.KeyHome:
+			t.selected, t.typed = 0, ""
+		case tcell.KeyEnd:
+			t.selected, t.typed = t.count()-1, ""
+		case tcell.KeyRune:
+			ch := event.Rune()
+			switch {
+			case ch == '+':
+				t.spin(t.selected, true)
+			case ch == '-':
+				t.spin(t.selected, false)
+			case ch >= '0' && ch <= '9':
+				typed := t.typed + string(ch)
+				value, _ := strconv.Atoi(typed)
+				if value >= t.limit(t.selected) {
+					typed = string(ch)
+					value = int(ch - '0')
+				}
+				t.typed = typed
+				t.setValue(t.selected, value)
+				if len(t.typed) == 2 || value*10 >= t.limit(t.selected) {
+					// No more digits possible. Move on.
+					t.typed = ""
+					if t.selected < t.count()-1 {
+						t.selected++
+					}
+				}
+			}
+		case tcell.KeyEscape, tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEnter:
+			t.typed = ""
+			if t.done != nil && key != tcell.KeyEnter {
+				t.done(key)
+			}
+		}
+	})
+}
+
+// componentAt returns the index of the component at the given screen
+// position or -1 if there is none.
+func (t *TimePicker) componentAt(x, y int) int {
+	rectX, rectY, width, _ := t.GetInnerRect()
+	if y != rectY {
+		return -1
+	}
+	labelWidth := t.labelWidth
+	if labelWidth == 0 {
+		labelWidth = TaggedStringWidth(t.label)
+	}
+	offset := x - rectX - min(labelWidth, width)
+	for index := range t.count() {
+		if start := t.componentOffset(index); offset >= start && offset < start+2 {
+			return index
+		}
+	}
+	return -1
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (t *TimePicker) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if t.disabled {
+			return false, nil
+		}
+		x, y := event.Position()
+		if !t.InRect(x, y) {
+			return false, nil
+		}
+		index := t.componentAt(x, y)
+		switch action {
+		case MouseLeftDown:
+			setFocus(t)
+			if index >= 0 {
+				t.selected, t.typed = index, ""
+			}
+			consumed = true
+		case MouseScrollUp, MouseScrollDown:
+			if index >= 0 {
+				t.selected = index
+				t.spin(index, action == MouseScrollUp)
+				consumed = true
+			}
+		}
+		return
+	})
+}
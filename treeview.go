@@ -326,6 +326,9 @@ type TreeView struct {
 	// Vertical scroll offset.
 	offsetY int
 
+	// The scroll bars. Only the vertical scroll bar is used.
+	scrollBars scrollBars
+
 	// If set to true, all node texts will be aligned horizontally.
 	align bool
 
@@ -360,6 +363,7 @@ func NewTreeView() *TreeView {
 		Box:           NewBox(),
 		graphics:      true,
 		graphicsColor: Styles.GraphicsColor,
+		scrollBars:    newScrollBars(),
 	}
 	t.Box.Primitive = t
 	return t
@@ -489,6 +493,28 @@ func (t *TreeView) GetSelectedFunc() func(node *TreeNode) {
 	return t.selected
 }
 
+// SetScrollBarVisibility sets when the vertical scroll bar is shown (see
+// [ScrollBarNever], [ScrollBarAuto], and [ScrollBarAlways]). The tree view
+// does not scroll horizontally. The thumb of the scroll bar can be dragged with
+// the mouse.
+func (t *TreeView) SetScrollBarVisibility(visibility ScrollBarVisibility) *TreeView {
+	t.scrollBars.setVisibility(visibility, ScrollBarNever)
+	return t
+}
+
+// SetScrollBarRunes sets the runes used to draw the scroll bar's track and
+// thumb.
+func (t *TreeView) SetScrollBarRunes(track, thumb rune) *TreeView {
+	t.scrollBars.setRunes(track, thumb)
+	return t
+}
+
+// SetScrollBarStyles sets the styles of the scroll bar's track and thumb.
+func (t *TreeView) SetScrollBarStyles(track, thumb tcell.Style) *TreeView {
+	t.scrollBars.setStyles(track, thumb)
+	return t
+}
+
 // SetDoneFunc sets a handler which is called whenever the user presses the
 // Escape, Tab, or Backtab key.
 func (t *TreeView) SetDoneFunc(handler func(key tcell.Key)) *TreeView {
@@ -718,6 +744,13 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 	}
 	t.movement = treeNone
 
+	// Make room for the scroll bar.
+	t.scrollBars.hide()
+	verticalBar := width > 1 && t.scrollBars.vertical.needed(len(t.nodes), height)
+	if verticalBar {
+		width--
+	}
+
 	// Fix invalid offsets.
 	if t.offsetY >= len(t.nodes)-height {
 		t.offsetY = len(t.nodes) - height
@@ -744,6 +777,7 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 			ancestor := node.parent
 			for ancestor != nil && ancestor.parent != nil && ancestor.parent.level >= t.topLevel {
 				if ancestor.graphicsX >= width {
+					ancestor = ancestor.parent
 					continue
 				}
 
@@ -796,6 +830,11 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 		// Advance.
 		posY++
 	}
+
+	// Draw the scroll bar.
+	if verticalBar {
+		t.scrollBars.vertical.draw(screen, x+width, y, height, len(t.nodes), height, t.offsetY)
+	}
 }
 
 // InputHandler returns the handler for this primitive.
@@ -868,6 +907,14 @@ func (t *TreeView) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 // MouseHandler returns the mouse handler for this primitive.
 func (t *TreeView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		// Scroll bar.
+		if consumed, capture := t.scrollBars.mouseHandler(t, action, event, setFocus, func(vertical bool, offset int) {
+			t.movement = treeScroll
+			t.step = offset - t.offsetY
+		}); consumed {
+			return consumed, capture
+		}
+
 		x, y := event.Position()
 		if !t.InRect(x, y) {
 			return false, nil
@@ -1,9 +1,12 @@
 package tview
 
 import (
+	"fmt"
 	"math"
 	"os"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 )
@@ -156,3 +159,60 @@ func printWithStyle(screen tcell.Screen, text string, x, y, skipWidth, maxWidth,
 func PrintSimple(screen tcell.Screen, text string, x, y int) {
 	Print(screen, text, x, y, math.MaxInt32, AlignLeft, Styles.PrimaryTextColor)
 }
+
+// styleTag returns a style tag which switches to the given style, for use in
+// texts printed by the functions in this package.
+func styleTag(style tcell.Style) string {
+	fg, bg, attr := style.Decompose()
+	var attributes strings.Builder
+	for _, flag := range []struct {
+		mask   tcell.AttrMask
+		letter byte
+	}{
+		{tcell.AttrBold, 'b'},
+		{tcell.AttrItalic, 'i'},
+		{tcell.AttrUnderline, 'u'},
+		{tcell.AttrBlink, 'l'},
+		{tcell.AttrDim, 'd'},
+		{tcell.AttrStrikeThrough, 's'},
+		{tcell.AttrReverse, 'r'},
+	} {
+		if attr&flag.mask != 0 {
+			attributes.WriteByte(flag.letter)
+		}
+	}
+	if attributes.Len() == 0 {
+		attributes.WriteByte('-')
+	}
+	return "[" + colorTagName(fg) + ":" + colorTagName(bg) + ":" + attributes.String() + "]"
+}
+
+// colorTagName returns the name of a color as used in style tags.
+func colorTagName(color tcell.Color) string {
+	if color == tcell.ColorDefault {
+		return "-"
+	}
+	if name := color.Name(); name != "" && color&tcell.ColorIsRGB == 0 {
+		return name
+	}
+	return fmt.Sprintf("#%06x", color.Hex())
+}
+
+// stepDecimals returns the number of decimal places needed to display
+// multiples of the given step.
+func stepDecimals(step float64) int {
+	text := strconv.FormatFloat(math.Abs(step), 'f', -1, 64)
+	if index := strings.IndexByte(text, '.'); index >= 0 {
+		return len(text) - index - 1
+	}
+	return 0
+}
+
+// roundDecimals rounds the given value to the given number of decimal places.
+func roundDecimals(value float64, decimals int) float64 {
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(value, 'f', decimals, 64), 64)
+	if err != nil {
+		return value
+	}
+	return rounded
+}
@@ -0,0 +1,139 @@
+package tview
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FormValidator checks the value of a form item. It returns nil if the value
+// is valid or an error whose message is shown below the item otherwise. See
+// [Form.SetValidators] for how to attach validators to form items.
+//
+// Validators may check any aspect of an item, including its relationship to
+// other items. For example, to check that two password fields match:
+//
+//	form.SetValidators(confirmField, func(item FormItem) error {
+//	  if confirmField.GetText() != passwordField.GetText() {
+//	    return errors.New("Passwords don't match")
+//	  }
+//	  return nil
+//	})
+type FormValidator func(item FormItem) error
+
+// RequiredValidator returns a validator which reports an error with the given
+// message if a form item has no value. Text-based items (such as [InputField]
+// and [TextArea]) have no value if their text is empty or consists of white
+// space only. Option-based items ([DropDown], [RadioGroup]) have no value if no
+// option is selected. A [Checkbox] has no value if it is not checked, a
+// [DatePicker] if no date is selected. Other items are always considered to
+// have a value.
+func RequiredValidator(message string) FormValidator {
+	return func(item FormItem) error {
+		var empty bool
+		switch item := item.(type) {
+		case *Checkbox:
+			empty = !item.IsChecked()
+		case *DatePicker:
+			empty = item.GetDate().IsZero()
+		case *DropDown:
+			if item.multiSelect {
+				empty = len(item.GetSelectedOptions()) == 0
+			} else {
+				index, text := item.GetCurrentOption()
+				empty = index < 0 && text == ""
+			}
+		default:
+			text, ok := formItemText(item)
+			empty = ok && strings.TrimSpace(text) == ""
+		}
+		if empty {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// RegexpValidator returns a validator which reports an error with the given
+// message if the text of a form item doesn't match the given regular
+// expression. Empty texts are not checked (use [RequiredValidator] for this).
+// The text of option-based items ([DropDown], [RadioGroup]) is the text of the
+// selected option. Items without text are always valid.
+func RegexpValidator(pattern *regexp.Regexp, message string) FormValidator {
+	return func(item FormItem) error {
+		text, ok := formItemText(item)
+		if !ok || text == "" || pattern.MatchString(text) {
+			return nil
+		}
+		return errors.New(message)
+	}
+}
+
+// RangeValidator returns a validator which reports an error with the given
+// message if the numeric value of a form item is outside the given range
+// (inclusive). The value of [NumberField] and [Slider] items is used directly.
+// The text of other items is parsed as a floating-point number, reporting an
+// error if it is not a number. Empty texts are not checked (use
+// [RequiredValidator] for this).
+func RangeValidator(minimum, maximum float64, message string) FormValidator {
+	return func(item FormItem) error {
+		var value float64
+		if number, ok := item.(interface{ GetValue() float64 }); ok {
+			value = number.GetValue()
+		} else {
+			text, ok := formItemText(item)
+			text = strings.TrimSpace(text)
+			if !ok || text == "" {
+				return nil
+			}
+			var err error
+			if value, err = strconv.ParseFloat(text, 64); err != nil {
+				return errors.New(message)
+			}
+		}
+		if !(value >= minimum && value <= maximum) { // Also catches NaN.
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// LengthValidator returns a validator which reports an error with the given
+// message if the number of characters of the text of a form item is outside
+// the given range (inclusive). A maximum of 0 or less means there is no upper
+// limit. Items without text are always valid.
+func LengthValidator(minimum, maximum int, message string) FormValidator {
+	return func(item FormItem) error {
+		text, ok := formItemText(item)
+		if !ok {
+			return nil
+		}
+		length := utf8.RuneCountInString(text)
+		if length < minimum || maximum > 0 && length > maximum {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// formItemText returns the text of a form item: The text of text-based items,
+// the text of the selected option of option-based items, and the formatted
+// date of date pickers. If the item has no text, false is returned.
+func formItemText(item FormItem) (string, bool) {
+	switch item := item.(type) {
+	case interface{ GetText() string }:
+		return item.GetText(), true
+	case interface{ GetCurrentOption() (int, string) }:
+		_, text := item.GetCurrentOption()
+		return text, true
+	case *DatePicker:
+		if date := item.GetDate(); !date.IsZero() {
+			return date.Format(time.DateOnly), true
+		}
+		return "", true
+	}
+	return "", false
+}
@@ -0,0 +1,1109 @@
+package tview
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/uniseg"
+)
+
+// Parser states of the terminal emulator.
+const (
+	vtGround = iota
+	vtEscape
+	vtCSI
+	vtOSC
+	vtOSCEscape
+	vtString
+	vtStringEscape
+)
+
+// Mouse reporting modes requested by the application running in a terminal.
+const (
+	vtMouseNone   = 0
+	vtMouseClick  = 1000 // Report button presses and releases.
+	vtMouseDrag   = 1002 // Also report motion while a button is pressed.
+	vtMouseMotion = 1003 // Report all motion.
+)
+
+// vtDECGraphics maps the characters 0x5f to 0x7e to the DEC special graphics
+// character set which is used for line drawing.
+var vtDECGraphics = []rune(" ◆▒␉␌␍␊°±␤␋┘┐┌└┼⎺⎻─⎼⎽├┤┴┬│≤≥π≠£·")
+
+// vtCell is one cell of the terminal's grid.
+type vtCell struct {
+	// The grapheme cluster shown in this cell. If empty, the cell is blank.
+	text string
+
+	// The width of the cell's grapheme cluster. This is 2 for the first cell
+	// of a wide character and 0 for the second cell.
+	width int
+
+	// The cell's style.
+	style tcell.Style
+}
+
+// vtCursor is the state saved and restored with DECSC and DECRC.
+type vtCursor struct {
+	x, y       int
+	style      tcell.Style
+	originMode bool
+	charsets   [2]bool
+	charset    int
+}
+
+// vtEmulator emulates the screen of a VT100/xterm compatible terminal. Output
+// of an application is passed to [vtEmulator.Write] which updates the grid of
+// cells accordingly. Replies to queries of the application are collected in
+// the response buffer. Keys and mouse events are translated into the byte
+// sequences expected by the application with [vtEmulator.key] and
+// [vtEmulator.mouse].
+type vtEmulator struct {
+	// The size of the screen.
+	cols, rows int
+
+	// The main screen and the alternate screen. The alternate screen is used
+	// by full-screen applications and has no scrollback.
+	main, alt [][]vtCell
+
+	// The cells of the active screen.
+	cells [][]vtCell
+
+	// Whether the alternate screen is active.
+	altActive bool
+
+	// The cursor position.
+	x, y int
+
+	// Whether the next printed character wraps to the next line first. This
+	// is set after a character was printed in the last column.
+	wrapNext bool
+
+	// The style of printed characters.
+	style tcell.Style
+
+	// The saved cursors of the main and the alternate screen.
+	savedMain, savedAlt vtCursor
+
+	// The scroll region (inclusive).
+	top, bottom int
+
+	// The tab stops.
+	tabs []bool
+
+	// Modes.
+	originMode     bool // DECOM: Cursor positions are relative to the scroll region.
+	autoWrap       bool // DECAWM: Wrap at the end of the line.
+	insertMode     bool // IRM: Printed characters shift the rest of the line.
+	cursorVisible  bool // DECTCEM: The cursor is shown.
+	appCursor      bool // DECCKM: Cursor keys send application sequences.
+	bracketedPaste bool // Pasted text is wrapped in escape sequences.
+	mouseMode      int  // One of the vtMouse constants.
+	mouseSGR       bool // Mouse events are reported in SGR encoding.
+
+	// Whether the character sets G0 and G1 are the DEC special graphics
+	// character set, and which one is active.
+	charsets [2]bool
+	charset  int
+
+	// The window title set by the application.
+	title string
+
+	// The last printed character, for REP.
+	lastChar rune
+
+	// Parser state.
+	state        int
+	private      byte   // The private marker of a control sequence, e.g. '?'.
+	intermediate []byte // Intermediate bytes of a sequence.
+	params       []byte // The parameter bytes of a control sequence.
+	str          []byte // The contents of an operating system command.
+	pending      []byte // An incomplete UTF-8 sequence.
+
+	// Bytes to be sent back to the application.
+	response []byte
+}
+
+// newVTEmulator returns a new terminal emulator with the given size.
+func newVTEmulator(cols, rows int) *vtEmulator {
+	e := &vtEmulator{}
+	e.resize(max(cols, 1), max(rows, 1))
+	e.reset()
+	return e
+}
+
+// reset resets the terminal to its initial state (RIS).
+func (e *vtEmulator) reset() {
+	e.main = e.newScreen()
+	e.alt = e.newScreen()
+	e.cells = e.main
+	e.altActive = false
+	e.x, e.y, e.wrapNext = 0, 0, false
+	e.style = tcell.StyleDefault
+	e.savedMain, e.savedAlt = vtCursor{}, vtCursor{}
+	e.top, e.bottom = 0, e.rows-1
+	e.tabs = make([]bool, e.cols)
+	for x := 8; x < e.cols; x += 8 {
+		e.tabs[x] = true
+	}
+	e.originMode, e.autoWrap, e.insertMode, e.cursorVisible = false, true, false, true
+	e.appCursor, e.bracketedPaste, e.mouseMode, e.mouseSGR = false, false, vtMouseNone, false
+	e.charsets, e.charset = [2]bool{}, 0
+	e.state = vtGround
+}
+
+// newScreen returns a new blank screen of the current size.
+func (e *vtEmulator) newScreen() [][]vtCell {
+	cells := make([][]vtCell, e.rows)
+	for y := range cells {
+		cells[y] = e.blankLine(e.cols, tcell.StyleDefault)
+	}
+	return cells
+}
+
+// blankLine returns a line of blank cells with the given style.
+func (e *vtEmulator) blankLine(length int, style tcell.Style) []vtCell {
+	line := make([]vtCell, length)
+	for x := range line {
+		line[x] = vtCell{width: 1, style: style}
+	}
+	return line
+}
+
+// blank returns a blank cell. Erased cells keep the current background color.
+func (e *vtEmulator) blank() vtCell {
+	_, background, _ := e.style.Decompose()
+	return vtCell{width: 1, style: tcell.StyleDefault.Background(background)}
+}
+
+// resize changes the size of the screen. Lines which no longer fit above the
+// cursor are dropped from the top of the main screen. Everything else is
+// truncated or padded at the right and the bottom.
+func (e *vtEmulator) resize(cols, rows int) {
+	if cols == e.cols && rows == e.rows {
+		return
+	}
+	resizeScreen := func(cells [][]vtCell, cursorY int) ([][]vtCell, int) {
+		if shift := cursorY - rows + 1; shift > 0 {
+			cells = cells[shift:]
+			cursorY -= shift
+		}
+		result := make([][]vtCell, rows)
+		for y := range result {
+			result[y] = e.blankLine(cols, tcell.StyleDefault)
+			if y < len(cells) {
+				copy(result[y], cells[y])
+				if cols < len(cells[y]) && result[y][cols-1].width == 2 {
+					result[y][cols-1] = vtCell{width: 1}
+				}
+			}
+		}
+		return result, cursorY
+	}
+	var mainY, altY int
+	if e.altActive {
+		altY, mainY = e.y, e.savedMain.y
+	} else {
+		mainY, altY = e.y, e.savedAlt.y
+	}
+	e.main, mainY = resizeScreen(e.main, mainY)
+	e.alt, altY = resizeScreen(e.alt, altY)
+	if e.altActive {
+		e.cells, e.y, e.savedMain.y = e.alt, altY, mainY
+	} else {
+		e.cells, e.y, e.savedAlt.y = e.main, mainY, altY
+	}
+
+	tabs := make([]bool, cols)
+	copy(tabs, e.tabs)
+	for x := max((e.cols+7)/8*8, 8); x < cols; x += 8 {
+		tabs[x] = true
+	}
+	e.tabs = tabs
+	e.cols, e.rows = cols, rows
+	e.top, e.bottom = 0, rows-1
+	e.x = min(e.x, cols-1)
+	e.y = min(e.y, rows-1)
+	e.wrapNext = false
+}
+
+// Write processes output of the application.
+func (e *vtEmulator) Write(p []byte) (n int, err error) {
+	data := p
+	if len(e.pending) > 0 {
+		data = append(e.pending, p...)
+		e.pending = nil
+	}
+	for index := 0; index < len(data); {
+		b := data[index]
+		if e.state == vtGround && b >= 0x80 {
+			if !utf8.FullRune(data[index:]) {
+				e.pending = append([]byte(nil), data[index:]...)
+				break
+			}
+			r, size := utf8.DecodeRune(data[index:])
+			index += size
+			e.print(r)
+			continue
+		}
+		index++
+		e.process(b)
+	}
+	return len(p), nil
+}
+
+// process processes one byte of output which is not part of a multi-byte
+// UTF-8 sequence.
+func (e *vtEmulator) process(b byte) {
+	switch e.state {
+	case vtOSC:
+		switch b {
+		case 0x07:
+			e.osc()
+			e.state = vtGround
+		case 0x1b:
+			e.state = vtOSCEscape
+		default:
+			if len(e.str) < 4096 {
+				e.str = append(e.str, b)
+			}
+		}
+		return
+	case vtOSCEscape:
+		e.osc()
+		e.state = vtGround
+		if b != '\\' {
+			e.process(0x1b)
+			e.process(b)
+		}
+		return
+	case vtString: // DCS, SOS, PM, and APC strings are ignored.
+		switch b {
+		case 0x07:
+			e.state = vtGround
+		case 0x1b:
+			e.state = vtStringEscape
+		}
+		return
+	case vtStringEscape:
+		e.state = vtGround
+		if b != '\\' {
+			e.process(0x1b)
+			e.process(b)
+		}
+		return
+	}
+
+	// Control characters are executed in all other states.
+	if b < 0x20 || b == 0x7f {
+		e.control(b)
+		return
+	}
+
+	switch e.state {
+	case vtGround:
+		e.print(rune(b))
+	case vtEscape:
+		switch {
+		case b >= 0x20 && b <= 0x2f:
+			e.intermediate = append(e.intermediate, b)
+		case len(e.intermediate) > 0:
+			e.escapeIntermediate(b)
+			e.state = vtGround
+		case b == '[':
+			e.private = 0
+			e.params = e.params[:0]
+			e.state = vtCSI
+		case b == ']':
+			e.str = e.str[:0]
+			e.state = vtOSC
+		case b == 'P' || b == 'X' || b == '^' || b == '_':
+			e.state = vtString
+		default:
+			e.escape(b)
+			e.state = vtGround
+		}
+	case vtCSI:
+		switch {
+		case b >= '0' && b <= '9' || b == ';' || b == ':':
+			e.params = append(e.params, b)
+		case b >= '<' && b <= '?':
+			e.private = b
+		case b >= 0x20 && b <= 0x2f:
+			e.intermediate = append(e.intermediate, b)
+		case b >= 0x40 && b <= 0x7e:
+			e.csi(b)
+			e.state = vtGround
+		}
+	}
+}
+
+// control executes a C0 control character.
+func (e *vtEmulator) control(b byte) {
+	switch b {
+	case 0x08: // BS
+		e.wrapNext = false
+		if e.x > 0 {
+			e.x--
+		}
+	case 0x09: // HT
+		e.tab(1)
+	case 0x0a, 0x0b, 0x0c: // LF, VT, FF
+		e.index()
+	case 0x0d: // CR
+		e.x, e.wrapNext = 0, false
+	case 0x0e: // SO
+		e.charset = 1
+	case 0x0f: // SI
+		e.charset = 0
+	case 0x18, 0x1a: // CAN, SUB
+		e.state = vtGround
+	case 0x1b: // ESC
+		e.intermediate = e.intermediate[:0]
+		e.state = vtEscape
+	}
+}
+
+// print prints a character at the cursor position.
+func (e *vtEmulator) print(r rune) {
+	if e.charsets[e.charset] && r >= 0x5f && r <= 0x7e {
+		r = vtDECGraphics[r-0x5f]
+	}
+	width := uniseg.StringWidth(string(r))
+
+	// Combining characters are added to the previous cell.
+	if width == 0 {
+		x := e.x
+		if !e.wrapNext {
+			x--
+		}
+		if x >= 0 && e.cells[e.y][x].width == 0 && x > 0 {
+			x--
+		}
+		if x >= 0 && e.cells[e.y][x].text != "" {
+			e.cells[e.y][x].text += string(r)
+		}
+		return
+	}
+	width = min(width, 2)
+
+	if e.wrapNext && e.autoWrap {
+		e.x = 0
+		e.index()
+	}
+	e.wrapNext = false
+	if width > e.cols-e.x {
+		if !e.autoWrap || e.cols < width {
+			return
+		}
+		e.x = 0
+		e.index()
+	}
+	if e.insertMode {
+		e.insertCells(width)
+	}
+
+	e.clearWide(e.x, e.y)
+	if width == 2 {
+		e.clearWide(e.x+1, e.y)
+	}
+	e.cells[e.y][e.x] = vtCell{text: string(r), width: width, style: e.style}
+	if width == 2 {
+		e.cells[e.y][e.x+1] = vtCell{style: e.style}
+	}
+	if e.x+width >= e.cols {
+		e.x = e.cols - 1
+		e.wrapNext = true
+	} else {
+		e.x += width
+	}
+	e.lastChar = r
+}
+
+// clearWide blanks the other half of a wide character if the cell at the
+// given position is part of one, in preparation of overwriting the cell.
+func (e *vtEmulator) clearWide(x, y int) {
+	if x < 0 || x >= e.cols {
+		return
+	}
+	line := e.cells[y]
+	switch line[x].width {
+	case 0:
+		if x > 0 {
+			line[x-1] = vtCell{width: 1, style: line[x-1].style}
+		}
+		line[x].width = 1
+	case 2:
+		if x+1 < e.cols {
+			line[x+1] = vtCell{width: 1, style: line[x+1].style}
+		}
+	}
+}
+
+// tab moves the cursor to the count-th next tab stop (or previous tab stop if
+// count is negative).
+func (e *vtEmulator) tab(count int) {
+	e.wrapNext = false
+	for ; count > 0 && e.x < e.cols-1; count-- {
+		for e.x++; e.x < e.cols-1 && !e.tabs[e.x]; e.x++ {
+		}
+	}
+	for ; count < 0 && e.x > 0; count++ {
+		for e.x--; e.x > 0 && !e.tabs[e.x]; e.x-- {
+		}
+	}
+}
+
+// index moves the cursor down one line, scrolling the scroll region up if the
+// cursor is at its bottom.
+func (e *vtEmulator) index() {
+	e.wrapNext = false
+	if e.y == e.bottom {
+		e.scrollUp(1)
+	} else if e.y < e.rows-1 {
+		e.y++
+	}
+}
+
+// reverseIndex moves the cursor up one line, scrolling the scroll region down
+// if the cursor is at its top.
+func (e *vtEmulator) reverseIndex() {
+	e.wrapNext = false
+	if e.y == e.top {
+		e.scrollDown(1)
+	} else if e.y > 0 {
+		e.y--
+	}
+}
+
+// scrollUp scrolls the lines of the scroll region up by the given number of
+// lines, inserting blank lines at the bottom.
+func (e *vtEmulator) scrollUp(count int) {
+	e.scrollLines(e.top, e.bottom, count)
+}
+
+// scrollDown scrolls the lines of the scroll region down by the given number
+// of lines, inserting blank lines at the top.
+func (e *vtEmulator) scrollDown(count int) {
+	e.scrollLines(e.top, e.bottom, -count)
+}
+
+// scrollLines scrolls the lines from top to bottom (inclusive) up by the given
+// number of lines, or down if the number is negative.
+func (e *vtEmulator) scrollLines(top, bottom, count int) {
+	height := bottom - top + 1
+	if count == 0 || height <= 0 {
+		return
+	}
+	lines := e.cells[top : bottom+1]
+	if count > 0 {
+		count = min(count, height)
+		copy(lines, lines[count:])
+		for y := height - count; y < height; y++ {
+			lines[y] = e.blankLine(e.cols, e.blank().style)
+		}
+	} else {
+		count = min(-count, height)
+		copy(lines[count:], lines)
+		for y := range count {
+			lines[y] = e.blankLine(e.cols, e.blank().style)
+		}
+	}
+}
+
+// insertCells inserts blank cells at the cursor position, shifting the rest
+// of the line to the right.
+func (e *vtEmulator) insertCells(count int) {
+	line := e.cells[e.y]
+	count = min(count, e.cols-e.x)
+	e.clearWide(e.x, e.y)
+	copy(line[e.x+count:], line[e.x:])
+	for x := e.x; x < e.x+count; x++ {
+		line[x] = e.blank()
+	}
+	if line[e.cols-1].width == 2 {
+		line[e.cols-1] = e.blank()
+	}
+}
+
+// deleteCells deletes cells at the cursor position, shifting the rest of the
+// line to the left.
+func (e *vtEmulator) deleteCells(count int) {
+	line := e.cells[e.y]
+	count = min(count, e.cols-e.x)
+	e.clearWide(e.x, e.y)
+	e.clearWide(e.x+count-1, e.y)
+	copy(line[e.x:], line[e.x+count:])
+	for x := e.cols - count; x < e.cols; x++ {
+		line[x] = e.blank()
+	}
+}
+
+// erase blanks the cells of the given line from "from" (inclusive) to "to"
+// (exclusive).
+func (e *vtEmulator) erase(y, from, to int) {
+	from, to = max(from, 0), min(to, e.cols)
+	if from >= to {
+		return
+	}
+	e.clearWide(from, y)
+	e.clearWide(to-1, y)
+	for x := from; x < to; x++ {
+		e.cells[y][x] = e.blank()
+	}
+}
+
+// moveTo moves the cursor to the given position, taking origin mode into
+// account and clamping it to the screen or the scroll region.
+func (e *vtEmulator) moveTo(x, y int) {
+	top, bottom := 0, e.rows-1
+	if e.originMode {
+		top, bottom = e.top, e.bottom
+		y += e.top
+	}
+	e.x = min(max(x, 0), e.cols-1)
+	e.y = min(max(y, top), bottom)
+	e.wrapNext = false
+}
+
+// saveCursor saves the cursor state (DECSC).
+func (e *vtEmulator) saveCursor() {
+	cursor := vtCursor{
+		x:          e.x,
+		y:          e.y,
+		style:      e.style,
+		originMode: e.originMode,
+		charsets:   e.charsets,
+		charset:    e.charset,
+	}
+	if e.altActive {
+		e.savedAlt = cursor
+	} else {
+		e.savedMain = cursor
+	}
+}
+
+// restoreCursor restores the cursor state saved with saveCursor (DECRC).
+func (e *vtEmulator) restoreCursor() {
+	cursor := e.savedMain
+	if e.altActive {
+		cursor = e.savedAlt
+	}
+	e.x, e.y = min(cursor.x, e.cols-1), min(cursor.y, e.rows-1)
+	e.style = cursor.style
+	e.originMode = cursor.originMode
+	e.charsets, e.charset = cursor.charsets, cursor.charset
+	e.wrapNext = false
+}
+
+// setAltScreen switches between the main and the alternate screen.
+func (e *vtEmulator) setAltScreen(active, clear bool) {
+	if active == e.altActive {
+		return
+	}
+	e.altActive = active
+	if active {
+		e.cells = e.alt
+		if clear {
+			for y := range e.rows {
+				e.erase(y, 0, e.cols)
+			}
+		}
+	} else {
+		e.cells = e.main
+	}
+	e.wrapNext = false
+}
+
+// escape executes an escape sequence without intermediate bytes.
+func (e *vtEmulator) escape(final byte) {
+	switch final {
+	case '7': // DECSC
+		e.saveCursor()
+	case '8': // DECRC
+		e.restoreCursor()
+	case 'D': // IND
+		e.index()
+	case 'E': // NEL
+		e.x = 0
+		e.index()
+	case 'H': // HTS
+		e.tabs[e.x] = true
+	case 'M': // RI
+		e.reverseIndex()
+	case 'c': // RIS
+		e.reset()
+	}
+}
+
+// escapeIntermediate executes an escape sequence with intermediate bytes.
+func (e *vtEmulator) escapeIntermediate(final byte) {
+	switch e.intermediate[0] {
+	case '(', ')': // Designate G0 or G1 character set.
+		e.charsets[e.intermediate[0]-'('] = final == '0'
+	case '#':
+		if final == '8' { // DECALN: Fill the screen with "E".
+			for y := range e.rows {
+				for x := range e.cols {
+					e.cells[y][x] = vtCell{text: "E", width: 1}
+				}
+			}
+			e.top, e.bottom = 0, e.rows-1
+			e.moveTo(0, 0)
+		}
+	}
+}
+
+// osc executes an operating system command.
+func (e *vtEmulator) osc() {
+	command, text, _ := strings.Cut(string(e.str), ";")
+	if command == "0" || command == "2" {
+		e.title = text
+	}
+}
+
+// csiParams returns the parameters of the current control sequence.
+// Sub-parameters separated by colons are returned as additional parameters.
+// Omitted parameters are returned as -1.
+func (e *vtEmulator) csiParams() (params [][]int) {
+	if len(e.params) == 0 {
+		return nil
+	}
+	for param := range strings.SplitSeq(string(e.params), ";") {
+		var values []int
+		for sub := range strings.SplitSeq(param, ":") {
+			value, err := strconv.Atoi(sub)
+			if err != nil {
+				value = -1
+			}
+			values = append(values, min(value, 65535))
+		}
+		params = append(params, values)
+	}
+	return
+}
+
+// csi executes a control sequence with the given final byte.
+func (e *vtEmulator) csi(final byte) {
+	params := e.csiParams()
+	intermediate := e.intermediate
+	e.intermediate = e.intermediate[:0]
+	if len(intermediate) > 0 {
+		return // Sequences with intermediates (e.g. DECSCUSR) are ignored.
+	}
+
+	// param returns the parameter at the given index or the default value if
+	// it is omitted or 0.
+	param := func(index, def int) int {
+		if index >= len(params) || params[index][0] <= 0 {
+			return def
+		}
+		return params[index][0]
+	}
+
+	if e.private == '?' {
+		switch final {
+		case 'h', 'l':
+			for index := range params {
+				e.setPrivateMode(params[index][0], final == 'h')
+			}
+		}
+		return
+	} else if e.private == '>' {
+		if final == 'c' { // Secondary device attributes.
+			e.response = append(e.response, "\x1b[>0;10;1c"...)
+		}
+		return
+	} else if e.private != 0 {
+		return
+	}
+
+	switch final {
+	case '@': // ICH
+		e.insertCells(param(0, 1))
+	case 'A': // CUU
+		e.moveVertically(-param(0, 1))
+	case 'B', 'e': // CUD, VPR
+		e.moveVertically(param(0, 1))
+	case 'C', 'a': // CUF, HPR
+		e.x = min(e.x+param(0, 1), e.cols-1)
+		e.wrapNext = false
+	case 'D': // CUB
+		e.x = max(e.x-param(0, 1), 0)
+		e.wrapNext = false
+	case 'E': // CNL
+		e.moveVertically(param(0, 1))
+		e.x = 0
+	case 'F': // CPL
+		e.moveVertically(-param(0, 1))
+		e.x = 0
+	case 'G', '`': // CHA, HPA
+		e.x = min(param(0, 1)-1, e.cols-1)
+		e.wrapNext = false
+	case 'H', 'f': // CUP, HVP
+		e.moveTo(param(1, 1)-1, param(0, 1)-1)
+	case 'I': // CHT
+		e.tab(param(0, 1))
+	case 'J': // ED
+		switch param(0, 0) {
+		case 0:
+			e.erase(e.y, e.x, e.cols)
+			for y := e.y + 1; y < e.rows; y++ {
+				e.erase(y, 0, e.cols)
+			}
+		case 1:
+			for y := range e.y {
+				e.erase(y, 0, e.cols)
+			}
+			e.erase(e.y, 0, e.x+1)
+		case 2: // 3 would clear the scrollback buffer which we don't have.
+			for y := range e.rows {
+				e.erase(y, 0, e.cols)
+			}
+		}
+	case 'K': // EL
+		switch param(0, 0) {
+		case 0:
+			e.erase(e.y, e.x, e.cols)
+		case 1:
+			e.erase(e.y, 0, e.x+1)
+		case 2:
+			e.erase(e.y, 0, e.cols)
+		}
+	case 'L': // IL
+		if e.y >= e.top && e.y <= e.bottom {
+			e.scrollLines(e.y, e.bottom, -param(0, 1))
+			e.x, e.wrapNext = 0, false
+		}
+	case 'M': // DL
+		if e.y >= e.top && e.y <= e.bottom {
+			e.scrollLines(e.y, e.bottom, param(0, 1))
+			e.x, e.wrapNext = 0, false
+		}
+	case 'P': // DCH
+		e.deleteCells(param(0, 1))
+	case 'S': // SU
+		e.scrollUp(param(0, 1))
+	case 'T': // SD
+		e.scrollDown(param(0, 1))
+	case 'X': // ECH
+		e.erase(e.y, e.x, e.x+param(0, 1))
+	case 'Z': // CBT
+		e.tab(-param(0, 1))
+	case 'b': // REP
+		if e.lastChar != 0 {
+			for range min(param(0, 1), e.cols*e.rows) {
+				e.print(e.lastChar)
+			}
+		}
+	case 'c': // Primary device attributes.
+		if param(0, 0) == 0 {
+			e.response = append(e.response, "\x1b[?62;22c"...)
+		}
+	case 'd': // VPA
+		e.moveTo(e.x, param(0, 1)-1)
+	case 'g': // TBC
+		switch param(0, 0) {
+		case 0:
+			e.tabs[e.x] = false
+		case 3:
+			clear(e.tabs)
+		}
+	case 'h', 'l': // SM, RM
+		for index := range params {
+			if params[index][0] == 4 {
+				e.insertMode = final == 'h'
+			}
+		}
+	case 'm': // SGR
+		e.sgr(params)
+	case 'n': // DSR
+		switch param(0, 0) {
+		case 5:
+			e.response = append(e.response, "\x1b[0n"...)
+		case 6:
+			y := e.y
+			if e.originMode {
+				y -= e.top
+			}
+			e.response = append(e.response, "\x1b["+strconv.Itoa(y+1)+";"+strconv.Itoa(e.x+1)+"R"...)
+		}
+	case 'r': // DECSTBM
+		top, bottom := param(0, 1)-1, min(param(1, e.rows), e.rows)-1
+		if top < bottom {
+			e.top, e.bottom = top, bottom
+			e.moveTo(0, 0)
+		}
+	case 's': // SCOSC
+		e.saveCursor()
+	case 'u': // SCORC
+		e.restoreCursor()
+	}
+}
+
+// moveVertically moves the cursor up (if count is negative) or down without
+// scrolling. The cursor stops at the margins of the scroll region if it starts
+// within the region.
+func (e *vtEmulator) moveVertically(count int) {
+	top, bottom := 0, e.rows-1
+	if e.y >= e.top {
+		top = e.top
+	}
+	if e.y <= e.bottom {
+		bottom = e.bottom
+	}
+	e.y = min(max(e.y+count, top), bottom)
+	e.wrapNext = false
+}
+
+// setPrivateMode sets or resets a DEC private mode.
+func (e *vtEmulator) setPrivateMode(mode int, set bool) {
+	switch mode {
+	case 1:
+		e.appCursor = set
+	case 6:
+		e.originMode = set
+		e.moveTo(0, 0)
+	case 7:
+		e.autoWrap = set
+		e.wrapNext = false
+	case 25:
+		e.cursorVisible = set
+	case 47, 1047:
+		e.setAltScreen(set, mode == 1047 && set)
+	case 1048:
+		if set {
+			e.saveCursor()
+		} else {
+			e.restoreCursor()
+		}
+	case 1049:
+		if set {
+			e.saveCursor()
+			e.setAltScreen(true, true)
+		} else {
+			e.setAltScreen(false, false)
+			e.restoreCursor()
+		}
+	case vtMouseClick, vtMouseDrag, vtMouseMotion:
+		if set {
+			e.mouseMode = mode
+		} else if e.mouseMode == mode {
+			e.mouseMode = vtMouseNone
+		}
+	case 1006:
+		e.mouseSGR = set
+	case 2004:
+		e.bracketedPaste = set
+	}
+}
+
+// sgr executes a "select graphic rendition" sequence which changes the style
+// of printed characters.
+func (e *vtEmulator) sgr(params [][]int) {
+	if len(params) == 0 {
+		params = [][]int{{0}}
+	}
+	for index := 0; index < len(params); index++ {
+		param := max(params[index][0], 0)
+		switch {
+		case param == 0:
+			e.style = tcell.StyleDefault
+		case param == 1:
+			e.style = e.style.Bold(true)
+		case param == 2:
+			e.style = e.style.Dim(true)
+		case param == 3:
+			e.style = e.style.Italic(true)
+		case param == 4:
+			e.style = e.style.Underline(len(params[index]) < 2 || params[index][1] != 0)
+		case param == 5 || param == 6:
+			e.style = e.style.Blink(true)
+		case param == 7:
+			e.style = e.style.Reverse(true)
+		case param == 9:
+			e.style = e.style.StrikeThrough(true)
+		case param == 21:
+			e.style = e.style.Underline(true)
+		case param == 22:
+			e.style = e.style.Bold(false).Dim(false)
+		case param == 23:
+			e.style = e.style.Italic(false)
+		case param == 24:
+			e.style = e.style.Underline(false)
+		case param == 25:
+			e.style = e.style.Blink(false)
+		case param == 27:
+			e.style = e.style.Reverse(false)
+		case param == 29:
+			e.style = e.style.StrikeThrough(false)
+		case param >= 30 && param <= 37:
+			e.style = e.style.Foreground(tcell.PaletteColor(param - 30))
+		case param == 38 || param == 48:
+			var color tcell.Color
+			color, index = vtExtendedColor(params, index)
+			if param == 38 {
+				e.style = e.style.Foreground(color)
+			} else {
+				e.style = e.style.Background(color)
+			}
+		case param == 39:
+			e.style = e.style.Foreground(tcell.ColorDefault)
+		case param >= 40 && param <= 47:
+			e.style = e.style.Background(tcell.PaletteColor(param - 40))
+		case param == 49:
+			e.style = e.style.Background(tcell.ColorDefault)
+		case param >= 90 && param <= 97:
+			e.style = e.style.Foreground(tcell.PaletteColor(param - 90 + 8))
+		case param >= 100 && param <= 107:
+			e.style = e.style.Background(tcell.PaletteColor(param - 100 + 8))
+		}
+	}
+}
+
+// vtExtendedColor parses an extended color of an SGR sequence (a 256 color
+// palette index or an RGB color), starting at the parameter with the given
+// index which is 38 or 48. It returns the color and the index of the last
+// parameter which belongs to the color. Both colons and semicolons are
+// accepted as separators.
+func vtExtendedColor(params [][]int, index int) (tcell.Color, int) {
+	values := params[index][1:]
+	if len(values) == 0 {
+		// Semicolon-separated parameters.
+		for _, param := range params[index+1:] {
+			values = append(values, param[0])
+		}
+		switch {
+		case len(values) >= 2 && values[0] == 5:
+			index += 2
+		case len(values) >= 4 && values[0] == 2:
+			index += 4
+			values = values[:4]
+		default:
+			return tcell.ColorDefault, len(params) - 1
+		}
+	} else if len(values) == 5 && values[0] == 2 {
+		values = append(values[:1], values[2:]...) // Skip the color space.
+	}
+	switch {
+	case len(values) >= 2 && values[0] == 5 && values[1] >= 0 && values[1] <= 255:
+		return tcell.PaletteColor(values[1]), index
+	case len(values) >= 4 && values[0] == 2:
+		return tcell.NewRGBColor(int32(max(values[1], 0)&255), int32(max(values[2], 0)&255), int32(max(values[3], 0)&255)), index
+	}
+	return tcell.ColorDefault, index
+}
+
+// key returns the bytes sent to the application for the given key event, or
+// nil if the key is not supported.
+func (e *vtEmulator) key(event *tcell.EventKey) []byte {
+	modifiers := event.Modifiers()
+	var prefix string
+	if modifiers&tcell.ModAlt != 0 {
+		prefix = "\x1b"
+	}
+	key := event.Key()
+	switch {
+	case key == tcell.KeyRune:
+		return []byte(prefix + string(event.Rune()))
+	case key < tcell.KeyRune: // ASCII control characters.
+		return []byte(prefix + string(rune(key)))
+	}
+
+	// The modifier parameter of xterm's sequences.
+	var modifier int
+	if modifiers&tcell.ModShift != 0 {
+		modifier |= 1
+	}
+	if modifiers&tcell.ModAlt != 0 {
+		modifier |= 2
+	}
+	if modifiers&tcell.ModCtrl != 0 {
+		modifier |= 4
+	}
+
+	// Cursor keys and others with a final letter.
+	if final, ok := map[tcell.Key]byte{
+		tcell.KeyUp:    'A',
+		tcell.KeyDown:  'B',
+		tcell.KeyRight: 'C',
+		tcell.KeyLeft:  'D',
+		tcell.KeyHome:  'H',
+		tcell.KeyEnd:   'F',
+		tcell.KeyF1:    'P',
+		tcell.KeyF2:    'Q',
+		tcell.KeyF3:    'R',
+		tcell.KeyF4:    'S',
+	}[key]; ok {
+		switch {
+		case modifier != 0:
+			return []byte("\x1b[1;" + strconv.Itoa(modifier+1) + string(final))
+		case key >= tcell.KeyF1 && key <= tcell.KeyF4 || e.appCursor:
+			return []byte("\x1bO" + string(final))
+		default:
+			return []byte("\x1b[" + string(final))
+		}
+	}
+
+	// Keys with a numeric code.
+	if code, ok := map[tcell.Key]int{
+		tcell.KeyInsert: 2,
+		tcell.KeyDelete: 3,
+		tcell.KeyPgUp:   5,
+		tcell.KeyPgDn:   6,
+		tcell.KeyF5:     15,
+		tcell.KeyF6:     17,
+		tcell.KeyF7:     18,
+		tcell.KeyF8:     19,
+		tcell.KeyF9:     20,
+		tcell.KeyF10:    21,
+		tcell.KeyF11:    23,
+		tcell.KeyF12:    24,
+	}[key]; ok {
+		if modifier != 0 {
+			return []byte("\x1b[" + strconv.Itoa(code) + ";" + strconv.Itoa(modifier+1) + "~")
+		}
+		return []byte("\x1b[" + strconv.Itoa(code) + "~")
+	}
+
+	if key == tcell.KeyBacktab {
+		return []byte("\x1b[Z")
+	}
+	return nil
+}
+
+// mouse returns the bytes which report a mouse event to the application, or
+// nil if the event is not reported. The button is 0 (left), 1 (middle), 2
+// (right), 3 (no button, for motion), or 64 and above for the mouse wheel. The
+// coordinates are 0-based cell positions.
+func (e *vtEmulator) mouse(button int, release, motion bool, modifiers tcell.ModMask, x, y int) []byte {
+	code := button
+	if modifiers&tcell.ModShift != 0 {
+		code |= 4
+	}
+	if modifiers&tcell.ModAlt != 0 {
+		code |= 8
+	}
+	if modifiers&tcell.ModCtrl != 0 {
+		code |= 16
+	}
+	if motion {
+		code |= 32
+	}
+	if e.mouseSGR {
+		final := "M"
+		if release {
+			final = "m"
+		}
+		return []byte("\x1b[<" + strconv.Itoa(code) + ";" + strconv.Itoa(x+1) + ";" + strconv.Itoa(y+1) + final)
+	}
+	if release {
+		code = code&^3 | 3
+	}
+	if x+33 > 255 || y+33 > 255 {
+		return nil // Not representable in the legacy encoding.
+	}
+	return []byte{0x1b, '[', 'M', byte(code + 32), byte(x + 33), byte(y + 33)}
+}
@@ -0,0 +1,748 @@
+package tview
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// Window edges, used to determine how a window is resized.
+const (
+	windowEdgeLeft = 1 << iota
+	windowEdgeRight
+	windowEdgeBottom
+)
+
+// Window is a movable, resizable frame around a primitive (its content) which
+// is managed by a [WindowManager]. The window's border and title (see
+// [Box.SetTitle]) serve as its title bar. The title bar also contains buttons
+// to minimize and to maximize or restore the window.
+//
+// Windows can be dragged with the mouse by their title bar and resized by
+// dragging their left, right, or bottom edges. Double-clicking the title bar
+// toggles between the maximized and the normal state.
+type Window struct {
+	*Box
+
+	// The primitive shown in the window.
+	content Primitive
+
+	// The position and size of the window, relative to the window manager's
+	// inner rectangle, in the normal state.
+	x, y, width, height int
+
+	// The minimum size of the window.
+	minWidth, minHeight int
+
+	// Whether or not the user may move or resize the window.
+	movable, resizable bool
+
+	// Whether or not the window is maximized or minimized.
+	maximized, minimized bool
+
+	// Whether or not the title bar buttons are shown.
+	showButtons bool
+}
+
+// NewWindow returns a new window with the given content. Windows are 40x10
+// cells large by default. Use [Window.SetPosition] and [Window.SetSize] to
+// change that.
+func NewWindow(content Primitive) *Window {
+	w := &Window{
+		Box:         NewBox(),
+		content:     content,
+		width:       40,
+		height:      10,
+		minWidth:    12,
+		minHeight:   3,
+		movable:     true,
+		resizable:   true,
+		showButtons: true,
+	}
+	w.SetBorder(true)
+	w.Box.Primitive = w
+	return w
+}
+
+// SetContent sets the primitive shown in the window.
+func (w *Window) SetContent(content Primitive) *Window {
+	w.content = content
+	return w
+}
+
+// GetContent returns the primitive shown in the window.
+func (w *Window) GetContent() Primitive {
+	return w.content
+}
+
+// SetPosition sets the position of the window's top left corner relative to
+// the top left corner of the window manager's inner rectangle.
+func (w *Window) SetPosition(x, y int) *Window {
+	w.x, w.y = x, y
+	return w
+}
+
+// GetPosition returns the position of the window's top left corner relative
+// to the top left corner of the window manager's inner rectangle. If the
+// window is maximized or minimized, the position of its normal state is
+// returned.
+func (w *Window) GetPosition() (x, y int) {
+	return w.x, w.y
+}
+
+// SetSize sets the width and height of the window, including its border. The
+// size will not be smaller than the minimum size (see [Window.SetMinSize]).
+func (w *Window) SetSize(width, height int) *Window {
+	w.width, w.height = max(width, w.minWidth), max(height, w.minHeight)
+	return w
+}
+
+// GetSize returns the width and height of the window, including its border.
+// If the window is maximized or minimized, the size of its normal state is
+// returned.
+func (w *Window) GetSize() (width, height int) {
+	return w.width, w.height
+}
+
+// SetMinSize sets the minimum width and height the user can resize the window
+// to. The default is 12x3.
+func (w *Window) SetMinSize(width, height int) *Window {
+	w.minWidth, w.minHeight = max(width, 1), max(height, 1)
+	w.width, w.height = max(w.width, w.minWidth), max(w.height, w.minHeight)
+	return w
+}
+
+// SetMovable sets whether or not the user can move the window by dragging its
+// title bar.
+func (w *Window) SetMovable(movable bool) *Window {
+	w.movable = movable
+	return w
+}
+
+// SetResizable sets whether or not the user can resize the window by dragging
+// its edges.
+func (w *Window) SetResizable(resizable bool) *Window {
+	w.resizable = resizable
+	return w
+}
+
+// ShowButtons sets whether or not the buttons to minimize and to maximize or
+// restore the window are shown in the title bar.
+func (w *Window) ShowButtons(show bool) *Window {
+	w.showButtons = show
+	return w
+}
+
+// Maximize makes the window fill its window manager's entire inner rectangle.
+func (w *Window) Maximize() *Window {
+	w.maximized = true
+	w.minimized = false
+	return w
+}
+
+// Minimize hides the window. Minimized windows are shown as buttons at the
+// bottom of the window manager. Clicking them restores the window.
+func (w *Window) Minimize() *Window {
+	w.minimized = true
+	return w
+}
+
+// Restore returns the window from the minimized or maximized state. If it was
+// minimized from the maximized state, it is maximized again.
+func (w *Window) Restore() *Window {
+	if w.minimized {
+		w.minimized = false
+	} else {
+		w.maximized = false
+	}
+	return w
+}
+
+// IsMaximized returns whether or not the window is maximized. Note that a
+// window may be both maximized and minimized.
+func (w *Window) IsMaximized() bool {
+	return w.maximized
+}
+
+// IsMinimized returns whether or not the window is minimized.
+func (w *Window) IsMinimized() bool {
+	return w.minimized
+}
+
+// buttons returns the text of the title bar buttons, an empty string if there
+// are none.
+func (w *Window) buttons() string {
+	if !w.showButtons {
+		return ""
+	}
+	if w.maximized {
+		return "[_][↕]"
+	}
+	return "[_][↑]"
+}
+
+// buttonAt returns the index of the title bar button at the given screen
+// position: 0 for the minimize button, 1 for the maximize/restore button. -1
+// is returned if there is no button at that position.
+func (w *Window) buttonAt(x, y int) int {
+	buttons := w.buttons()
+	rectX, rectY, width, _ := w.GetRect()
+	if buttons == "" || y != rectY {
+		return -1
+	}
+	start := rectX + width - 1 - TaggedStringWidth(Escape(buttons))
+	if x < start || x >= rectX+width-1 || start <= rectX {
+		return -1
+	}
+	return (x - start) / 3
+}
+
+// edgesAt returns the edges (a combination of windowEdge* flags) which can be
+// dragged at the given screen position to resize the window.
+func (w *Window) edgesAt(x, y int) (edges int) {
+	rectX, rectY, width, height := w.GetRect()
+	if !w.resizable || w.maximized || y <= rectY || y >= rectY+height || x < rectX || x >= rectX+width {
+		return 0
+	}
+	if x == rectX {
+		edges |= windowEdgeLeft
+	}
+	if x == rectX+width-1 {
+		edges |= windowEdgeRight
+	}
+	if y == rectY+height-1 {
+		edges |= windowEdgeBottom
+	}
+	return
+}
+
+// Draw draws this primitive onto the screen.
+func (w *Window) Draw(screen tcell.Screen) {
+	w.Box.DrawForSubclass(screen, w)
+
+	// Draw the title bar buttons.
+	if buttons := w.buttons(); buttons != "" {
+		x, y, width, _ := w.GetRect()
+		if start := x + width - 1 - TaggedStringWidth(Escape(buttons)); start > x {
+			printWithStyle(screen, Escape(buttons), start, y, 0, width-2, AlignLeft, w.borderStyle, false)
+		}
+	}
+
+	// Draw the content.
+	if w.content != nil {
+		x, y, width, height := w.GetInnerRect()
+		w.content.SetRect(x, y, width, height)
+		w.content.Draw(screen)
+	}
+}
+
+// Focus is called when this primitive receives focus.
+func (w *Window) Focus(delegate func(p Primitive)) {
+	if w.content != nil && delegate != nil {
+		delegate(w.content)
+		return
+	}
+	w.Box.Focus(delegate)
+}
+
+// focusChain implements the [Primitive]'s focusChain method.
+func (w *Window) focusChain(chain *[]Primitive) bool {
+	if w.content != nil {
+		if hasFocus := w.content.focusChain(chain); hasFocus {
+			if chain != nil {
+				*chain = append(*chain, w)
+			}
+			return true
+		}
+	}
+	return w.Box.focusChain(chain)
+}
+
+// InputHandler returns the handler for this primitive.
+func (w *Window) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return w.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if w.content != nil && w.content.HasFocus() {
+			if handler := w.content.InputHandler(); handler != nil {
+				handler(event, setFocus)
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive. It only handles
+// events for the window's content. Moving and resizing windows is handled by
+// the [WindowManager].
+func (w *Window) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return w.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if !w.InRect(event.Position()) {
+			return false, nil
+		}
+		if w.content != nil {
+			consumed, capture = w.content.MouseHandler()(action, event, setFocus)
+			if consumed {
+				return
+			}
+		}
+
+		// Clicking anywhere on the window focuses it.
+		if action == MouseLeftDown {
+			setFocus(w)
+			consumed = true
+		}
+		return
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (w *Window) PasteHandler() func(pastedText string, setFocus func(p Primitive)) {
+	return w.WrapPasteHandler(func(pastedText string, setFocus func(p Primitive)) {
+		if w.content != nil && w.content.HasFocus() {
+			if handler := w.content.PasteHandler(); handler != nil {
+				handler(pastedText, setFocus)
+			}
+		}
+	})
+}
+
+// WindowManager is a container for [Window]s which can overlap. Windows are
+// drawn in their z-order, the top window is drawn last. Clicking on a window
+// raises it to the top and gives it focus. Conversely, when a window receives
+// focus in any other way, it is raised to the top.
+//
+// Minimized windows are shown as buttons in the bottom row of the window
+// manager. Clicking a button restores its window.
+//
+// The following keys are available to switch between windows:
+//
+//   - F6: Raise and focus the window at the bottom of the z-order, i.e. cycle
+//     through all windows.
+//   - Shift-F6: Raise and focus the window below the top window.
+//
+// Minimized windows are skipped.
+type WindowManager struct {
+	*Box
+
+	// The windows in z-order, the last window is on top.
+	windows []*Window
+
+	// The key which switches to the next window.
+	switchKey tcell.Key
+
+	// The style of the buttons of minimized windows.
+	minimizedStyle tcell.Style
+
+	// The window currently being moved or resized, if any.
+	dragging *Window
+
+	// The edges being dragged (windowEdge* flags), 0 if the window is being
+	// moved.
+	dragEdges int
+
+	// The mouse position and window rectangle when dragging started.
+	dragX, dragY                                   int
+	dragStartX, dragStartY, dragStartW, dragStartH int
+
+	// The positions of the buttons of minimized windows as determined during
+	// the last call to Draw(): the window and the start and end (exclusive) x
+	// coordinate.
+	minimizedButtons []minimizedButton
+}
+
+// minimizedButton describes the position of a minimized window's button.
+type minimizedButton struct {
+	window     *Window
+	start, end int
+}
+
+// NewWindowManager returns a new window manager without any windows.
+func NewWindowManager() *WindowManager {
+	m := &WindowManager{
+		Box:            NewBox(),
+		switchKey:      tcell.KeyF6,
+		minimizedStyle: tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+	}
+	m.Box.Primitive = m
+	return m
+}
+
+// SetSwitchKey sets the key which switches between windows. The default is
+// F6.
+func (m *WindowManager) SetSwitchKey(key tcell.Key) *WindowManager {
+	m.switchKey = key
+	return m
+}
+
+// SetMinimizedStyle sets the style of the buttons of minimized windows.
+func (m *WindowManager) SetMinimizedStyle(style tcell.Style) *WindowManager {
+	m.minimizedStyle = style
+	return m
+}
+
+// AddWindow adds a window on top of all other windows. If the window was
+// already added, it is raised to the top.
+func (m *WindowManager) AddWindow(window *Window) *WindowManager {
+	m.removeWindow(window)
+	m.windows = append(m.windows, window)
+	return m
+}
+
+// RemoveWindow removes the given window from the window manager.
+func (m *WindowManager) RemoveWindow(window *Window) *WindowManager {
+	m.removeWindow(window)
+	return m
+}
+
+// removeWindow removes the given window from the z-order. It returns true if
+// the window was found.
+func (m *WindowManager) removeWindow(window *Window) bool {
+	for index, w := range m.windows {
+		if w == window {
+			m.windows = append(m.windows[:index], m.windows[index+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// GetWindows returns all windows in their z-order, the top window last.
+func (m *WindowManager) GetWindows() []*Window {
+	return append([]*Window(nil), m.windows...)
+}
+
+// GetWindowCount returns the number of windows.
+func (m *WindowManager) GetWindowCount() int {
+	return len(m.windows)
+}
+
+// RaiseWindow moves the given window to the top of the z-order. This does not
+// change the focus. Use [Application.SetFocus] to give the window focus.
+func (m *WindowManager) RaiseWindow(window *Window) *WindowManager {
+	if m.removeWindow(window) {
+		m.windows = append(m.windows, window)
+	}
+	return m
+}
+
+// GetTopWindow returns the top-most window which is not minimized or nil if
+// there is no such window.
+func (m *WindowManager) GetTopWindow() *Window {
+	for index := len(m.windows) - 1; index >= 0; index-- {
+		if !m.windows[index].minimized {
+			return m.windows[index]
+		}
+	}
+	return nil
+}
+
+// Clear removes all windows.
+func (m *WindowManager) Clear() *WindowManager {
+	m.windows = nil
+	return m
+}
+
+// layout sets the screen rectangle of all windows.
+func (m *WindowManager) layout() {
+	x, y, width, height := m.GetInnerRect()
+	for _, window := range m.windows {
+		if window.minimized {
+			continue
+		}
+		if window.maximized {
+			window.SetRect(x, y, width, height-m.taskbarHeight())
+			continue
+		}
+		window.SetRect(x+window.x, y+window.y, window.width, window.height)
+	}
+}
+
+// taskbarHeight returns the number of rows reserved for the buttons of
+// minimized windows (0 or 1).
+func (m *WindowManager) taskbarHeight() int {
+	for _, window := range m.windows {
+		if window.minimized {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Draw draws this primitive onto the screen.
+func (m *WindowManager) Draw(screen tcell.Screen) {
+	m.Box.DrawForSubclass(screen, m)
+	x, y, width, height := m.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// The focused window moves to the top.
+	for _, window := range m.windows {
+		if !window.minimized && window.HasFocus() {
+			m.RaiseWindow(window)
+			break
+		}
+	}
+
+	// Draw the windows, clipped to our inner rectangle.
+	m.layout()
+	clipped := &clippedScreen{Screen: screen, x: x, y: y, width: width, height: height}
+	for _, window := range m.windows {
+		if !window.minimized {
+			window.Draw(clipped)
+		}
+	}
+
+	// Draw the buttons of minimized windows.
+	m.minimizedButtons = m.minimizedButtons[:0]
+	if m.taskbarHeight() == 0 {
+		return
+	}
+	pos, row := x, y+height-1
+	for column := x; column < x+width; column++ {
+		screen.SetContent(column, row, ' ', nil, tcell.StyleDefault.Background(m.backgroundColor))
+	}
+	for _, window := range m.windows {
+		if !window.minimized || pos >= x+width {
+			continue
+		}
+		title := window.GetTitle()
+		if title == "" {
+			title = "Window"
+		}
+		_, _, printed := printWithStyle(screen, " "+title+" ", pos, row, 0, min(x+width-pos, 20), AlignLeft, m.minimizedStyle, false)
+		m.minimizedButtons = append(m.minimizedButtons, minimizedButton{window: window, start: pos, end: pos + printed})
+		pos += printed + 1
+	}
+}
+
+// Focus is called when this primitive receives focus.
+func (m *WindowManager) Focus(delegate func(p Primitive)) {
+	if top := m.GetTopWindow(); top != nil && delegate != nil {
+		delegate(top)
+		return
+	}
+	m.Box.Focus(delegate)
+}
+
+// focusChain implements the [Primitive]'s focusChain method.
+func (m *WindowManager) focusChain(chain *[]Primitive) bool {
+	for _, window := range m.windows {
+		if hasFocus := window.focusChain(chain); hasFocus {
+			if chain != nil {
+				*chain = append(*chain, m)
+			}
+			return true
+		}
+	}
+	return m.Box.focusChain(chain)
+}
+
+// focusWindow raises the given window and gives it focus.
+func (m *WindowManager) focusWindow(window *Window, setFocus func(p Primitive)) {
+	window.minimized = false
+	m.RaiseWindow(window)
+	setFocus(window)
+}
+
+// setMinimized minimizes or restores the given window. If the window had
+// focus, the focus moves to the next window.
+func (m *WindowManager) setMinimized(window *Window, setFocus func(p Primitive)) {
+	hadFocus := window.HasFocus()
+	window.Minimize()
+	if hadFocus {
+		if top := m.GetTopWindow(); top != nil {
+			setFocus(top)
+		} else {
+			setFocus(m)
+		}
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (m *WindowManager) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return m.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		// Switch windows.
+		if event.Key() == m.switchKey && len(m.windows) > 0 {
+			var candidates []*Window
+			for _, window := range m.windows {
+				if !window.minimized {
+					candidates = append(candidates, window)
+				}
+			}
+			if len(candidates) > 1 {
+				if event.Modifiers()&tcell.ModShift != 0 {
+					// Move the top window to the bottom.
+					top := candidates[len(candidates)-1]
+					m.removeWindow(top)
+					m.windows = append([]*Window{top}, m.windows...)
+					m.focusWindow(candidates[len(candidates)-2], setFocus)
+				} else {
+					m.focusWindow(candidates[0], setFocus)
+				}
+			} else if len(candidates) == 1 && !candidates[0].HasFocus() {
+				m.focusWindow(candidates[0], setFocus)
+			}
+			return
+		}
+
+		// Pass the event on to the focused window.
+		for _, window := range m.windows {
+			if window.HasFocus() {
+				if handler := window.InputHandler(); handler != nil {
+					handler(event, setFocus)
+				}
+				return
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (m *WindowManager) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return m.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		x, y := event.Position()
+
+		// Move or resize a window.
+		if m.dragging != nil {
+			switch action {
+			case MouseMove:
+				m.drag(x, y)
+				return true, m
+			case MouseLeftUp:
+				m.dragging = nil
+				return true, nil
+			}
+		}
+
+		if !m.InRect(x, y) {
+			return false, nil
+		}
+
+		// Buttons of minimized windows.
+		_, rectY, _, height := m.GetInnerRect()
+		if y == rectY+height-1 && len(m.minimizedButtons) > 0 {
+			for _, button := range m.minimizedButtons {
+				if x >= button.start && x < button.end {
+					if action == MouseLeftClick {
+						m.focusWindow(button.window, setFocus)
+					}
+					return true, nil
+				}
+			}
+		}
+
+		// Find the top-most window at the mouse position.
+		var window *Window
+		for index := len(m.windows) - 1; index >= 0; index-- {
+			if w := m.windows[index]; !w.minimized && w.InRect(x, y) {
+				window = w
+				break
+			}
+		}
+		if window == nil {
+			return false, nil
+		}
+
+		// Raise the window when it's clicked.
+		if action == MouseLeftDown && window != m.GetTopWindow() {
+			m.RaiseWindow(window)
+		}
+
+		// Title bar and edges.
+		_, windowY, _, _ := window.GetRect()
+		if y == windowY {
+			switch action {
+			case MouseLeftDown:
+				if !window.HasFocus() {
+					setFocus(window)
+				}
+				if window.buttonAt(x, y) < 0 && window.movable && !window.maximized {
+					m.startDrag(window, x, y, 0)
+					return true, m
+				}
+			case MouseLeftClick:
+				switch window.buttonAt(x, y) {
+				case 0:
+					m.setMinimized(window, setFocus)
+				case 1:
+					if window.maximized {
+						window.Restore()
+					} else {
+						window.Maximize()
+					}
+				}
+			case MouseLeftDoubleClick:
+				if window.buttonAt(x, y) < 0 {
+					if window.maximized {
+						window.Restore()
+					} else {
+						window.Maximize()
+					}
+				}
+			}
+			return true, nil
+		}
+		if edges := window.edgesAt(x, y); edges != 0 {
+			if action == MouseLeftDown {
+				if !window.HasFocus() {
+					setFocus(window)
+				}
+				m.startDrag(window, x, y, edges)
+				return true, m
+			}
+			return true, nil
+		}
+
+		// Everything else goes to the window.
+		consumed, capture = window.MouseHandler()(action, event, setFocus)
+		return true, capture
+	})
+}
+
+// startDrag starts moving (if "edges" is 0) or resizing the given window.
+func (m *WindowManager) startDrag(window *Window, x, y, edges int) {
+	m.dragging = window
+	m.dragEdges = edges
+	m.dragX, m.dragY = x, y
+	m.dragStartX, m.dragStartY, m.dragStartW, m.dragStartH = window.x, window.y, window.width, window.height
+}
+
+// drag moves or resizes the window being dragged according to the current
+// mouse position.
+func (m *WindowManager) drag(x, y int) {
+	window := m.dragging
+	_, _, width, height := m.GetInnerRect()
+	dx, dy := x-m.dragX, y-m.dragY
+
+	// Move.
+	if m.dragEdges == 0 {
+		// Keep a part of the title bar visible.
+		window.x = max(min(m.dragStartX+dx, width-3), 3-window.width)
+		window.y = max(min(m.dragStartY+dy, height-1-m.taskbarHeight()), 0)
+		return
+	}
+
+	// Resize.
+	if m.dragEdges&windowEdgeRight != 0 {
+		window.width = max(m.dragStartW+dx, window.minWidth)
+	}
+	if m.dragEdges&windowEdgeLeft != 0 {
+		newWidth := max(m.dragStartW-dx, window.minWidth)
+		window.x = m.dragStartX + m.dragStartW - newWidth
+		window.width = newWidth
+	}
+	if m.dragEdges&windowEdgeBottom != 0 {
+		window.height = max(m.dragStartH+dy, window.minHeight)
+	}
+}
+
+// PasteHandler returns the handler for this primitive.
+func (m *WindowManager) PasteHandler() func(pastedText string, setFocus func(p Primitive)) {
+	return m.WrapPasteHandler(func(pastedText string, setFocus func(p Primitive)) {
+		for _, window := range m.windows {
+			if window.HasFocus() {
+				if handler := window.PasteHandler(); handler != nil {
+					handler(pastedText, setFocus)
+				}
+				return
+			}
+		}
+	})
+}